@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 
 	"github.com/MyCarrier-DevOps/go-gitsemver/internal/calculator"
 	"github.com/MyCarrier-DevOps/go-gitsemver/internal/config"
@@ -14,6 +15,19 @@ import (
 	"github.com/MyCarrier-DevOps/go-gitsemver/internal/strategy"
 
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// --explain-format values.
+const (
+	explainFormatText = "text"
+	explainFormatJSON = "json"
+)
+
+// --show-config values.
+const (
+	showConfigMerged    = "merged"
+	showConfigEffective = "effective"
 )
 
 // configFileNames lists the files searched for configuration in order.
@@ -25,26 +39,51 @@ var configFileNames = []string{
 	"go-gitsemver.yml",
 }
 
-func calculateRunE(_ *cobra.Command, _ []string) error {
+func calculateRunE(cmd *cobra.Command, _ []string) error {
+	if err := validatePublish(); err != nil {
+		return err
+	}
+	if err := validateShowConfig(); err != nil {
+		return err
+	}
+	if err := validateExplainFormat(); err != nil {
+		return err
+	}
+	if err := validateExplainGraph(); err != nil {
+		return err
+	}
+
 	// 1. Open repository.
 	repo, err := git.Open(flagPath)
 	if err != nil {
 		return fmt.Errorf("opening repository: %w", err)
 	}
 
-	// 2. Load configuration.
-	cfg, err := loadConfig(repo.WorkingDirectory())
+	// 2. Load configuration layers.
+	builder, err := configBuilder(repo.WorkingDirectory())
+	if err != nil {
+		return fmt.Errorf("loading configuration: %w", err)
+	}
+	cfg, sources, err := builder.BuildAnnotated()
 	if err != nil {
 		return fmt.Errorf("loading configuration: %w", err)
 	}
 
 	// 3. Show config mode — print and exit.
-	if flagShowConfig {
-		return showConfig(cfg)
+	if flagShowConfig == showConfigMerged {
+		return showConfig(cfg, sources)
+	}
+	if flagShowConfig == showConfigEffective && flagBranch != "" {
+		// The branch name alone selects the config; it need not exist.
+		ec, err := cfg.GetEffectiveConfiguration(flagBranch)
+		if err != nil {
+			return fmt.Errorf("resolving branch configuration: %w", err)
+		}
+		return showEffectiveConfig(flagBranch, ec, sources)
 	}
 
 	// 4. Build context.
-	store := git.NewRepositoryStore(repo)
+	store := git.NewRepositoryStore(repo).WithContext(commandContext(cmd))
 	ctx, err := configctx.NewContext(store, repo, cfg, configctx.Options{
 		TargetBranch: flagBranch,
 		CommitID:     flagCommit,
@@ -58,31 +97,60 @@ func calculateRunE(_ *cobra.Command, _ []string) error {
 	if err != nil {
 		return fmt.Errorf("resolving branch configuration: %w", err)
 	}
+	if flagShowConfig == showConfigEffective {
+		return showEffectiveConfig(ctx.CurrentBranch.FriendlyName(), ec, sources)
+	}
 
-	// 6. Calculate version.
+	// 6. Calculate version. Publishing needs the explanation for its summary.
 	strategies := strategy.AllStrategies(store)
 	calc := calculator.NewNextVersionCalculator(store, strategies)
-	result, err := calc.Calculate(ctx, ec, flagExplain)
+	result, err := calc.Calculate(ctx, ec, explaining() || flagPublish != "")
 	if err != nil {
 		return fmt.Errorf("calculating version: %w", err)
 	}
 
-	// 7. Write explain output to stderr if requested.
-	if flagExplain {
-		if err := output.WriteExplanation(os.Stderr, result); err != nil {
+	// 7. Write explain output and history graph to stderr if requested.
+	if explaining() {
+		if err := writeExplanation(result); err != nil {
 			return fmt.Errorf("writing explanation: %w", err)
 		}
 	}
+	if flagExplainGraph != "" {
+		if err := writeHistoryGraph(calc, ctx, ec, result); err != nil {
+			return fmt.Errorf("writing history graph: %w", err)
+		}
+	}
+
+	// 8. Publish to GitHub if requested.
+	if flagPublish != "" {
+		publisher, err := localPublisher(commandContext(cmd), repo)
+		if err != nil {
+			return err
+		}
+		if err := publishVersion(commandContext(cmd), publisher, ctx.CurrentCommit.Sha, result); err != nil {
+			return fmt.Errorf("publishing version: %w", err)
+		}
+	}
 
-	// 8. Compute output variables.
+	// 9. Compute output variables.
 	vars := output.GetVariables(result.Version, ec)
 
-	// 9. Write output.
+	// 10. Write output.
 	return writeOutput(vars)
 }
 
 // loadConfig loads configuration from a file or defaults.
 func loadConfig(workDir string) (*config.Config, error) {
+	builder, err := configBuilder(workDir)
+	if err != nil {
+		return nil, err
+	}
+	return builder.Build()
+}
+
+// configBuilder returns a Builder holding the config file and the files it
+// extends, bases first.
+func configBuilder(workDir string) (*config.Builder, error) {
 	builder := config.NewBuilder()
 
 	configPath := flagConfig
@@ -91,14 +159,45 @@ func loadConfig(workDir string) (*config.Config, error) {
 	}
 
 	if configPath != "" {
-		userCfg, err := config.LoadFromFile(configPath)
+		layers, err := config.LoadLayers(configPath, nil)
 		if err != nil {
 			return nil, err
 		}
-		builder.Add(userCfg)
+		addLayers(builder, layers)
 	}
 
-	return builder.Build()
+	if err := addOverrideLayers(builder); err != nil {
+		return nil, err
+	}
+	return builder, nil
+}
+
+// addLayers adds configuration file layers to builder, reporting deprecated
+// syntax in them on stderr.
+func addLayers(builder *config.Builder, layers []config.Layer) {
+	for _, l := range layers {
+		for _, w := range l.Warnings {
+			fmt.Fprintln(os.Stderr, issueLine(l.Source, w))
+		}
+	}
+	builder.AddLayers(layers)
+}
+
+// addOverrideLayers adds GITSEMVER_* environment variables and then --set
+// flags as the final configuration layers.
+func addOverrideLayers(builder *config.Builder) error {
+	env, err := config.ParseOverrides(config.EnvOverrides(os.Environ()))
+	if err != nil {
+		return fmt.Errorf("environment override: %w", err)
+	}
+	builder.AddLayer("env", env)
+
+	set, err := config.ParseOverrides(flagSet)
+	if err != nil {
+		return fmt.Errorf("--set: %w", err)
+	}
+	builder.AddLayer("--set", set)
+	return nil
 }
 
 // findConfigFile searches for a config file in the working directory.
@@ -112,9 +211,82 @@ func findConfigFile(dir string) string {
 	return ""
 }
 
-// showConfig prints the effective configuration as JSON.
-func showConfig(cfg *config.Config) error {
-	data, err := json.MarshalIndent(cfg, "", "  ")
+// annotatedConfig is the --show-config output: the configuration plus the
+// layer that set each value, keyed by YAML path.
+type annotatedConfig struct {
+	*config.Config
+	Sources config.Sources `json:"Sources,omitempty"`
+}
+
+// validateExplainFormat checks the --explain-format flag value.
+func validateExplainFormat() error {
+	switch flagExplainFormat {
+	case explainFormatText, explainFormatJSON:
+		return nil
+	}
+	return fmt.Errorf("invalid --explain-format value %q, expected %q or %q", flagExplainFormat, explainFormatText, explainFormatJSON)
+}
+
+// explaining reports whether explain output was requested. --explain-format
+// json implies --explain.
+func explaining() bool {
+	return flagExplain || flagExplainFormat == explainFormatJSON
+}
+
+// writeExplanation writes the explain output to stderr in the
+// --explain-format format.
+func writeExplanation(result calculator.VersionResult) error {
+	if flagExplainFormat == explainFormatJSON {
+		return output.WriteExplanationJSON(os.Stderr, result)
+	}
+	return output.WriteExplanation(os.Stderr, result)
+}
+
+// validateExplainGraph checks the --explain-graph flag value.
+func validateExplainGraph() error {
+	switch flagExplainGraph {
+	case "", output.GraphFormatDOT, output.GraphFormatMermaid:
+		return nil
+	}
+	return fmt.Errorf("invalid --explain-graph value %q, expected %q or %q", flagExplainGraph, output.GraphFormatDOT, output.GraphFormatMermaid)
+}
+
+// writeHistoryGraph writes the commit graph behind result to stderr in the
+// --explain-graph format.
+func writeHistoryGraph(
+	calc *calculator.NextVersionCalculator,
+	ctx *configctx.GitVersionContext,
+	ec config.EffectiveConfiguration,
+	result calculator.VersionResult,
+) error {
+	graph, err := calc.HistoryGraph(ctx, ec, result)
+	if err != nil {
+		return err
+	}
+	return output.WriteGraph(os.Stderr, graph, flagExplainGraph)
+}
+
+// validateShowConfig checks the --show-config flag value. The boolean
+// spellings from when it was a bool flag still work: true means merged and
+// false turns it off.
+func validateShowConfig() error {
+	switch flagShowConfig {
+	case "", showConfigMerged, showConfigEffective:
+		return nil
+	}
+	if b, err := strconv.ParseBool(flagShowConfig); err == nil {
+		flagShowConfig = ""
+		if b {
+			flagShowConfig = showConfigMerged
+		}
+		return nil
+	}
+	return fmt.Errorf("invalid --show-config value %q, expected %q or %q", flagShowConfig, showConfigMerged, showConfigEffective)
+}
+
+// showConfig prints the merged configuration as JSON.
+func showConfig(cfg *config.Config, sources config.Sources) error {
+	data, err := json.MarshalIndent(annotatedConfig{Config: cfg, Sources: sources}, "", "  ")
 	if err != nil {
 		return fmt.Errorf("marshaling config: %w", err)
 	}
@@ -122,6 +294,43 @@ func showConfig(cfg *config.Config) error {
 	return nil
 }
 
+// effectiveConfig is the --show-config=effective output: the values
+// resolved for one branch, with the key and layer behind each.
+type effectiveConfig struct {
+	Branch       string                    `json:"branch" yaml:"branch"`
+	BranchConfig string                    `json:"branch-config" yaml:"branch-config"`
+	Settings     []config.EffectiveSetting `json:"settings" yaml:"settings"`
+}
+
+// showEffectiveConfig prints the configuration resolved for branch as YAML,
+// or as JSON with --output json.
+func showEffectiveConfig(branch string, ec config.EffectiveConfiguration, sources config.Sources) error {
+	report := effectiveConfig{
+		Branch:       branch,
+		BranchConfig: ec.BranchConfigName,
+		Settings:     ec.Settings(sources),
+	}
+
+	switch flagOutput {
+	case "json":
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshaling config: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	case "":
+		enc := yaml.NewEncoder(os.Stdout)
+		enc.SetIndent(2)
+		if err := enc.Encode(report); err != nil {
+			return fmt.Errorf("marshaling config: %w", err)
+		}
+		return enc.Close()
+	default:
+		return fmt.Errorf("unknown output format %q", flagOutput)
+	}
+}
+
 // writeOutput writes the version variables in the requested format.
 func writeOutput(vars map[string]string) error {
 	w := os.Stdout
@@ -1,10 +1,16 @@
 package cmd
 
 import (
+	"encoding/json"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/output"
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/testutil"
+
 	"github.com/stretchr/testify/require"
 )
 
@@ -157,7 +163,7 @@ func TestShowConfig(t *testing.T) {
 	cfg, err := loadConfig(t.TempDir())
 	require.NoError(t, err)
 
-	err = showConfig(cfg)
+	err = showConfig(cfg, nil)
 	require.NoError(t, err)
 
 	w.Close()
@@ -168,3 +174,250 @@ func TestShowConfig(t *testing.T) {
 	out := string(buf[:n])
 	require.Contains(t, out, "TagPrefix")
 }
+
+func TestShowConfig_AnnotatesLayers(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "org.yml"), []byte("mode: Mainline\n"), 0o644))
+	path := filepath.Join(dir, "go-gitsemver.yml")
+	require.NoError(t, os.WriteFile(path, []byte("extends: org.yml\nnext-version: 5.0.0\n"), 0o644))
+
+	flagConfig = path
+	defer func() { flagConfig = "" }()
+
+	builder, err := configBuilder(dir)
+	require.NoError(t, err)
+	cfg, sources, err := builder.BuildAnnotated()
+	require.NoError(t, err)
+
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	err = showConfig(cfg, sources)
+	w.Close()
+	os.Stdout = old
+	require.NoError(t, err)
+
+	out, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.Contains(t, string(out), `"mode": "`+filepath.Join(dir, "org.yml")+`"`)
+	require.Contains(t, string(out), `"next-version": "`+path+`"`)
+	require.Contains(t, string(out), `"tag-prefix": "defaults"`)
+}
+
+func TestShowEffectiveConfig_Branch(t *testing.T) {
+	repo := testutil.NewTestRepo(t)
+	repo.AddCommit("initial")
+	dir := repo.Path()
+	path := filepath.Join(dir, "go-gitsemver.yml")
+	require.NoError(t, os.WriteFile(path, []byte("branches:\n  feature:\n    mode: ContinuousDeployment\n"), 0o644))
+
+	flagPath = dir
+	flagConfig = path
+	flagShowConfig = showConfigEffective
+	flagBranch = "feature/login"
+	flagSet = []string{"branches.feature.tag=alpha"}
+	defer func() {
+		flagPath = "."
+		flagConfig = ""
+		flagShowConfig = ""
+		flagBranch = ""
+		flagSet = nil
+	}()
+
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	err := calculateRunE(nil, nil)
+	w.Close()
+	os.Stdout = old
+	require.NoError(t, err)
+
+	out, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.Contains(t, string(out), "branch: feature/login\nbranch-config: feature\n")
+	require.Contains(t, string(out), "  - name: BranchMode\n    value: ContinuousDeployment\n    key: branches.feature.mode\n    source: "+path+"\n")
+	require.Contains(t, string(out), "  - name: Tag\n    value: alpha\n    key: branches.feature.tag\n    source: --set\n")
+	require.Contains(t, string(out), "  - name: Mode\n    value: ContinuousDelivery\n    key: mode\n    source: defaults\n")
+}
+
+func TestShowEffectiveConfig_JSON(t *testing.T) {
+	flagOutput = "json"
+	defer func() { flagOutput = "" }()
+
+	cfg, err := loadConfig(t.TempDir())
+	require.NoError(t, err)
+	ec, err := cfg.GetEffectiveConfiguration("main")
+	require.NoError(t, err)
+
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	err = showEffectiveConfig("main", ec, nil)
+	w.Close()
+	os.Stdout = old
+	require.NoError(t, err)
+
+	out, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.Contains(t, string(out), `"branch-config": "main"`)
+	require.Contains(t, string(out), `"name": "IsMainline",
+      "value": true,
+      "key": "branches.main.is-mainline",
+      "source": "defaults"`)
+}
+
+func TestCalculate_ExplainFormatJSON(t *testing.T) {
+	repo := testutil.NewTestRepo(t)
+	sha := repo.AddCommit("initial")
+	repo.CreateTag("v1.0.0", sha)
+	repo.AddCommit("feat: add login")
+
+	flagPath = repo.Path()
+	flagExplainFormat = explainFormatJSON
+	flagShowVariable = "SemVer"
+	defer func() {
+		flagPath = "."
+		flagExplainFormat = explainFormatText
+		flagShowVariable = ""
+	}()
+
+	oldOut, oldErr := os.Stdout, os.Stderr
+	rOut, wOut, _ := os.Pipe()
+	rErr, wErr, _ := os.Pipe()
+	os.Stdout, os.Stderr = wOut, wErr
+	err := calculateRunE(nil, nil)
+	wOut.Close()
+	wErr.Close()
+	os.Stdout, os.Stderr = oldOut, oldErr
+	require.NoError(t, err)
+
+	stdout, err := io.ReadAll(rOut)
+	require.NoError(t, err)
+	require.Equal(t, "1.1.0\n", string(stdout))
+
+	stderr, err := io.ReadAll(rErr)
+	require.NoError(t, err)
+	var explanation output.Explanation
+	require.NoError(t, json.Unmarshal(stderr, &explanation))
+	require.Equal(t, "TaggedCommit", explanation.Selected.Strategy)
+	require.Equal(t, "Minor", explanation.Increment.DecidingField)
+	require.Equal(t, "1.1.0+1", explanation.Version)
+}
+
+func TestValidateExplainFormat(t *testing.T) {
+	require.NoError(t, validateExplainFormat())
+	flagExplainFormat = "yaml"
+	defer func() { flagExplainFormat = explainFormatText }()
+	require.ErrorContains(t, validateExplainFormat(), `invalid --explain-format value "yaml"`)
+}
+
+func TestCalculate_ExplainGraph(t *testing.T) {
+	repo := testutil.NewTestRepo(t)
+	sha := repo.AddCommit("initial")
+	repo.CreateTag("v1.0.0", sha)
+	repo.AddCommit("feat: add login")
+
+	flagPath = repo.Path()
+	flagExplainGraph = output.GraphFormatMermaid
+	flagShowVariable = "SemVer"
+	defer func() {
+		flagPath = "."
+		flagExplainGraph = ""
+		flagShowVariable = ""
+	}()
+
+	oldOut, oldErr := os.Stdout, os.Stderr
+	rOut, wOut, _ := os.Pipe()
+	rErr, wErr, _ := os.Pipe()
+	os.Stdout, os.Stderr = wOut, wErr
+	err := calculateRunE(nil, nil)
+	wOut.Close()
+	wErr.Close()
+	os.Stdout, os.Stderr = oldOut, oldErr
+	require.NoError(t, err)
+
+	stdout, err := io.ReadAll(rOut)
+	require.NoError(t, err)
+	require.Equal(t, "1.1.0\n", string(stdout))
+
+	stderr, err := io.ReadAll(rErr)
+	require.NoError(t, err)
+	graph := string(stderr)
+	require.True(t, strings.HasPrefix(graph, "flowchart TB\n"))
+	require.Contains(t, graph, "feat: add login<br/>bump: Minor")
+	require.Contains(t, graph, "initial<br/>tag: v1.0.0<br/>selected: ")
+	require.Contains(t, graph, " --> c"+sha[:7]+"\n")
+	require.NotContains(t, graph, "c"+sha[:7]+" -->")
+}
+
+func TestValidateExplainGraph(t *testing.T) {
+	require.NoError(t, validateExplainGraph())
+	flagExplainGraph = "svg"
+	defer func() { flagExplainGraph = "" }()
+	require.ErrorContains(t, validateExplainGraph(), `invalid --explain-graph value "svg"`)
+}
+
+func TestValidateShowConfig(t *testing.T) {
+	for _, v := range []string{"", showConfigMerged, showConfigEffective} {
+		flagShowConfig = v
+		require.NoError(t, validateShowConfig())
+	}
+	flagShowConfig = "raw"
+	defer func() { flagShowConfig = "" }()
+	require.ErrorContains(t, validateShowConfig(), `invalid --show-config value "raw"`)
+}
+
+func TestValidateShowConfig_BoolSpellings(t *testing.T) {
+	defer func() { flagShowConfig = "" }()
+	for v, want := range map[string]string{"true": showConfigMerged, "false": ""} {
+		flagShowConfig = v
+		require.NoError(t, validateShowConfig())
+		require.Equal(t, want, flagShowConfig, v)
+	}
+
+	require.NoError(t, rootCmd.PersistentFlags().Parse([]string{"--show-config=true"}))
+	require.NoError(t, validateShowConfig())
+	require.Equal(t, showConfigMerged, flagShowConfig)
+	require.NoError(t, rootCmd.PersistentFlags().Parse([]string{"--show-config=false"}))
+	require.NoError(t, validateShowConfig())
+	require.Empty(t, flagShowConfig)
+}
+
+func TestConfigBuilder_EnvAndSetOverrides(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "go-gitsemver.yml")
+	require.NoError(t, os.WriteFile(path, []byte("next-version: 5.0.0\ntag-prefix: v\n"), 0o644))
+
+	t.Setenv("GITSEMVER_TAG_PREFIX", "release-")
+	t.Setenv("GITSEMVER_BRANCHES__FEATURE__TAG", "alpha")
+	flagConfig = path
+	flagSet = []string{"next-version=6.0.0", "branches.feature.tag=beta"}
+	defer func() { flagConfig, flagSet = "", nil }()
+
+	builder, err := configBuilder(dir)
+	require.NoError(t, err)
+	cfg, sources, err := builder.BuildAnnotated()
+	require.NoError(t, err)
+
+	require.Equal(t, "6.0.0", *cfg.NextVersion)
+	require.Equal(t, "release-", *cfg.TagPrefix)
+	require.Equal(t, "beta", *cfg.Branches["feature"].Tag)
+	require.Equal(t, "--set", sources["next-version"])
+	require.Equal(t, "env", sources["tag-prefix"])
+	require.Equal(t, "--set", sources["branches.feature.tag"])
+}
+
+func TestConfigBuilder_InvalidOverride(t *testing.T) {
+	flagSet = []string{"mode=Sideways"}
+	defer func() { flagSet = nil }()
+
+	_, err := configBuilder(t.TempDir())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "--set")
+
+	flagSet = nil
+	t.Setenv("GITSEMVER_TAG_PREFX", "v")
+	_, err = configBuilder(t.TempDir())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "environment override")
+}
@@ -0,0 +1,223 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/config"
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/git"
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect and check go-gitsemver configuration",
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate [file]",
+	Short: "Validate a configuration file",
+	Long: `Validate a configuration file for YAML errors, unknown keys, invalid values
+and regexes, undefined source branches, and branch regexes that overlap at
+equal priority. Without a file argument, the file is located as for version
+calculation (--config, then auto-detect).
+
+Use -o json for machine-readable output. Exits non-zero when any error is
+found; warnings alone do not fail.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: configValidateRunE,
+}
+
+var configMatchCmd = &cobra.Command{
+	Use:   "match <branch>",
+	Short: "Show which branch configurations match a branch name",
+	Long: `List every branch configuration whose regex matches the given branch name,
+in selection order (highest priority first), and mark the one used for
+versioning. Configuration is loaded as for version calculation, including
+extends, GITSEMVER_* environment variables and --set.
+
+Use -o json for machine-readable output. Exits non-zero when no branch
+configuration matches.`,
+	Args: cobra.ExactArgs(1),
+	RunE: configMatchRunE,
+}
+
+func init() {
+	configCmd.AddCommand(configValidateCmd)
+	configCmd.AddCommand(configMatchCmd)
+	rootCmd.AddCommand(configCmd)
+}
+
+// configFileArg returns the configuration file named by args, or else
+// located as for version calculation: --config, then auto-detect.
+func configFileArg(args []string) (string, error) {
+	if len(args) > 0 {
+		return args[0], nil
+	}
+	if flagConfig != "" {
+		return flagConfig, nil
+	}
+	dir := flagPath
+	if repo, err := git.Open(flagPath); err == nil {
+		dir = repo.WorkingDirectory()
+	}
+	if path := findConfigFile(dir); path != "" {
+		return path, nil
+	}
+	return "", fmt.Errorf("no configuration file found in %s", dir)
+}
+
+// validationReport is the JSON output of config validate.
+type validationReport struct {
+	File   string         `json:"file"`
+	Valid  bool           `json:"valid"`
+	Issues []config.Issue `json:"issues"`
+}
+
+func configValidateRunE(cmd *cobra.Command, args []string) error {
+	// 1. Locate the configuration file.
+	path, err := configFileArg(args)
+	if err != nil {
+		return err
+	}
+
+	// 2. Validate.
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading config file: %w", err)
+	}
+	var bases []*config.Config
+	var issues []config.Issue
+	layers, err := config.LoadLayersFromBytes(path, data, nil)
+	if err == nil {
+		for _, l := range layers[:len(layers)-1] {
+			bases = append(bases, l.Config)
+		}
+	} else if cfg, parseErr := config.LoadFromBytes(data); parseErr == nil && len(cfg.Extends) > 0 {
+		issues = append(issues, config.Issue{
+			Severity: config.SeverityError,
+			Path:     "extends",
+			Message:  err.Error(),
+		})
+	}
+	issues = append(issues, config.Validate(data, bases...)...)
+
+	// 3. Write the report.
+	report := validationReport{
+		File:   path,
+		Valid:  !config.HasErrors(issues),
+		Issues: issues,
+	}
+	if report.Issues == nil {
+		report.Issues = []config.Issue{}
+	}
+	if err := writeValidationReport(cmd.OutOrStdout(), report); err != nil {
+		return err
+	}
+
+	if !report.Valid {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%s: configuration is invalid", path)
+	}
+	return nil
+}
+
+// issueLine formats issue as "file:line:column: severity: message (path)".
+func issueLine(file string, issue config.Issue) string {
+	sep := " "
+	if issue.Line > 0 {
+		sep = ""
+	}
+	return fmt.Sprintf("%s:%s%s", file, sep, issue)
+}
+
+// writeValidationReport writes report as JSON (-o json) or as one
+// "file:line:column: severity: message (path)" line per issue.
+func writeValidationReport(w io.Writer, report validationReport) error {
+	switch flagOutput {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	case "":
+		for _, issue := range report.Issues {
+			if _, err := fmt.Fprintln(w, issueLine(report.File, issue)); err != nil {
+				return err
+			}
+		}
+		if len(report.Issues) == 0 {
+			_, err := fmt.Fprintf(w, "%s: configuration is valid\n", report.File)
+			return err
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown output format %q", flagOutput)
+	}
+}
+
+// matchReport is the JSON output of config match.
+type matchReport struct {
+	Branch   string       `json:"branch"`
+	Selected string       `json:"selected,omitempty"`
+	Matches  []matchEntry `json:"matches"`
+}
+
+type matchEntry struct {
+	Name     string `json:"name"`
+	Regex    string `json:"regex"`
+	Priority int    `json:"priority"`
+}
+
+func configMatchRunE(cmd *cobra.Command, args []string) error {
+	branch := args[0]
+
+	// 1. Load configuration.
+	dir := flagPath
+	if repo, err := git.Open(flagPath); err == nil {
+		dir = repo.WorkingDirectory()
+	}
+	cfg, err := loadConfig(dir)
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+
+	// 2. Match the branch against every branch configuration.
+	matches, err := cfg.MatchBranchConfigurations(branch)
+	if err != nil {
+		return err
+	}
+
+	// 3. Write the report.
+	w := cmd.OutOrStdout()
+	switch flagOutput {
+	case "json":
+		report := matchReport{Branch: branch, Matches: []matchEntry{}}
+		for _, m := range matches {
+			report.Matches = append(report.Matches, matchEntry{Name: m.Name, Regex: m.Regex, Priority: m.Priority})
+		}
+		if len(matches) > 0 {
+			report.Selected = matches[0].Name
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			return err
+		}
+	case "":
+		if err := output.WriteBranchMatches(w, "", branch, matches); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown output format %q", flagOutput)
+	}
+
+	if len(matches) == 0 {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("no branch configuration matches %q", branch)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func runConfigValidate(t *testing.T, content string) (string, error) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "go-gitsemver.yml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+
+	var buf bytes.Buffer
+	configValidateCmd.SetOut(&buf)
+	defer configValidateCmd.SetOut(nil)
+	err := configValidateRunE(configValidateCmd, []string{path})
+	return buf.String(), err
+}
+
+func TestConfigValidate_Valid(t *testing.T) {
+	out, err := runConfigValidate(t, "mode: Mainline\n")
+	require.NoError(t, err)
+	require.Contains(t, out, "configuration is valid")
+}
+
+func TestConfigValidate_TextReport(t *testing.T) {
+	out, err := runConfigValidate(t, "mode: Mainline\ntag-prefx: v\n")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "configuration is invalid")
+	require.Contains(t, out, `go-gitsemver.yml:2:1: error: unknown key "tag-prefx"`)
+}
+
+func TestConfigValidate_WarningsDoNotFail(t *testing.T) {
+	out, err := runConfigValidate(t, "branches:\n  docs:\n    regex: ^docs/\n")
+	require.NoError(t, err)
+	require.Contains(t, out, "warning:")
+}
+
+func TestConfigValidate_JSONReport(t *testing.T) {
+	flagOutput = "json"
+	defer func() { flagOutput = "" }()
+
+	out, err := runConfigValidate(t, "branches:\n  feature:\n    regex: '(('\n")
+	require.Error(t, err)
+
+	var report validationReport
+	require.NoError(t, json.Unmarshal([]byte(out), &report))
+	require.False(t, report.Valid)
+	require.Len(t, report.Issues, 1)
+	require.Equal(t, "branches.feature.regex", report.Issues[0].Path)
+	require.Equal(t, 3, report.Issues[0].Line)
+}
+
+func TestConfigValidate_NoConfigFile(t *testing.T) {
+	flagPath = t.TempDir()
+	defer func() { flagPath = "." }()
+
+	err := configValidateRunE(configValidateCmd, nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "no configuration file found")
+}
+
+func TestConfigCmd_IsRegistered(t *testing.T) {
+	cmd, _, err := rootCmd.Find([]string{"config", "validate"})
+	require.NoError(t, err)
+	require.Equal(t, "validate", cmd.Name())
+}
+
+func runConfigMatch(t *testing.T, branch string) (string, error) {
+	t.Helper()
+	flagPath = t.TempDir()
+	defer func() { flagPath = "." }()
+
+	var buf bytes.Buffer
+	configMatchCmd.SetOut(&buf)
+	defer configMatchCmd.SetOut(nil)
+	err := configMatchRunE(configMatchCmd, []string{branch})
+	return buf.String(), err
+}
+
+func TestConfigMatch_Text(t *testing.T) {
+	out, err := runConfigMatch(t, "feature/login")
+	require.NoError(t, err)
+	require.Contains(t, out, `"feature/login" matched 2 branch configs:`)
+	require.Contains(t, out, "→ feature priority 50")
+	require.Contains(t, out, "(selected)")
+	require.Contains(t, out, "→ unknown priority 0")
+}
+
+func TestConfigMatch_JSON(t *testing.T) {
+	flagOutput = "json"
+	defer func() { flagOutput = "" }()
+
+	out, err := runConfigMatch(t, "release/1.2")
+	require.NoError(t, err)
+
+	var report matchReport
+	require.NoError(t, json.Unmarshal([]byte(out), &report))
+	require.Equal(t, "release/1.2", report.Branch)
+	require.Equal(t, "release", report.Selected)
+	require.Len(t, report.Matches, 2)
+	require.Equal(t, 90, report.Matches[0].Priority)
+}
+
+func TestConfigMatch_NoMatch(t *testing.T) {
+	flagSet = []string{"branches.unknown.regex=^never$"}
+	defer func() { flagSet = nil }()
+
+	out, err := runConfigMatch(t, "scratch")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `no branch configuration matches "scratch"`)
+	require.Contains(t, out, `no branch configuration matches "scratch"`)
+}
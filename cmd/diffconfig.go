@@ -0,0 +1,289 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/calculator"
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/config"
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/git"
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/output"
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/semver"
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/strategy"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagDiffBranches []string
+	flagDiffCommits  int
+)
+
+var diffConfigCmd = &cobra.Command{
+	Use:   "diff-config [old-config] new-config",
+	Short: "Show which versions change under a different configuration",
+	Long: `Calculate versions under two configurations and report every difference.
+With one argument, the current configuration (--config, or the file found
+in the repository) is compared with the given file.
+
+By default every local branch is versioned at its tip. Use --branches to
+limit the comparison to some branches, or --commits N to compare the last
+N commits on the first-parent chain of --branch (default: the main
+branch) instead. Uncommitted changes are not counted. --set and
+GITSEMVER_* overrides apply to both configurations.
+
+The version compared is FullSemVer unless --show-variable names another
+variable.
+
+Examples:
+  go-gitsemver diff-config proposed.yml
+  go-gitsemver diff-config old.yml new.yml --branches main,develop
+  go-gitsemver diff-config proposed.yml --commits 50 -o json`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: diffConfigRunE,
+}
+
+func init() {
+	diffConfigCmd.Flags().StringSliceVar(&flagDiffBranches, "branches", nil, "branches to compare (default: all local branches)")
+	diffConfigCmd.Flags().IntVar(&flagDiffCommits, "commits", 0, "compare the last N commits of --branch (default: the main branch) instead of branch tips")
+	rootCmd.AddCommand(diffConfigCmd)
+}
+
+// configDiff is the diff-config report.
+type configDiff struct {
+	Variable    string          `json:"variable"`
+	Compared    int             `json:"compared"`
+	Differences []versionChange `json:"differences"`
+}
+
+// versionTarget is a branch tip or commit versioned under both configs.
+type versionTarget struct {
+	branch git.Branch
+	commit git.Commit
+}
+
+// versionChange is a target whose version differs between the configs.
+// A calculation error is reported in place of the version.
+type versionChange struct {
+	Branch  string `json:"branch"`
+	Commit  string `json:"commit"`
+	Subject string `json:"subject,omitempty"` // set when comparing commits
+	Old     string `json:"old"`
+	New     string `json:"new"`
+}
+
+func diffConfigRunE(cmd *cobra.Command, args []string) error {
+	if len(flagDiffBranches) > 0 && flagDiffCommits > 0 {
+		return errors.New("--branches and --commits are mutually exclusive")
+	}
+	if flagDiffCommits < 0 {
+		return errors.New("--commits must not be negative")
+	}
+	if flagOutput != "" && flagOutput != "json" {
+		return fmt.Errorf("unknown output format %q", flagOutput)
+	}
+	variable := flagShowVariable
+	if variable == "" {
+		variable = "FullSemVer"
+	}
+	if _, ok := output.GetVariables(semver.SemanticVersion{}, config.EffectiveConfiguration{})[variable]; !ok {
+		return fmt.Errorf("unknown variable %q", variable)
+	}
+
+	// 1. Open repository.
+	repo, err := git.Open(flagPath)
+	if err != nil {
+		return fmt.Errorf("opening repository: %w", err)
+	}
+
+	// 2. Load both configurations.
+	var oldCfg *config.Config
+	if len(args) == 2 {
+		oldCfg, err = loadConfigFile(args[0])
+	} else {
+		oldCfg, err = loadConfig(repo.WorkingDirectory())
+	}
+	if err != nil {
+		return fmt.Errorf("loading old configuration: %w", err)
+	}
+	newCfg, err := loadConfigFile(args[len(args)-1])
+	if err != nil {
+		return fmt.Errorf("loading new configuration: %w", err)
+	}
+
+	// 3. Pick the branch tips or commits to compare.
+	store := git.NewMemoizedRepositoryStore(repo).WithContext(commandContext(cmd))
+	targets, err := diffTargets(store, oldCfg)
+	if err != nil {
+		return err
+	}
+
+	// 4. Calculate each target under both configurations.
+	calc := calculator.NewNextVersionCalculator(store, strategy.AllStrategies(store))
+	report := configDiff{Variable: variable, Compared: len(targets), Differences: []versionChange{}}
+	for _, t := range targets {
+		oldVersion := versionUnder(calc, store, oldCfg, t, variable)
+		newVersion := versionUnder(calc, store, newCfg, t, variable)
+		if oldVersion == newVersion {
+			continue
+		}
+		change := versionChange{
+			Branch: t.branch.FriendlyName(),
+			Commit: t.commit.Sha,
+			Old:    oldVersion,
+			New:    newVersion,
+		}
+		if flagDiffCommits > 0 {
+			change.Subject, _, _ = strings.Cut(t.commit.Message, "\n")
+		}
+		report.Differences = append(report.Differences, change)
+	}
+
+	// 5. Write the report.
+	w := cmd.OutOrStdout()
+	if flagOutput == "json" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshaling report: %w", err)
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	}
+
+	noun := "branches"
+	if flagDiffCommits > 0 {
+		noun = "commits"
+	}
+	if len(report.Differences) == 0 {
+		_, err := fmt.Fprintf(w, "no %s differences across %d %s\n", variable, report.Compared, noun)
+		return err
+	}
+	width := 0
+	for _, d := range report.Differences {
+		width = max(width, len(diffLabel(d)))
+	}
+	for _, d := range report.Differences {
+		if _, err := fmt.Fprintf(w, "%-*s  %s -> %s\n", width, diffLabel(d), d.Old, d.New); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(w, "%d of %d %s changed %s\n", len(report.Differences), report.Compared, noun, variable)
+	return err
+}
+
+// diffTargets returns the branch tips, or with --commits the first-parent
+// commits, to compare.
+func diffTargets(store *git.RepositoryStore, cfg *config.Config) ([]versionTarget, error) {
+	if flagDiffCommits > 0 {
+		branch, err := diffCommitsBranch(store, cfg)
+		if err != nil {
+			return nil, err
+		}
+		commits, err := store.GetMainlineCommitLog(git.Commit{}, *branch.Tip)
+		if err != nil {
+			return nil, fmt.Errorf("listing commits: %w", err)
+		}
+		if len(commits) > flagDiffCommits {
+			commits = commits[:flagDiffCommits]
+		}
+		targets := make([]versionTarget, 0, len(commits))
+		for _, c := range commits {
+			targets = append(targets, versionTarget{branch: branch, commit: c})
+		}
+		return targets, nil
+	}
+
+	var branches []git.Branch
+	if len(flagDiffBranches) > 0 {
+		for _, name := range flagDiffBranches {
+			b, err := store.GetTargetBranch(name)
+			if err != nil {
+				return nil, err
+			}
+			branches = append(branches, b)
+		}
+	} else {
+		all, err := store.GetBranches()
+		if err != nil {
+			return nil, fmt.Errorf("listing branches: %w", err)
+		}
+		for _, b := range all {
+			if !b.IsRemote {
+				branches = append(branches, b)
+			}
+		}
+	}
+
+	targets := make([]versionTarget, 0, len(branches))
+	for _, b := range branches {
+		if b.Tip == nil {
+			continue
+		}
+		targets = append(targets, versionTarget{branch: b, commit: *b.Tip})
+	}
+	return targets, nil
+}
+
+// diffCommitsBranch returns the branch whose commits --commits compares:
+// --branch, or the main branch of cfg.
+func diffCommitsBranch(store *git.RepositoryStore, cfg *config.Config) (git.Branch, error) {
+	var branch git.Branch
+	if flagBranch != "" {
+		b, err := store.GetTargetBranch(flagBranch)
+		if err != nil {
+			return git.Branch{}, err
+		}
+		branch = b
+	} else {
+		b, ok, err := store.FindMainBranch(cfg)
+		if err != nil {
+			return git.Branch{}, err
+		}
+		if !ok {
+			return git.Branch{}, errors.New("no main branch found; use --branch to pick the branch for --commits")
+		}
+		branch = b
+	}
+	if branch.Tip == nil {
+		return git.Branch{}, fmt.Errorf("branch %q has no tip commit", branch.FriendlyName())
+	}
+	return branch, nil
+}
+
+// versionUnder returns the variable for t calculated under cfg, or the
+// error when the calculation fails.
+func versionUnder(calc *calculator.NextVersionCalculator, store *git.RepositoryStore, cfg *config.Config, t versionTarget, variable string) string {
+	ctx, err := commitContext(store, cfg, t.branch, t.commit)
+	if err != nil {
+		return "error: " + err.Error()
+	}
+	version, err := calculateVariable(calc, ctx, variable)
+	if err != nil {
+		return "error: " + err.Error()
+	}
+	return version
+}
+
+func diffLabel(d versionChange) string {
+	if d.Subject != "" {
+		return d.Commit[:min(7, len(d.Commit))] + " " + d.Subject
+	}
+	return d.Branch
+}
+
+// loadConfigFile loads the configuration in path and the files it extends,
+// followed by the environment and --set overrides.
+func loadConfigFile(path string) (*config.Config, error) {
+	layers, err := config.LoadLayers(path, nil)
+	if err != nil {
+		return nil, err
+	}
+	builder := config.NewBuilder()
+	addLayers(builder, layers)
+	if err := addOverrideLayers(builder); err != nil {
+		return nil, err
+	}
+	return builder.Build()
+}
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/testutil"
+
+	"github.com/stretchr/testify/require"
+)
+
+func runDiffConfig(t *testing.T, path string, args ...string) (string, error) {
+	t.Helper()
+	flagPath = path
+	defer func() { flagPath = "." }()
+
+	var stdout bytes.Buffer
+	diffConfigCmd.SetOut(&stdout)
+	defer diffConfigCmd.SetOut(nil)
+
+	err := diffConfigRunE(diffConfigCmd, args)
+	return stdout.String(), err
+}
+
+func diffConfigTestRepo(t *testing.T) *testutil.TestRepo {
+	t.Helper()
+	repo := testutil.NewTestRepo(t)
+	initial := repo.AddCommit("initial")
+	repo.CreateTag("v1.0.0", initial)
+	repo.AddCommit("fix: typo")
+	repo.CreateBranch("develop", repo.AddCommit("feat: add login"))
+	return repo
+}
+
+func writeTestConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "proposed.yml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func TestDiffConfig_Branches(t *testing.T) {
+	repo := diffConfigTestRepo(t)
+	proposed := writeTestConfig(t, "branches:\n  develop:\n    tag: beta\n")
+
+	out, err := runDiffConfig(t, repo.Path(), proposed)
+	require.NoError(t, err)
+	require.Equal(t, "develop  1.1.0-alpha.1+2 -> 1.1.0-beta.1+2\n1 of 2 branches changed FullSemVer\n", out)
+}
+
+func TestDiffConfig_CurrentConfigIsOld(t *testing.T) {
+	repo := diffConfigTestRepo(t)
+	repo.WriteConfig("branches:\n  develop:\n    tag: beta\n")
+	proposed := writeTestConfig(t, "branches:\n  develop:\n    tag: beta\n")
+
+	out, err := runDiffConfig(t, repo.Path(), proposed)
+	require.NoError(t, err)
+	require.Equal(t, "no FullSemVer differences across 2 branches\n", out)
+}
+
+func TestDiffConfig_CommitsJSON(t *testing.T) {
+	repo := diffConfigTestRepo(t)
+	old := writeTestConfig(t, "commit-message-incrementing: Disabled\n")
+	proposed := writeTestConfig(t, "commit-message-incrementing: Enabled\n")
+
+	flagDiffCommits = 5
+	flagOutput = "json"
+	flagShowVariable = "SemVer"
+	defer func() {
+		flagDiffCommits = 0
+		flagOutput = ""
+		flagShowVariable = ""
+	}()
+
+	out, err := runDiffConfig(t, repo.Path(), old, proposed)
+	require.NoError(t, err)
+
+	var report configDiff
+	require.NoError(t, json.Unmarshal([]byte(out), &report))
+	require.Equal(t, "SemVer", report.Variable)
+	require.Equal(t, 3, report.Compared)
+	require.Len(t, report.Differences, 1)
+	require.Equal(t, "master", report.Differences[0].Branch)
+	require.Equal(t, "feat: add login", report.Differences[0].Subject)
+	require.Equal(t, "1.0.1", report.Differences[0].Old)
+	require.Equal(t, "1.1.0", report.Differences[0].New)
+}
+
+func TestDiffConfig_BranchesFlag(t *testing.T) {
+	repo := diffConfigTestRepo(t)
+	proposed := writeTestConfig(t, "branches:\n  main:\n    increment: Major\n  develop:\n    tag: beta\n")
+
+	flagDiffBranches = []string{"master"}
+	defer func() { flagDiffBranches = nil }()
+
+	out, err := runDiffConfig(t, repo.Path(), proposed)
+	require.NoError(t, err)
+	require.Equal(t, "master  1.1.0+2 -> 2.0.0+2\n1 of 1 branches changed FullSemVer\n", out)
+}
+
+func TestDiffConfig_Errors(t *testing.T) {
+	repo := diffConfigTestRepo(t)
+	proposed := writeTestConfig(t, "mode: Mainline\n")
+
+	flagDiffBranches = []string{"master"}
+	flagDiffCommits = 3
+	_, err := runDiffConfig(t, repo.Path(), proposed)
+	require.ErrorContains(t, err, "mutually exclusive")
+	flagDiffBranches = nil
+	flagDiffCommits = 0
+
+	flagShowVariable = "Nope"
+	defer func() { flagShowVariable = "" }()
+	_, err = runDiffConfig(t, repo.Path(), proposed)
+	require.ErrorContains(t, err, `unknown variable "Nope"`)
+}
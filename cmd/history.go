@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/calculator"
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/config"
+	configctx "github.com/MyCarrier-DevOps/go-gitsemver/internal/context"
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/git"
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/output"
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/strategy"
+
+	"github.com/spf13/cobra"
+)
+
+var flagHistoryLimit int
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Show the version calculated at each commit of a branch",
+	Long: `Show the version go-gitsemver would have calculated at each commit on the
+first-parent chain of a branch, newest first. Use it to audit the effect of
+a configuration change across history, or to find the commit that
+introduced an unexpected bump.
+
+The branch defaults to the current HEAD; use --branch to pick another.
+The version shown is FullSemVer unless --show-variable names another
+variable. Uncommitted changes are only counted at the branch tip.
+
+Examples:
+  go-gitsemver history --branch main --limit 200
+  go-gitsemver history --set branches.main.increment=Minor --show-variable SemVer
+  go-gitsemver history -o json`,
+	Args: cobra.NoArgs,
+	RunE: historyRunE,
+}
+
+func init() {
+	historyCmd.Flags().IntVar(&flagHistoryLimit, "limit", 100, "maximum number of commits to show (0 for all)")
+	rootCmd.AddCommand(historyCmd)
+}
+
+// historyEntry is one commit in the history output.
+type historyEntry struct {
+	Commit  string    `json:"commit"`
+	Date    time.Time `json:"date"`
+	Subject string    `json:"subject"`
+	Version string    `json:"version"`
+}
+
+func historyRunE(cmd *cobra.Command, _ []string) error {
+	if flagHistoryLimit < 0 {
+		return errors.New("--limit must not be negative")
+	}
+	if flagOutput != "" && flagOutput != "json" {
+		return fmt.Errorf("unknown output format %q", flagOutput)
+	}
+	variable := flagShowVariable
+	if variable == "" {
+		variable = "FullSemVer"
+	}
+
+	// 1. Open repository and load configuration.
+	repo, err := git.Open(flagPath)
+	if err != nil {
+		return fmt.Errorf("opening repository: %w", err)
+	}
+	cfg, err := loadConfig(repo.WorkingDirectory())
+	if err != nil {
+		return fmt.Errorf("loading configuration: %w", err)
+	}
+
+	// 2. Build the context for the branch tip. One memoized store serves
+	// every commit, so tags, branches, commit logs and merge bases are read
+	// once. Strategies and the increment scan still run at each commit.
+	store := git.NewMemoizedRepositoryStore(repo).WithContext(commandContext(cmd))
+	tipCtx, err := configctx.NewContext(store, repo, cfg, configctx.Options{TargetBranch: flagBranch})
+	if err != nil {
+		return fmt.Errorf("building context: %w", err)
+	}
+
+	// 3. List the first-parent chain.
+	commits, err := store.GetMainlineCommitLog(git.Commit{}, tipCtx.CurrentCommit)
+	if err != nil {
+		return fmt.Errorf("listing commits: %w", err)
+	}
+	if flagHistoryLimit > 0 && len(commits) > flagHistoryLimit {
+		commits = commits[:flagHistoryLimit]
+	}
+
+	// 4. Calculate the version at each commit.
+	calc := calculator.NewNextVersionCalculator(store, strategy.AllStrategies(store))
+	entries := make([]historyEntry, 0, len(commits))
+	for _, commit := range commits {
+		ctx, err := commitContext(store, cfg, tipCtx.CurrentBranch, commit)
+		if err != nil {
+			return err
+		}
+		if commit.Sha == tipCtx.CurrentCommit.Sha {
+			ctx.NumberOfUncommittedChanges = tipCtx.NumberOfUncommittedChanges
+		}
+		version, err := calculateVariable(calc, ctx, variable)
+		if err != nil {
+			return fmt.Errorf("calculating version at %s: %w", commit.ShortSha(), err)
+		}
+
+		subject, _, _ := strings.Cut(commit.Message, "\n")
+		entries = append(entries, historyEntry{
+			Commit:  commit.Sha,
+			Date:    commit.When,
+			Subject: subject,
+			Version: version,
+		})
+	}
+
+	// 5. Write output.
+	w := cmd.OutOrStdout()
+	if flagOutput == "json" {
+		data, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshaling history: %w", err)
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	}
+	width := 0
+	for _, e := range entries {
+		width = max(width, len(e.Version))
+	}
+	for _, e := range entries {
+		if _, err := fmt.Fprintf(w, "%s  %s  %-*s  %s\n",
+			e.Commit[:min(7, len(e.Commit))], e.Date.Format(time.DateOnly), width, e.Version, e.Subject); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// commitContext returns the context for versioning commit as part of branch.
+// Uncommitted changes are not counted.
+func commitContext(store *git.RepositoryStore, cfg *config.Config, branch git.Branch, commit git.Commit) (*configctx.GitVersionContext, error) {
+	tagPrefix := "[vV]"
+	if cfg.TagPrefix != nil {
+		tagPrefix = *cfg.TagPrefix
+	}
+	tagged, isTagged, err := store.GetCurrentCommitTaggedVersion(commit, tagPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("checking version tag on %s: %w", commit.ShortSha(), err)
+	}
+	return &configctx.GitVersionContext{
+		CurrentBranch:              branch,
+		CurrentCommit:              commit,
+		FullConfiguration:          cfg,
+		CurrentCommitTaggedVersion: tagged,
+		IsCurrentCommitTagged:      isTagged,
+	}, nil
+}
+
+// calculateVariable calculates the version for ctx and returns the named
+// output variable.
+func calculateVariable(calc *calculator.NextVersionCalculator, ctx *configctx.GitVersionContext, variable string) (string, error) {
+	ec, err := ctx.GetEffectiveConfiguration(ctx.CurrentBranch.FriendlyName())
+	if err != nil {
+		return "", fmt.Errorf("resolving branch configuration: %w", err)
+	}
+	result, err := calc.Calculate(ctx, ec, false)
+	if err != nil {
+		return "", err
+	}
+	version, ok := output.GetVariables(result.Version, ec)[variable]
+	if !ok {
+		return "", fmt.Errorf("unknown variable %q", variable)
+	}
+	return version, nil
+}
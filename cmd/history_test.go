@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/testutil"
+
+	"github.com/stretchr/testify/require"
+)
+
+func runHistory(t *testing.T, path string) (string, error) {
+	t.Helper()
+	flagPath = path
+	defer func() { flagPath = "." }()
+
+	var stdout bytes.Buffer
+	historyCmd.SetOut(&stdout)
+	defer historyCmd.SetOut(nil)
+
+	err := historyRunE(historyCmd, nil)
+	return stdout.String(), err
+}
+
+func historyTestRepo(t *testing.T) (*testutil.TestRepo, []string) {
+	t.Helper()
+	repo := testutil.NewTestRepo(t)
+	initial := repo.AddCommit("initial")
+	repo.CreateTag("v1.0.0", initial)
+	feat := repo.AddCommit("feat: add login")
+	fix := repo.AddCommit("fix: typo")
+	return repo, []string{fix, feat, initial}
+}
+
+func TestHistory_Text(t *testing.T) {
+	repo, shas := historyTestRepo(t)
+
+	out, err := runHistory(t, repo.Path())
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	require.Len(t, lines, 3)
+	require.True(t, strings.HasPrefix(lines[0], shas[0][:7]+"  "))
+	require.Contains(t, lines[0], "  1.1.0+2  fix: typo")
+	require.Contains(t, lines[1], "  1.1.0+1  feat: add login")
+	require.Contains(t, lines[2], "  1.0.0    initial")
+}
+
+func TestHistory_JSONWithLimitAndVariable(t *testing.T) {
+	repo, shas := historyTestRepo(t)
+
+	flagOutput = "json"
+	flagShowVariable = "SemVer"
+	flagHistoryLimit = 2
+	defer func() {
+		flagOutput = ""
+		flagShowVariable = ""
+		flagHistoryLimit = 100
+	}()
+
+	out, err := runHistory(t, repo.Path())
+	require.NoError(t, err)
+
+	var entries []historyEntry
+	require.NoError(t, json.Unmarshal([]byte(out), &entries))
+	require.Len(t, entries, 2)
+	require.Equal(t, shas[0], entries[0].Commit)
+	require.Equal(t, "fix: typo", entries[0].Subject)
+	require.Equal(t, "1.1.0", entries[0].Version)
+	require.Equal(t, shas[1], entries[1].Commit)
+}
+
+func TestHistory_ConfigOverride(t *testing.T) {
+	repo, _ := historyTestRepo(t)
+
+	flagSet = []string{"commit-message-incrementing=Disabled"}
+	flagShowVariable = "SemVer"
+	defer func() {
+		flagSet = nil
+		flagShowVariable = ""
+	}()
+
+	out, err := runHistory(t, repo.Path())
+	require.NoError(t, err)
+	require.Contains(t, out, "  1.0.1  fix: typo")
+}
+
+func TestHistory_UnknownVariable(t *testing.T) {
+	repo, _ := historyTestRepo(t)
+
+	flagShowVariable = "Nope"
+	defer func() { flagShowVariable = "" }()
+
+	_, err := runHistory(t, repo.Path())
+	require.ErrorContains(t, err, `unknown variable "Nope"`)
+}
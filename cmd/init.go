@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/config"
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/git"
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/preset"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagInitPreset string
+	flagInitForce  bool
+	flagInitStdout bool
+)
+
+var initCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Generate a starter configuration for the repository",
+	Long: `Generate a starter go-gitsemver.yml by inspecting the repository.
+Branches, version tags and the recent history of the main branch are used
+to pick a workflow preset (gitflow when a develop branch exists,
+github-flow when the main branch is built from merged pull requests,
+trunk-based otherwise), the tag prefix in use, and whether Conventional
+Commits are prevalent. The file is based on the matching example from
+docs/examples and keeps its comments.
+
+Use --preset to choose the preset instead. An existing configuration is
+never overwritten without --force.`,
+	Args: cobra.NoArgs,
+	RunE: initRunE,
+}
+
+func init() {
+	initCmd.Flags().StringVar(&flagInitPreset, "preset", "", "preset to use instead of the detected one ("+strings.Join(preset.Names(), ", ")+")")
+	initCmd.Flags().BoolVar(&flagInitForce, "force", false, "overwrite an existing configuration file")
+	initCmd.Flags().BoolVar(&flagInitStdout, "stdout", false, "print the configuration instead of writing go-gitsemver.yml")
+	_ = initCmd.RegisterFlagCompletionFunc("preset", func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		return preset.Names(), cobra.ShellCompDirectiveNoFileComp
+	})
+	rootCmd.AddCommand(initCmd)
+}
+
+func initRunE(cmd *cobra.Command, _ []string) error {
+	// 1. Open repository and refuse to replace an existing config.
+	repo, err := git.Open(flagPath)
+	if err != nil {
+		return fmt.Errorf("opening repository: %w", err)
+	}
+	target := filepath.Join(repo.WorkingDirectory(), "go-gitsemver.yml")
+	existing := findConfigFile(repo.WorkingDirectory())
+	if existing != "" && !flagInitStdout && !flagInitForce {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("configuration already exists at %s (use --force to overwrite, or config migrate to convert a GitVersion file)", existing)
+	}
+
+	// 2. Inspect branches, tags and commit messages against the defaults.
+	defaults, err := config.NewBuilder().Build()
+	if err != nil {
+		return fmt.Errorf("loading default configuration: %w", err)
+	}
+	detection, err := preset.Detect(git.NewRepositoryStore(repo).WithContext(commandContext(cmd)), defaults)
+	if err != nil {
+		return fmt.Errorf("inspecting repository: %w", err)
+	}
+
+	// 3. Render the chosen preset.
+	name := detection.Preset
+	if flagInitPreset != "" {
+		name = flagInitPreset
+	}
+	out, err := preset.Render(name, detection)
+	if err != nil {
+		return err
+	}
+	if problems := config.Validate(out); config.HasErrors(problems) {
+		return fmt.Errorf("preset %s produced an invalid configuration: %s", name, problems[0].Message)
+	}
+
+	stderr := cmd.ErrOrStderr()
+	for _, reason := range detection.Reasons {
+		fmt.Fprintf(stderr, "detected: %s\n", reason)
+	}
+	if name != detection.Preset {
+		fmt.Fprintf(stderr, "using preset %s instead of detected %s\n", name, detection.Preset)
+	}
+
+	// 4. Write the configuration.
+	if flagInitStdout {
+		_, err := cmd.OutOrStdout().Write(out)
+		return err
+	}
+	if err := os.WriteFile(target, out, 0o644); err != nil {
+		return fmt.Errorf("writing config: %w", err)
+	}
+	fmt.Fprintf(stderr, "wrote %s from the %s preset\n", target, name)
+	if existing != "" && existing != target {
+		fmt.Fprintf(stderr, "warning: %s takes precedence over %s; remove it to use the new file\n", existing, target)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/testutil"
+
+	"github.com/stretchr/testify/require"
+)
+
+func runInit(t *testing.T, path string) (string, string, error) {
+	t.Helper()
+	flagPath = path
+	defer func() { flagPath = "." }()
+
+	var stdout, stderr bytes.Buffer
+	initCmd.SetOut(&stdout)
+	initCmd.SetErr(&stderr)
+	defer func() {
+		initCmd.SetOut(nil)
+		initCmd.SetErr(nil)
+	}()
+
+	err := initRunE(initCmd, nil)
+	return stdout.String(), stderr.String(), err
+}
+
+func TestInit_WritesDetectedPreset(t *testing.T) {
+	repo := testutil.NewTestRepo(t)
+	sha := repo.AddCommit("initial")
+	repo.CreateTag("v1.0.0", sha)
+	repo.CreateBranch("develop", sha)
+
+	_, stderr, err := runInit(t, repo.Path())
+	require.NoError(t, err)
+	require.Contains(t, stderr, "detected: branch develop matches the develop branch configuration")
+	require.Contains(t, stderr, "from the gitflow preset")
+
+	data, err := os.ReadFile(filepath.Join(repo.Path(), "go-gitsemver.yml"))
+	require.NoError(t, err)
+	require.Contains(t, string(data), "# Generated by go-gitsemver init from the gitflow preset.")
+	require.Contains(t, string(data), "\ntag-prefix: '[vV]'\n")
+}
+
+func TestInit_PresetOverrideToStdout(t *testing.T) {
+	repo := testutil.NewTestRepo(t)
+	repo.AddCommit("initial")
+
+	flagInitPreset = "mainline-each-commit"
+	flagInitStdout = true
+	defer func() {
+		flagInitPreset = ""
+		flagInitStdout = false
+	}()
+
+	stdout, stderr, err := runInit(t, repo.Path())
+	require.NoError(t, err)
+	require.Contains(t, stdout, "# Generated by go-gitsemver init from the mainline-each-commit preset.")
+	require.Contains(t, stderr, "using preset mainline-each-commit instead of detected trunk-based")
+	require.NoFileExists(t, filepath.Join(repo.Path(), "go-gitsemver.yml"))
+}
+
+func TestInit_RefusesExistingConfig(t *testing.T) {
+	repo := testutil.NewTestRepo(t)
+	repo.AddCommit("initial")
+	repo.WriteConfig("mode: Mainline\n")
+
+	_, _, err := runInit(t, repo.Path())
+	require.ErrorContains(t, err, "configuration already exists")
+
+	flagInitForce = true
+	defer func() { flagInitForce = false }()
+	_, _, err = runInit(t, repo.Path())
+	require.NoError(t, err)
+}
+
+func TestInit_UnknownPreset(t *testing.T) {
+	repo := testutil.NewTestRepo(t)
+	repo.AddCommit("initial")
+
+	flagInitPreset = "nope"
+	defer func() { flagInitPreset = "" }()
+
+	_, _, err := runInit(t, repo.Path())
+	require.ErrorContains(t, err, `unknown preset "nope"`)
+}
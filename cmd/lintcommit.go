@@ -0,0 +1,273 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/calculator"
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/config"
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/git"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagLintRange string
+	flagLintTypes []string
+)
+
+// scissorsLine marks the start of the diff git appends to the message file
+// with commit --verbose; everything from it on is not part of the message.
+const scissorsLine = "# ------------------------ >8 ------------------------"
+
+var lintCommitCmd = &cobra.Command{
+	Use:   "lint-commit [message-file]",
+	Short: "Check commit messages and show the bump each one causes",
+	Long: `Check commit messages with the same patterns the version calculation
+uses, and show the bump each commit causes. Reported problems are malformed
+Conventional Commits headers, unknown types, bump directives that conflict
+with the header or each other, and directives or footers the configured
+commit-message-convention ignores. Settings come from the configuration of
+--branch (default: the current branch).
+
+Give a message file to check a commit being made, such as the file git
+passes to a commit-msg hook ("-" reads standard input), or --range to check
+existing commits. The command fails when any message has a problem.
+
+Examples:
+  go-gitsemver lint-commit .git/COMMIT_EDITMSG
+  go-gitsemver lint-commit --range origin/main..HEAD
+  echo 'feat: add login' | go-gitsemver lint-commit -
+
+commit-msg hook (.git/hooks/commit-msg):
+  #!/bin/sh
+  exec go-gitsemver lint-commit "$1"`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: lintCommitRunE,
+}
+
+func init() {
+	lintCommitCmd.Flags().StringVar(&flagLintRange, "range", "", "check the commits in a revision range such as origin/main..HEAD")
+	lintCommitCmd.Flags().StringSliceVar(&flagLintTypes, "type", nil, "additional Conventional Commits types to accept (default types: "+strings.Join(calculator.DefaultCommitTypes, ", ")+")")
+	rootCmd.AddCommand(lintCommitCmd)
+}
+
+// lintEntry is the lint-commit result for one message.
+type lintEntry struct {
+	Commit     string   `json:"commit,omitempty"` // empty for a message file
+	Subject    string   `json:"subject"`
+	Type       string   `json:"type,omitempty"`
+	Bump       string   `json:"bump"`
+	Convention string   `json:"convention,omitempty"`
+	Problems   []string `json:"problems"`
+}
+
+func lintCommitRunE(cmd *cobra.Command, args []string) error {
+	if (len(args) == 1) == (flagLintRange != "") {
+		return errors.New("give either a message file or --range")
+	}
+	if flagOutput != "" && flagOutput != "json" {
+		return fmt.Errorf("unknown output format %q", flagOutput)
+	}
+
+	// 1. Open repository and resolve the branch configuration.
+	repo, err := git.Open(flagPath)
+	if err != nil {
+		return fmt.Errorf("opening repository: %w", err)
+	}
+	cfg, err := loadConfig(repo.WorkingDirectory())
+	if err != nil {
+		return fmt.Errorf("loading configuration: %w", err)
+	}
+	ec, err := lintConfiguration(commandContext(cmd), repo, cfg)
+	if err != nil {
+		return err
+	}
+
+	// 2. Collect the messages.
+	var commits []git.Commit
+	if flagLintRange != "" {
+		commits, err = rangeCommits(commandContext(cmd), repo, flagLintRange)
+		if err != nil {
+			return err
+		}
+	} else {
+		msg, err := readMessageFile(cmd.InOrStdin(), args[0])
+		if err != nil {
+			return err
+		}
+		commits = []git.Commit{{Message: msg}}
+	}
+
+	// 3. Lint each message.
+	types := slices.Concat(calculator.DefaultCommitTypes, flagLintTypes)
+	entries := make([]lintEntry, 0, len(commits))
+	problems := 0
+	for _, c := range commits {
+		lint := calculator.LintCommit(c, ec, types)
+		subject, _, _ := strings.Cut(c.Message, "\n")
+		entries = append(entries, lintEntry{
+			Commit:     c.Sha,
+			Subject:    subject,
+			Type:       lint.Type,
+			Bump:       lint.Bump.String(),
+			Convention: lint.Convention,
+			Problems:   nonNilStrings(lint.Problems),
+		})
+		problems += len(lint.Problems)
+	}
+
+	// 4. Write the report.
+	if err := writeLintReport(cmd.OutOrStdout(), entries); err != nil {
+		return err
+	}
+	if problems > 0 {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%d commit message problem(s)", problems)
+	}
+	return nil
+}
+
+// lintConfiguration returns the effective configuration for --branch or the
+// current branch. Before the first commit HEAD names no branch yet, so the
+// main branch configuration is used.
+func lintConfiguration(ctx context.Context, repo *git.GoGitRepository, cfg *config.Config) (config.EffectiveConfiguration, error) {
+	branch := flagBranch
+	if branch == "" {
+		head, err := repo.Head(ctx)
+		if err == nil {
+			branch = head.FriendlyName()
+		} else {
+			branch = "main"
+		}
+	}
+	ec, err := cfg.GetEffectiveConfiguration(branch)
+	if err != nil {
+		return config.EffectiveConfiguration{}, fmt.Errorf("resolving branch configuration: %w", err)
+	}
+	return ec, nil
+}
+
+// rangeCommits returns the commits in a "from..to" range, newest first. An
+// empty side means HEAD; the range starts at the merge base of both sides,
+// as with git log.
+func rangeCommits(ctx context.Context, repo *git.GoGitRepository, rng string) ([]git.Commit, error) {
+	fromRev, toRev, ok := strings.Cut(rng, "..")
+	if !ok {
+		return nil, fmt.Errorf("invalid --range %q, expected from..to", rng)
+	}
+	if fromRev == "" {
+		fromRev = "HEAD"
+	}
+	if toRev == "" {
+		toRev = "HEAD"
+	}
+	from, err := repo.ResolveRevision(fromRev)
+	if err != nil {
+		return nil, err
+	}
+	to, err := repo.ResolveRevision(toRev)
+	if err != nil {
+		return nil, err
+	}
+
+	store := git.NewRepositoryStore(repo).WithContext(ctx)
+	fromCommit, err := repo.CommitFromSha(ctx, from)
+	if err != nil {
+		return nil, fmt.Errorf("loading commit %s: %w", fromRev, err)
+	}
+	toCommit, err := repo.CommitFromSha(ctx, to)
+	if err != nil {
+		return nil, fmt.Errorf("loading commit %s: %w", toRev, err)
+	}
+	base, found, err := store.FindMergeBaseFromCommits(fromCommit, toCommit)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		base = git.Commit{}
+	}
+	commits, err := store.GetCommitLog(base, toCommit)
+	if err != nil {
+		return nil, fmt.Errorf("listing commits: %w", err)
+	}
+	return commits, nil
+}
+
+// readMessageFile reads a commit message file, or r for "-", and removes
+// what git strips before committing: comment lines, the diff below the
+// scissors line, and trailing blank lines.
+func readMessageFile(r io.Reader, path string) (string, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(r)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return "", fmt.Errorf("reading commit message: %w", err)
+	}
+
+	var lines []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if line == scissorsLine {
+			break
+		}
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, strings.TrimRight(line, " \t\r"))
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n")), nil
+}
+
+// writeLintReport writes entries as text, or JSON with --output json.
+func writeLintReport(w io.Writer, entries []lintEntry) error {
+	if flagOutput == "json" {
+		data, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshaling report: %w", err)
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	}
+
+	for _, e := range entries {
+		indent := ""
+		if e.Commit != "" {
+			if _, err := fmt.Fprintf(w, "%s %s\n", e.Commit[:min(7, len(e.Commit))], e.Subject); err != nil {
+				return err
+			}
+			indent = "  "
+		}
+		bump := e.Bump
+		if e.Convention != "" {
+			bump += " (" + e.Convention + ")"
+		}
+		if _, err := fmt.Fprintf(w, "%sbump: %s\n", indent, bump); err != nil {
+			return err
+		}
+		for _, p := range e.Problems {
+			if _, err := fmt.Fprintf(w, "%sproblem: %s\n", indent, p); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// nonNilStrings returns s, or an empty slice so that JSON shows [] rather
+// than null.
+func nonNilStrings(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/testutil"
+
+	"github.com/stretchr/testify/require"
+)
+
+func runLintCommit(t *testing.T, path, stdin string, args ...string) (string, error) {
+	t.Helper()
+	flagPath = path
+	defer func() { flagPath = "." }()
+
+	var stdout bytes.Buffer
+	lintCommitCmd.SetIn(strings.NewReader(stdin))
+	lintCommitCmd.SetOut(&stdout)
+	defer func() {
+		lintCommitCmd.SetIn(nil)
+		lintCommitCmd.SetOut(nil)
+	}()
+
+	err := lintCommitRunE(lintCommitCmd, args)
+	return stdout.String(), err
+}
+
+func TestLintCommit_MessageFile(t *testing.T) {
+	repo := testutil.NewTestRepo(t)
+	repo.AddCommit("initial")
+
+	msgFile := filepath.Join(t.TempDir(), "COMMIT_EDITMSG")
+	content := "feat(auth): add login\n\nDetails.\n# Please enter the commit message\n" + scissorsLine + "\ndiff --git a/x b/x\n+semver: major\n"
+	require.NoError(t, os.WriteFile(msgFile, []byte(content), 0o644))
+
+	out, err := runLintCommit(t, repo.Path(), "", msgFile)
+	require.NoError(t, err)
+	require.Equal(t, "bump: Minor (Conventional Commits)\n", out)
+}
+
+func TestLintCommit_StdinProblems(t *testing.T) {
+	repo := testutil.NewTestRepo(t)
+	repo.AddCommit("initial")
+
+	out, err := runLintCommit(t, repo.Path(), "fix: rename flag +semver: major\n", "-")
+	require.ErrorContains(t, err, "1 commit message problem(s)")
+	require.Equal(t, "bump: Major (Bump Directive)\nproblem: \"fix:\" means Patch but \"+semver: major\" means Major; Major is used\n", out)
+}
+
+func TestLintCommit_ExtraTypes(t *testing.T) {
+	repo := testutil.NewTestRepo(t)
+	repo.AddCommit("initial")
+
+	_, err := runLintCommit(t, repo.Path(), "deps: bump go-git\n", "-")
+	require.ErrorContains(t, err, "1 commit message problem(s)")
+
+	flagLintTypes = []string{"deps"}
+	defer func() { flagLintTypes = nil }()
+	out, err := runLintCommit(t, repo.Path(), "deps: bump go-git\n", "-")
+	require.NoError(t, err)
+	require.Equal(t, "bump: None\n", out)
+}
+
+func TestLintCommit_Range(t *testing.T) {
+	repo := testutil.NewTestRepo(t)
+	base := repo.AddCommit("initial")
+	repo.CreateBranch("feature/login", base)
+	repo.Checkout("feature/login")
+	repo.AddCommit("feat: add login")
+	bad := repo.AddCommit("feat:missing space")
+
+	flagLintRange = "master..feature/login"
+	flagOutput = "json"
+	defer func() {
+		flagLintRange = ""
+		flagOutput = ""
+	}()
+
+	out, err := runLintCommit(t, repo.Path(), "")
+	require.ErrorContains(t, err, "1 commit message problem(s)")
+
+	var entries []lintEntry
+	require.NoError(t, json.Unmarshal([]byte(out), &entries))
+	require.Len(t, entries, 2)
+	require.Equal(t, bad, entries[0].Commit)
+	require.Equal(t, "None", entries[0].Bump)
+	require.Len(t, entries[0].Problems, 1)
+	require.Equal(t, "feat", entries[1].Type)
+	require.Equal(t, "Minor", entries[1].Bump)
+	require.Empty(t, entries[1].Problems)
+}
+
+func TestLintCommit_RangeUsesCommandContext(t *testing.T) {
+	repo := testutil.NewTestRepo(t)
+	repo.AddCommit("initial")
+	repo.AddCommit("feat: add login")
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	lintCommitCmd.SetContext(ctx)
+	flagLintRange = "HEAD~1..HEAD"
+	defer func() {
+		lintCommitCmd.SetContext(nil)
+		flagLintRange = ""
+	}()
+
+	_, err := runLintCommit(t, repo.Path(), "")
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestLintCommit_Args(t *testing.T) {
+	repo := testutil.NewTestRepo(t)
+	repo.AddCommit("initial")
+
+	_, err := runLintCommit(t, repo.Path(), "")
+	require.ErrorContains(t, err, "give either a message file or --range")
+
+	flagLintRange = "main"
+	defer func() { flagLintRange = "" }()
+	_, err = runLintCommit(t, repo.Path(), "")
+	require.ErrorContains(t, err, `invalid --range "main", expected from..to`)
+}
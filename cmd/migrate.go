@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/config"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagMigrateFrom  int
+	flagMigrateWrite string
+)
+
+var configMigrateCmd = &cobra.Command{
+	Use:   "migrate [file]",
+	Short: "Convert a GitVersion 5 or 6 configuration file",
+	Long: `Convert a GitVersion 5 or 6 configuration file to go-gitsemver syntax.
+GitVersion 6 key names (label, label-number-pattern, is-main-branch,
+prevent-increment) are renamed, GitVersion 6 mode values are translated,
+and keys without an equivalent are removed. Every change is reported on
+stderr.
+
+The GitVersion version is detected from the keys used unless --from is
+given. The result is written to stdout, or to the file named by --write.
+Without a file argument, the file is located as for version calculation.
+Exits non-zero when the migrated configuration does not validate.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: configMigrateRunE,
+}
+
+func init() {
+	configMigrateCmd.Flags().IntVar(&flagMigrateFrom, "from", 0, "GitVersion major version of the file (5 or 6; default: detect)")
+	configMigrateCmd.Flags().StringVarP(&flagMigrateWrite, "write", "w", "", "Write the migrated config to this file instead of stdout")
+	configCmd.AddCommand(configMigrateCmd)
+}
+
+func configMigrateRunE(cmd *cobra.Command, args []string) error {
+	// 1. Locate and read the configuration file.
+	path, err := configFileArg(args)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading config file: %w", err)
+	}
+
+	// 2. Migrate, reporting each change.
+	out, issues, err := config.Migrate(data, flagMigrateFrom)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	stderr := cmd.ErrOrStderr()
+	for _, issue := range issues {
+		fmt.Fprintln(stderr, issueLine(path, issue))
+	}
+
+	// 3. Write the result.
+	target := "stdout"
+	if flagMigrateWrite != "" {
+		target = flagMigrateWrite
+		if err := os.WriteFile(flagMigrateWrite, out, 0o644); err != nil {
+			return fmt.Errorf("writing migrated config: %w", err)
+		}
+	} else if _, err := cmd.OutOrStdout().Write(out); err != nil {
+		return err
+	}
+
+	// 4. Check the result as config validate would.
+	problems := config.Validate(out)
+	for _, issue := range problems {
+		fmt.Fprintln(stderr, issueLine(target, issue))
+	}
+	if config.HasErrors(problems) {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%s: migrated configuration is invalid", target)
+	}
+	return nil
+}
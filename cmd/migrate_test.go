@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigMigrate_Stdout(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "GitVersion.yml")
+	require.NoError(t, os.WriteFile(path, []byte("mode: ManualDeployment\nbranches:\n  feature:\n    label: '{BranchName}'\n"), 0o644))
+
+	var stdout, stderr bytes.Buffer
+	configMigrateCmd.SetOut(&stdout)
+	configMigrateCmd.SetErr(&stderr)
+	defer func() {
+		configMigrateCmd.SetOut(nil)
+		configMigrateCmd.SetErr(nil)
+	}()
+
+	require.NoError(t, configMigrateRunE(configMigrateCmd, []string{path}))
+	require.Equal(t, "mode: ContinuousDelivery\nbranches:\n  feature:\n    tag: '{BranchName}'\n", stdout.String())
+	require.Contains(t, stderr.String(), path+":1:7: warning: GitVersion 6 mode ManualDeployment is ContinuousDelivery")
+	require.Contains(t, stderr.String(), path+`:4:5: warning: "label" is the GitVersion 6 name of "tag"`)
+}
+
+func TestConfigMigrate_WriteFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "GitVersion.yml")
+	require.NoError(t, os.WriteFile(path, []byte("mode: Mainline\nassembly-versioning-scheme: None\n"), 0o644))
+	target := filepath.Join(dir, "go-gitsemver.yml")
+
+	flagMigrateWrite = target
+	defer func() { flagMigrateWrite = "" }()
+
+	var stdout, stderr bytes.Buffer
+	configMigrateCmd.SetOut(&stdout)
+	configMigrateCmd.SetErr(&stderr)
+	defer func() {
+		configMigrateCmd.SetOut(nil)
+		configMigrateCmd.SetErr(nil)
+	}()
+
+	require.NoError(t, configMigrateRunE(configMigrateCmd, []string{path}))
+	require.Empty(t, stdout.String())
+	require.Contains(t, stderr.String(), `removed unsupported key "assembly-versioning-scheme"`)
+
+	data, err := os.ReadFile(target)
+	require.NoError(t, err)
+	require.Equal(t, "mode: Mainline\n", string(data))
+}
+
+func TestConfigMigrate_InvalidResult(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "GitVersion.yml")
+	require.NoError(t, os.WriteFile(path, []byte("branches:\n  feature:\n    regex: '^feature/(?!wip)'\n"), 0o644))
+
+	var stdout, stderr bytes.Buffer
+	configMigrateCmd.SetOut(&stdout)
+	configMigrateCmd.SetErr(&stderr)
+	defer func() {
+		configMigrateCmd.SetOut(nil)
+		configMigrateCmd.SetErr(nil)
+	}()
+
+	err := configMigrateRunE(configMigrateCmd, []string{path})
+	require.ErrorContains(t, err, "migrated configuration is invalid")
+	require.Contains(t, stderr.String(), "stdout:3:5: error: invalid regex")
+}
+
+func TestLoadConfig_WarnsOnGitVersion6Keys(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "GitVersion.yml")
+	require.NoError(t, os.WriteFile(path, []byte("branches:\n  feature:\n    label: beta\n"), 0o644))
+
+	old := os.Stderr
+	r, w, _ := os.Pipe()
+	os.Stderr = w
+	cfg, err := loadConfig(dir)
+	w.Close()
+	os.Stderr = old
+	require.NoError(t, err)
+	require.Equal(t, "beta", *cfg.Branches["feature"].Tag)
+
+	buf := make([]byte, 512)
+	n, _ := r.Read(buf)
+	require.Contains(t, string(buf[:n]), path+`:3:5: warning: "label" is the GitVersion 6 name of "tag"`)
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/calculator"
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/git"
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/output"
+
+	ghprovider "github.com/MyCarrier-DevOps/go-gitsemver/internal/github"
+)
+
+// Accepted --publish values.
+const (
+	publishStatus = "status"
+	publishCheck  = "check"
+)
+
+// validatePublish checks the --publish flag value.
+func validatePublish() error {
+	switch flagPublish {
+	case "", publishStatus, publishCheck:
+		return nil
+	}
+	return fmt.Errorf("invalid --publish value %q, expected %q or %q", flagPublish, publishStatus, publishCheck)
+}
+
+// publishVersion publishes the calculated version on sha as a commit status
+// or check run, depending on --publish.
+func publishVersion(ctx context.Context, repo *ghprovider.GitHubRepository, sha string, result calculator.VersionResult) error {
+	summary := "Will release " + output.FormatSummary(result)
+
+	switch flagPublish {
+	case publishStatus:
+		return repo.CreateCommitStatus(ctx, sha, summary)
+	case publishCheck:
+		text := "```\n" + output.FormatExplanation(result) + "```\n"
+		return repo.CreateCheckRun(ctx, sha, result.Version.SemVer(), summary, text)
+	}
+	return nil
+}
+
+// localPublisher creates a GitHubRepository for publishing from local mode.
+// The repository is taken from GITHUB_REPOSITORY (set in GitHub Actions) or
+// the origin remote; credentials come from the usual environment variables.
+func localPublisher(ctx context.Context, repo *git.GoGitRepository) (*ghprovider.GitHubRepository, error) {
+	var owner, name string
+	var err error
+	if env := os.Getenv("GITHUB_REPOSITORY"); env != "" {
+		owner, name, err = parseOwnerRepo(env)
+	} else {
+		var url string
+		url, err = repo.RemoteURL("origin")
+		if err == nil {
+			owner, name, err = ghprovider.ParseRepositoryURL(url)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("resolving GitHub repository: %w", err)
+	}
+
+	baseURL := ghprovider.ResolveBaseURL("")
+	client, err := ghprovider.NewClient(ctx, ghprovider.ClientConfig{
+		BaseURL: baseURL,
+		Owner:   owner,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating GitHub client: %w", err)
+	}
+
+	var opts []ghprovider.Option
+	if baseURL != "" {
+		opts = append(opts, ghprovider.WithBaseURL(baseURL))
+	}
+	return ghprovider.NewGitHubRepository(client, owner, name, opts...), nil
+}
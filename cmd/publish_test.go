@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/calculator"
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/git"
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/semver"
+)
+
+func TestValidatePublish(t *testing.T) {
+	defer func() { flagPublish = "" }()
+
+	for _, v := range []string{"", "status", "check"} {
+		flagPublish = v
+		require.NoError(t, validatePublish())
+	}
+
+	flagPublish = "comment"
+	err := validatePublish()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid --publish value")
+}
+
+func publishTestResult() calculator.VersionResult {
+	exp := &calculator.IncrementExplanation{}
+	exp.Decide(git.Commit{Sha: "abc1234def", Message: "feat!: drop v1 API"}, semver.VersionFieldMajor)
+	return calculator.VersionResult{
+		Version:              semver.SemanticVersion{Major: 2},
+		IncrementExplanation: exp,
+	}
+}
+
+func TestPublishVersion_Status(t *testing.T) {
+	flagPublish = "status"
+	defer func() { flagPublish = "" }()
+
+	var got map[string]interface{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v3/repos/testowner/testrepo/statuses/abc1234def", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		w.WriteHeader(http.StatusCreated)
+		writeTestJSON(w, map[string]interface{}{})
+	})
+	repo, cleanup := newTestGHRepo(t, mux)
+	defer cleanup()
+
+	require.NoError(t, publishVersion(t.Context(), repo, "abc1234def", publishTestResult()))
+	require.Equal(t, "Will release 2.0.0 (breaking change in abc1234)", got["description"])
+}
+
+func TestPublishVersion_Check(t *testing.T) {
+	flagPublish = "check"
+	defer func() { flagPublish = "" }()
+
+	var got map[string]interface{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v3/repos/testowner/testrepo/check-runs", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		w.WriteHeader(http.StatusCreated)
+		writeTestJSON(w, map[string]interface{}{"id": 1})
+	})
+	repo, cleanup := newTestGHRepo(t, mux)
+	defer cleanup()
+
+	require.NoError(t, publishVersion(t.Context(), repo, "abc1234def", publishTestResult()))
+	out := got["output"].(map[string]interface{})
+	require.Equal(t, "2.0.0", out["title"])
+	require.Equal(t, "Will release 2.0.0 (breaking change in abc1234)", out["summary"])
+	require.True(t, strings.HasPrefix(out["text"].(string), "```\n"))
+}
+
+func TestLocalPublisher_FromEnvironment(t *testing.T) {
+	t.Setenv("GITHUB_REPOSITORY", "myorg/myrepo")
+	t.Setenv("GITHUB_TOKEN", "test-token")
+	t.Setenv("GITHUB_API_URL", "")
+
+	repo, err := localPublisher(t.Context(), nil)
+	require.NoError(t, err)
+	require.NotNil(t, repo)
+}
+
+func TestLocalPublisher_InvalidEnvironment(t *testing.T) {
+	t.Setenv("GITHUB_REPOSITORY", "not-a-repo")
+
+	_, err := localPublisher(t.Context(), nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "resolving GitHub repository")
+}
@@ -1,9 +1,11 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"os"
 	"strings"
+	"time"
 
 	"github.com/MyCarrier-DevOps/go-gitsemver/internal/calculator"
 	"github.com/MyCarrier-DevOps/go-gitsemver/internal/config"
@@ -27,6 +29,9 @@ var (
 	flagRef              string
 	flagMaxCommits       int
 	flagRemoteConfigPath string
+	flagMaxRetryWait     time.Duration
+	flagConcurrency      int
+	flagPullRequest      int
 )
 
 var remoteCmd = &cobra.Command{
@@ -43,6 +48,7 @@ Authentication (checked in order):
 Examples:
   GITHUB_TOKEN=ghp_xxx go-gitsemver remote myorg/myrepo
   go-gitsemver remote myorg/myrepo --token ghp_xxx --ref main
+  go-gitsemver remote myorg/myrepo --pull-request 123
   go-gitsemver remote myorg/myrepo --github-app-id 12345 --github-app-key "$APP_PRIVATE_KEY"
   go-gitsemver remote myorg/myrepo --github-app-id 12345 --github-app-key-path /path/to/key.pem`,
 	Args: cobra.ExactArgs(1),
@@ -56,30 +62,51 @@ func init() {
 	remoteCmd.Flags().StringVar(&flagAppKeyPath, "github-app-key-path", "", "path to GitHub App private key PEM file (or set GH_APP_PRIVATE_KEY_PATH env var)")
 	remoteCmd.Flags().StringVar(&flagGitHubURL, "github-url", "", "GitHub API base URL for GitHub Enterprise (or set GITHUB_API_URL env var)")
 	remoteCmd.Flags().StringVar(&flagRef, "ref", "", "git ref to version: branch, tag, or SHA (default: repo default branch)")
+	remoteCmd.Flags().IntVar(&flagPullRequest, "pull-request", 0, "version pull request N as branch pull/N/merge, as if merged into its base")
 	remoteCmd.Flags().IntVar(&flagMaxCommits, "max-commits", 1000, "maximum commit depth to walk via API")
 	remoteCmd.Flags().StringVar(&flagRemoteConfigPath, "remote-config-path", "", "path to config file in the remote repo (e.g. .github/GitVersion.yml)")
+	remoteCmd.Flags().IntVar(&flagConcurrency, "concurrency", 8, "parallel API requests for prefetching tag peels and merge bases (1 disables)")
+	remoteCmd.Flags().DurationVar(&flagMaxRetryWait, "max-retry-wait", 2*time.Minute, "total time to wait on GitHub rate limits and transient errors before failing")
 
 	rootCmd.AddCommand(remoteCmd)
 }
 
-func remoteRunE(_ *cobra.Command, args []string) error {
+func remoteRunE(cmd *cobra.Command, args []string) error {
+	reqCtx := commandContext(cmd)
+
 	// 1. Parse owner/repo.
 	owner, repo, err := parseOwnerRepo(args[0])
 	if err != nil {
 		return err
 	}
+	if flagPullRequest > 0 && flagRef != "" {
+		return errors.New("--pull-request and --ref are mutually exclusive")
+	}
+	if err := validatePublish(); err != nil {
+		return err
+	}
+	if err := validateShowConfig(); err != nil {
+		return err
+	}
+	if err := validateExplainFormat(); err != nil {
+		return err
+	}
+	if err := validateExplainGraph(); err != nil {
+		return err
+	}
 
 	// 2. Resolve base URL from flag or env var so both client and repository use it.
 	baseURL := ghprovider.ResolveBaseURL(flagGitHubURL)
 
 	// 3. Create GitHub client.
-	client, err := ghprovider.NewClient(ghprovider.ClientConfig{
+	client, err := ghprovider.NewClient(reqCtx, ghprovider.ClientConfig{
 		Token:      flagToken,
 		AppID:      flagAppID,
 		AppKey:     flagAppKey,
 		AppKeyPath: flagAppKeyPath,
 		BaseURL:    baseURL,
 		Owner:      owner,
+		Retry:      ghprovider.RetryConfig{MaxWait: flagMaxRetryWait},
 	})
 	if err != nil {
 		return fmt.Errorf("creating GitHub client: %w", err)
@@ -93,27 +120,52 @@ func remoteRunE(_ *cobra.Command, args []string) error {
 	if flagMaxCommits > 0 {
 		opts = append(opts, ghprovider.WithMaxCommits(flagMaxCommits))
 	}
+	if flagPullRequest > 0 {
+		opts = append(opts, ghprovider.WithPullRequest(flagPullRequest))
+	}
+	if flagConcurrency > 0 {
+		opts = append(opts, ghprovider.WithConcurrency(flagConcurrency))
+	}
 	if baseURL != "" {
 		opts = append(opts, ghprovider.WithBaseURL(baseURL))
 	}
 	ghRepo := ghprovider.NewGitHubRepository(client, owner, repo, opts...)
 
 	// 5. Load configuration.
-	cfg, err := loadRemoteConfig(ghRepo)
+	builder, err := remoteConfigBuilder(reqCtx, ghRepo)
+	if err != nil {
+		return fmt.Errorf("loading configuration: %w", err)
+	}
+	cfg, sources, err := builder.BuildAnnotated()
 	if err != nil {
 		return fmt.Errorf("loading configuration: %w", err)
 	}
 
 	// 6. Show config mode.
-	if flagShowConfig {
-		return showConfig(cfg)
+	if flagShowConfig == showConfigMerged {
+		return showConfig(cfg, sources)
+	}
+	if flagShowConfig == showConfigEffective && flagBranch != "" {
+		// The branch name alone selects the config; it need not exist.
+		ec, err := cfg.GetEffectiveConfiguration(flagBranch)
+		if err != nil {
+			return fmt.Errorf("resolving branch configuration: %w", err)
+		}
+		return showEffectiveConfig(flagBranch, ec, sources)
 	}
 
-	// 7. Build context.
-	store := git.NewRepositoryStore(ghRepo)
+	// 7. Build context. For pull requests, the PR head is the commit to version.
+	commitID := flagCommit
+	if commitID == "" {
+		commitID, err = ghRepo.PullRequestHead(reqCtx)
+		if err != nil {
+			return fmt.Errorf("resolving pull request: %w", err)
+		}
+	}
+	store := git.NewRepositoryStore(ghRepo).WithContext(reqCtx)
 	ctx, err := configctx.NewContext(store, ghRepo, cfg, configctx.Options{
 		TargetBranch: flagBranch,
-		CommitID:     flagCommit,
+		CommitID:     commitID,
 	})
 	if err != nil {
 		return fmt.Errorf("building context: %w", err)
@@ -124,26 +176,50 @@ func remoteRunE(_ *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("resolving branch configuration: %w", err)
 	}
+	if flagShowConfig == showConfigEffective {
+		return showEffectiveConfig(ctx.CurrentBranch.FriendlyName(), ec, sources)
+	}
 
-	// 9. Calculate version.
+	// 9. Prefetch tag peels and merge bases concurrently.
+	candidates, err := store.MergeBaseCandidates(ctx.CurrentBranch, cfg)
+	if err != nil {
+		return fmt.Errorf("resolving merge base candidates: %w", err)
+	}
+	if err := ghRepo.Prefetch(reqCtx, ctx.CurrentCommit, candidates); err != nil {
+		return fmt.Errorf("prefetching repository data: %w", err)
+	}
+
+	// 10. Calculate version.
 	strategies := strategy.AllStrategies(store)
 	calc := calculator.NewNextVersionCalculator(store, strategies)
-	result, err := calc.Calculate(ctx, ec, flagExplain)
+	result, err := calc.Calculate(ctx, ec, explaining() || flagPublish != "")
 	if err != nil {
 		return fmt.Errorf("calculating version: %w", err)
 	}
 
-	// 10. Write explain output to stderr if requested.
-	if flagExplain {
-		if err := output.WriteExplanation(os.Stderr, result); err != nil {
+	// 11. Write explain output and history graph to stderr if requested.
+	if explaining() {
+		if err := writeExplanation(result); err != nil {
 			return fmt.Errorf("writing explanation: %w", err)
 		}
 	}
+	if flagExplainGraph != "" {
+		if err := writeHistoryGraph(calc, ctx, ec, result); err != nil {
+			return fmt.Errorf("writing history graph: %w", err)
+		}
+	}
 
-	// 11. Compute output variables.
+	// 12. Publish to GitHub if requested.
+	if flagPublish != "" {
+		if err := publishVersion(reqCtx, ghRepo, ctx.CurrentCommit.Sha, result); err != nil {
+			return fmt.Errorf("publishing version: %w", err)
+		}
+	}
+
+	// 13. Compute output variables.
 	vars := output.GetVariables(result.Version, ec)
 
-	// 12. Write output.
+	// 14. Write output.
 	return writeOutput(vars)
 }
 
@@ -156,31 +232,45 @@ func parseOwnerRepo(s string) (string, string, error) {
 }
 
 // loadRemoteConfig fetches configuration from the remote repo or uses a local file.
-func loadRemoteConfig(ghRepo *ghprovider.GitHubRepository) (*config.Config, error) {
+func loadRemoteConfig(ctx context.Context, ghRepo *ghprovider.GitHubRepository) (*config.Config, error) {
+	builder, err := remoteConfigBuilder(ctx, ghRepo)
+	if err != nil {
+		return nil, err
+	}
+	return builder.Build()
+}
+
+// remoteConfigBuilder returns a Builder holding the remote (or local
+// override) config file and the files it extends, bases first. Files
+// extended by a remote config are fetched from the same repository.
+func remoteConfigBuilder(ctx context.Context, ghRepo *ghprovider.GitHubRepository) (*config.Builder, error) {
 	builder := config.NewBuilder()
+	read := func(path string) ([]byte, error) {
+		content, err := ghRepo.FetchFileContent(ctx, path)
+		if err != nil {
+			return nil, fmt.Errorf("fetching remote config %s: %w", path, err)
+		}
+		return []byte(content), nil
+	}
 
 	if flagConfig != "" {
 		// Use explicit local config file.
-		userCfg, err := config.LoadFromFile(flagConfig)
+		layers, err := config.LoadLayers(flagConfig, nil)
 		if err != nil {
 			return nil, err
 		}
-		builder.Add(userCfg)
+		addLayers(builder, layers)
 	} else if flagRemoteConfigPath != "" {
 		// Fetch a specific config file from the remote repo.
-		content, err := ghRepo.FetchFileContent(flagRemoteConfigPath)
+		layers, err := config.LoadLayers(flagRemoteConfigPath, read)
 		if err != nil {
-			return nil, fmt.Errorf("fetching remote config %s: %w", flagRemoteConfigPath, err)
-		}
-		userCfg, err := config.LoadFromBytes([]byte(content))
-		if err != nil {
-			return nil, fmt.Errorf("parsing remote config %s: %w", flagRemoteConfigPath, err)
+			return nil, err
 		}
-		builder.Add(userCfg)
+		addLayers(builder, layers)
 	} else {
 		// Auto-detect: try known config file names in the remote repo.
 		for _, name := range configFileNames {
-			content, err := ghRepo.FetchFileContent(name)
+			content, err := ghRepo.FetchFileContent(ctx, name)
 			if err != nil {
 				// 404 means the file doesn't exist — try the next name.
 				if ghprovider.IsNotFoundError(err) {
@@ -189,14 +279,17 @@ func loadRemoteConfig(ghRepo *ghprovider.GitHubRepository) (*config.Config, erro
 				// Other errors (auth failure, rate limit, network) should not be silently ignored.
 				return nil, fmt.Errorf("fetching remote config %s: %w", name, err)
 			}
-			userCfg, err := config.LoadFromBytes([]byte(content))
+			layers, err := config.LoadLayersFromBytes(name, []byte(content), read)
 			if err != nil {
 				return nil, fmt.Errorf("parsing remote config %s: %w", name, err)
 			}
-			builder.Add(userCfg)
+			addLayers(builder, layers)
 			break
 		}
 	}
 
-	return builder.Build()
+	if err := addOverrideLayers(builder); err != nil {
+		return nil, err
+	}
+	return builder, nil
 }
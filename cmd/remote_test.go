@@ -7,12 +7,14 @@ import (
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	gh "github.com/google/go-github/v68/github"
 	"github.com/stretchr/testify/require"
 
 	ghprovider "github.com/MyCarrier-DevOps/go-gitsemver/internal/github"
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/semver"
 )
 
 func TestParseOwnerRepo_Valid(t *testing.T) {
@@ -60,6 +62,9 @@ func TestRemoteCmd_HasExpectedFlags(t *testing.T) {
 	require.NotNil(t, flags.Lookup("github-url"))
 	require.NotNil(t, flags.Lookup("ref"))
 	require.NotNil(t, flags.Lookup("max-commits"))
+	require.NotNil(t, flags.Lookup("max-retry-wait"))
+	require.NotNil(t, flags.Lookup("concurrency"))
+	require.NotNil(t, flags.Lookup("pull-request"))
 }
 
 func TestRemoteCmd_MaxCommitsDefault(t *testing.T) {
@@ -68,6 +73,21 @@ func TestRemoteCmd_MaxCommitsDefault(t *testing.T) {
 	require.Equal(t, "1000", f.DefValue)
 }
 
+func TestRemoteCmd_MaxRetryWaitDefault(t *testing.T) {
+	f := remoteCmd.Flags().Lookup("max-retry-wait")
+	require.NotNil(t, f)
+	require.Equal(t, "2m0s", f.DefValue)
+}
+
+func TestRemoteRunE_PullRequestAndRefExclusive(t *testing.T) {
+	flagRef, flagPullRequest = "main", 12
+	defer func() { flagRef, flagPullRequest = "", 0 }()
+
+	err := remoteRunE(nil, []string{"myorg/myrepo"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "mutually exclusive")
+}
+
 func TestRemoteCmd_IsRegistered(t *testing.T) {
 	found := false
 	for _, sub := range rootCmd.Commands() {
@@ -114,7 +134,7 @@ func TestLoadRemoteConfig_FetchesFromRemote(t *testing.T) {
 	flagConfig = ""
 	defer func() { flagConfig = "" }()
 
-	cfg, err := loadRemoteConfig(ghRepo)
+	cfg, err := loadRemoteConfig(t.Context(), ghRepo)
 	require.NoError(t, err)
 	require.NotNil(t, cfg)
 	require.Equal(t, "3.0.0", *cfg.NextVersion)
@@ -144,7 +164,7 @@ func TestLoadRemoteConfig_FallsBackToGitsemverYml(t *testing.T) {
 	flagConfig = ""
 	defer func() { flagConfig = "" }()
 
-	cfg, err := loadRemoteConfig(ghRepo)
+	cfg, err := loadRemoteConfig(t.Context(), ghRepo)
 	require.NoError(t, err)
 	require.NotNil(t, cfg)
 	require.Equal(t, "4.0.0", *cfg.NextVersion)
@@ -163,7 +183,7 @@ func TestLoadRemoteConfig_NoRemoteConfig_UsesDefaults(t *testing.T) {
 	flagConfig = ""
 	defer func() { flagConfig = "" }()
 
-	cfg, err := loadRemoteConfig(ghRepo)
+	cfg, err := loadRemoteConfig(t.Context(), ghRepo)
 	require.NoError(t, err)
 	require.NotNil(t, cfg)
 	// Should have default branches.
@@ -183,7 +203,7 @@ func TestLoadRemoteConfig_LocalConfigOverride(t *testing.T) {
 	flagConfig = path
 	defer func() { flagConfig = "" }()
 
-	cfg, err := loadRemoteConfig(ghRepo)
+	cfg, err := loadRemoteConfig(t.Context(), ghRepo)
 	require.NoError(t, err)
 	require.NotNil(t, cfg)
 	require.Equal(t, "9.0.0", *cfg.NextVersion)
@@ -197,7 +217,7 @@ func TestLoadRemoteConfig_LocalConfigInvalid(t *testing.T) {
 	flagConfig = "/nonexistent/config.yml"
 	defer func() { flagConfig = "" }()
 
-	_, err := loadRemoteConfig(ghRepo)
+	_, err := loadRemoteConfig(t.Context(), ghRepo)
 	require.Error(t, err)
 }
 
@@ -214,7 +234,7 @@ func TestLoadRemoteConfig_FailsOnAuthError(t *testing.T) {
 	flagConfig = ""
 	defer func() { flagConfig = "" }()
 
-	_, err := loadRemoteConfig(ghRepo)
+	_, err := loadRemoteConfig(t.Context(), ghRepo)
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "fetching remote config")
 }
@@ -238,7 +258,42 @@ func TestLoadRemoteConfig_FailsOnInvalidYAML(t *testing.T) {
 	flagConfig = ""
 	defer func() { flagConfig = "" }()
 
-	_, err := loadRemoteConfig(ghRepo)
+	_, err := loadRemoteConfig(t.Context(), ghRepo)
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "parsing remote config")
 }
+
+func TestLoadRemoteConfig_ExtendsFetchesFromRepo(t *testing.T) {
+	files := map[string]string{
+		".github/GitVersion.yml": "extends: /policy/versioning.yml\nnext-version: 3.0.0\n",
+		"policy/versioning.yml":  "mode: Mainline\n",
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v3/repos/testowner/testrepo/contents/", func(w http.ResponseWriter, r *http.Request) {
+		content, ok := files[strings.TrimPrefix(r.URL.Path, "/api/v3/repos/testowner/testrepo/contents/")]
+		if !ok {
+			http.Error(w, `{"message":"Not Found"}`, http.StatusNotFound)
+			return
+		}
+		writeTestJSON(w, map[string]interface{}{
+			"type":     "file",
+			"encoding": "base64",
+			"content":  base64.StdEncoding.EncodeToString([]byte(content)),
+		})
+	})
+
+	ghRepo, cleanup := newTestGHRepo(t, mux)
+	defer cleanup()
+
+	flagConfig = ""
+	defer func() { flagConfig = "" }()
+
+	builder, err := remoteConfigBuilder(t.Context(), ghRepo)
+	require.NoError(t, err)
+	cfg, sources, err := builder.BuildAnnotated()
+	require.NoError(t, err)
+	require.Equal(t, "3.0.0", *cfg.NextVersion)
+	require.Equal(t, semver.VersioningModeMainline, *cfg.Mode)
+	require.Equal(t, "policy/versioning.yml", sources["mode"])
+	require.Equal(t, ".github/GitVersion.yml", sources["next-version"])
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
 
@@ -9,15 +10,19 @@ import (
 
 // Global flags shared across commands.
 var (
-	flagPath         string
-	flagBranch       string
-	flagCommit       string
-	flagConfig       string
-	flagOutput       string
-	flagShowVariable string
-	flagShowConfig   bool
-	flagExplain      bool
-	flagVerbosity    string
+	flagPath          string
+	flagBranch        string
+	flagCommit        string
+	flagConfig        string
+	flagOutput        string
+	flagShowVariable  string
+	flagShowConfig    string
+	flagExplain       bool
+	flagExplainFormat string
+	flagExplainGraph  string
+	flagVerbosity     string
+	flagPublish       string
+	flagSet           []string
 )
 
 // rootCmd is the top-level command for go-gitsemver.
@@ -36,11 +41,25 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&flagConfig, "config", "", "path to config file (default: auto-detect)")
 	rootCmd.PersistentFlags().StringVarP(&flagOutput, "output", "o", "", "output format: json, buildserver, or empty for default")
 	rootCmd.PersistentFlags().StringVar(&flagShowVariable, "show-variable", "", "output a single variable (e.g. SemVer, FullSemVer)")
-	rootCmd.PersistentFlags().BoolVar(&flagShowConfig, "show-config", false, "display the effective configuration and exit")
+	rootCmd.PersistentFlags().StringVar(&flagShowConfig, "show-config", "", "display the configuration and exit: \"merged\" (default) for the merged config, or \"effective\" for the values resolved for the target branch (true and false are accepted for compatibility)")
+	rootCmd.PersistentFlags().Lookup("show-config").NoOptDefVal = showConfigMerged
 	rootCmd.PersistentFlags().BoolVar(&flagExplain, "explain", false, "show how the version was calculated")
+	rootCmd.PersistentFlags().StringVar(&flagExplainFormat, "explain-format", explainFormatText, "explain output format on stderr: text or json (json implies --explain)")
+	rootCmd.PersistentFlags().StringVar(&flagExplainGraph, "explain-graph", "", "write the commit graph between the base version source and HEAD to stderr: dot or mermaid")
+	rootCmd.PersistentFlags().StringVar(&flagPublish, "publish", "", "publish the version to GitHub as a commit \"status\" or \"check\" run (local mode uses GITHUB_TOKEN and the origin remote)")
+	rootCmd.PersistentFlags().StringArrayVar(&flagSet, "set", nil, "override a config value, e.g. branches.main.increment=Minor (repeatable; applied after GITSEMVER_* environment variables)")
 	rootCmd.PersistentFlags().StringVarP(&flagVerbosity, "verbosity", "v", "info", "log verbosity: quiet, info, debug")
 }
 
+// commandContext returns the context cmd runs with, or context.Background
+// when there is none, as when a test calls a RunE function directly.
+func commandContext(cmd *cobra.Command) context.Context {
+	if cmd == nil || cmd.Context() == nil {
+		return context.Background()
+	}
+	return cmd.Context()
+}
+
 // Execute runs the root command.
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
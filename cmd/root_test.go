@@ -18,6 +18,8 @@ func TestRootCmd_HasExpectedFlags(t *testing.T) {
 	require.NotNil(t, flags.Lookup("show-config"))
 	require.NotNil(t, flags.Lookup("explain"))
 	require.NotNil(t, flags.Lookup("verbosity"))
+	require.NotNil(t, flags.Lookup("publish"))
+	require.NotNil(t, flags.Lookup("set"))
 }
 
 func TestRootCmd_HasVersionSubcommand(t *testing.T) {
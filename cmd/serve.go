@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	ghprovider "github.com/MyCarrier-DevOps/go-gitsemver/internal/github"
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/server"
+	"github.com/MyCarrier-DevOps/go-gitsemver/pkg/sdk"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagListen        string
+	flagAllowPaths    []string
+	flagAllowRepos    []string
+	flagWebhookSecret string
+	flagCacheTTL      time.Duration
+	flagCacheSize     int
+	flagCalcTimeout   time.Duration
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve version calculation as an HTTP API",
+	Long: `Serve version calculation over HTTP, so tools such as a developer portal
+can show the next version of every service without running CI.
+
+Endpoints:
+  GET  /v1/version?repo=owner/name&ref=main&explain=true
+  POST /v1/webhooks/github   (enabled by --webhook-secret)
+  GET  /metrics              (Prometheus)
+  GET  /healthz
+
+repo is a GitHub owner/name matching --allow-repo, calculated through the
+API, or a local repository path given with --allow-path; ref is a branch,
+tag or SHA for remote repositories and a branch for local ones. Responses
+are JSON with an ETag, and If-None-Match returns 304 when the version is
+unchanged.
+
+Remote results are cached and shared by all requests for --cache-ttl, up to
+--cache-size of them. Point a GitHub push webhook at /v1/webhooks/github to
+drop a repository's cached results as soon as it changes; deliveries must be
+signed with --webhook-secret. Local repositories are calculated on every request.
+
+Authentication for remote repositories works as for the remote command.
+The API itself has no authentication: anyone who can reach it can read the
+versions, branches and commits of every allowed repository the token can
+see. It listens on localhost by default; put it behind an authenticating
+proxy before listening on other interfaces.
+
+Examples:
+  GITHUB_TOKEN=ghp_xxx go-gitsemver serve --allow-repo 'myorg/*'
+  go-gitsemver serve --allow-path /srv/repos/api --webhook-secret "$WEBHOOK_SECRET"
+  curl 'localhost:8080/v1/version?repo=myorg/myrepo&ref=main'`,
+	Args: cobra.NoArgs,
+	RunE: serveRunE,
+}
+
+func init() {
+	serveCmd.Flags().StringVar(&flagListen, "listen", "127.0.0.1:8080", "address to listen on; the API is unauthenticated, so bind other interfaces only behind a proxy")
+	serveCmd.Flags().StringArrayVar(&flagAllowPaths, "allow-path", nil, "local repository path requests may name (repeatable)")
+	serveCmd.Flags().StringArrayVar(&flagAllowRepos, "allow-repo", nil, "GitHub owner/name requests may name, with * globs such as 'myorg/*' (repeatable; none serves no remote repository)")
+	serveCmd.Flags().StringVar(&flagWebhookSecret, "webhook-secret", "", "secret GitHub signs webhook deliveries with; enables /v1/webhooks/github (or set GITHUB_WEBHOOK_SECRET env var)")
+	serveCmd.Flags().DurationVar(&flagCacheTTL, "cache-ttl", 10*time.Minute, "how long remote results are cached (0 keeps them until a push webhook)")
+	serveCmd.Flags().IntVar(&flagCacheSize, "cache-size", server.DefaultCacheSize, "maximum number of cached remote results; the least recently used is dropped")
+	serveCmd.Flags().DurationVar(&flagCalcTimeout, "calculate-timeout", server.DefaultCalculateTimeout, "maximum time a remote calculation may run")
+
+	serveCmd.Flags().StringVar(&flagToken, "token", "", "GitHub token (or set GITHUB_TOKEN env var)")
+	serveCmd.Flags().Int64Var(&flagAppID, "github-app-id", 0, "GitHub App ID (or set GH_APP_ID env var)")
+	serveCmd.Flags().StringVar(&flagAppKey, "github-app-key", "", "GitHub App private key PEM content (or set GH_APP_PRIVATE_KEY env var)")
+	serveCmd.Flags().StringVar(&flagAppKeyPath, "github-app-key-path", "", "path to GitHub App private key PEM file (or set GH_APP_PRIVATE_KEY_PATH env var)")
+	serveCmd.Flags().StringVar(&flagGitHubURL, "github-url", "", "GitHub API base URL for GitHub Enterprise (or set GITHUB_API_URL env var)")
+	serveCmd.Flags().IntVar(&flagMaxCommits, "max-commits", 1000, "maximum commit depth to walk via API")
+	serveCmd.Flags().StringVar(&flagRemoteConfigPath, "remote-config-path", "", "path to config file in the remote repos (e.g. .github/GitVersion.yml)")
+	serveCmd.Flags().IntVar(&flagConcurrency, "concurrency", 8, "parallel API requests for prefetching tag peels and merge bases (1 disables)")
+	serveCmd.Flags().DurationVar(&flagMaxRetryWait, "max-retry-wait", 2*time.Minute, "total time to wait on GitHub rate limits and transient errors before failing")
+
+	rootCmd.AddCommand(serveCmd)
+}
+
+func serveRunE(cmd *cobra.Command, _ []string) error {
+	secret := flagWebhookSecret
+	if secret == "" {
+		secret = os.Getenv("GITHUB_WEBHOOK_SECRET")
+	}
+
+	// 1. Build the API handler.
+	srv, err := serveHandler(secret)
+	if err != nil {
+		return err
+	}
+
+	// 2. Serve until interrupted, then let requests in flight finish.
+	httpServer := &http.Server{
+		Addr:              flagListen,
+		Handler:           srv,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errc := make(chan error, 1)
+	go func() {
+		fmt.Fprintf(cmd.ErrOrStderr(), "listening on %s\n", flagListen)
+		errc <- httpServer.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errc:
+		return fmt.Errorf("serving: %w", err)
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("shutting down: %w", err)
+	}
+	if err := <-errc; !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("serving: %w", err)
+	}
+	return nil
+}
+
+// serveHandler returns the API handler configured from the flags.
+func serveHandler(webhookSecret string) (*server.Server, error) {
+	srv, err := server.New(server.Options{
+		Remote: sdk.RemoteOptions{
+			Token:            flagToken,
+			AppID:            flagAppID,
+			AppKey:           flagAppKey,
+			AppKeyPath:       flagAppKeyPath,
+			BaseURL:          ghprovider.ResolveBaseURL(flagGitHubURL),
+			MaxCommits:       flagMaxCommits,
+			Concurrency:      flagConcurrency,
+			MaxRetryWait:     flagMaxRetryWait,
+			RemoteConfigPath: flagRemoteConfigPath,
+			ConfigOverrides:  flagSet,
+		},
+		Local: sdk.LocalOptions{
+			ConfigOverrides: flagSet,
+		},
+		AllowedPaths:     flagAllowPaths,
+		AllowedRepos:     flagAllowRepos,
+		WebhookSecret:    webhookSecret,
+		CacheTTL:         flagCacheTTL,
+		CacheSize:        flagCacheSize,
+		CalculateTimeout: flagCalcTimeout,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("configuring server: %w", err)
+	}
+	return srv, nil
+}
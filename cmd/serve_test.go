@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/testutil"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestServeHandler_AllowedPath(t *testing.T) {
+	repo := testutil.NewTestRepo(t)
+	initial := repo.AddCommit("initial")
+	repo.CreateTag("v1.0.0", initial)
+	repo.AddCommit("fix: typo")
+
+	flagAllowPaths = []string{repo.Path()}
+	flagSet = []string{"branches.main.increment=Minor"}
+	defer func() {
+		flagAllowPaths = nil
+		flagSet = nil
+	}()
+
+	srv, err := serveHandler("")
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/version?repo="+repo.Path(), nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), `"SemVer": "1.1.0"`)
+
+	// No secret: the webhook endpoint is not served.
+	rec = httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/webhooks/github", strings.NewReader("{}")))
+	require.Equal(t, http.StatusNotFound, rec.Code)
+}
@@ -0,0 +1,297 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/calculator"
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/config"
+	configctx "github.com/MyCarrier-DevOps/go-gitsemver/internal/context"
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/git"
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/output"
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/semver"
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/strategy"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagWhatIfSource string
+	flagWhatIfTarget string
+	flagWhatIfSquash bool
+	flagWhatIfTitle  string
+)
+
+// whatif merge modes.
+const (
+	whatIfModeMerge  = "merge"
+	whatIfModeSquash = "squash"
+)
+
+var whatIfCmd = &cobra.Command{
+	Use:   "whatif",
+	Short: "Show the version a branch would get if another branch were merged into it",
+	Long: `Show the version the target branch would get if the source branch were
+merged into it, without touching the repository. The merge is simulated
+with a virtual commit on top of the target branch: a merge commit with
+both tips as parents, or with --squash a single commit holding the source
+commits the way GitHub squashes a pull request, titled with --title.
+
+The target defaults to the main branch. The version shown is FullSemVer
+unless --show-variable names another variable. --explain and
+--explain-graph describe the calculation after the merge.
+
+Examples:
+  go-gitsemver whatif --source feature/x
+  go-gitsemver whatif --source feature/x --target main --squash --title "feat: add login"
+  go-gitsemver whatif --source origin/feature/x -o json`,
+	Args: cobra.NoArgs,
+	RunE: whatIfRunE,
+}
+
+func init() {
+	whatIfCmd.Flags().StringVar(&flagWhatIfSource, "source", "", "branch to merge (required)")
+	whatIfCmd.Flags().StringVar(&flagWhatIfTarget, "target", "", "branch to merge into (default: the main branch)")
+	whatIfCmd.Flags().BoolVar(&flagWhatIfSquash, "squash", false, "simulate a squash merge instead of a merge commit")
+	whatIfCmd.Flags().StringVar(&flagWhatIfTitle, "title", "", "pull request title, used as the squash commit subject or the merge commit body")
+	rootCmd.AddCommand(whatIfCmd)
+}
+
+// whatIfReport is the whatif output.
+type whatIfReport struct {
+	Target  string `json:"target"`
+	Source  string `json:"source"`
+	Mode    string `json:"mode"`
+	Current string `json:"current"`
+	After   string `json:"after"`
+	Bump    string `json:"bump"`
+}
+
+func whatIfRunE(cmd *cobra.Command, _ []string) error {
+	if flagWhatIfSource == "" {
+		return errors.New("--source is required")
+	}
+	if flagOutput != "" && flagOutput != "json" {
+		return fmt.Errorf("unknown output format %q", flagOutput)
+	}
+	if err := validateExplainFormat(); err != nil {
+		return err
+	}
+	if err := validateExplainGraph(); err != nil {
+		return err
+	}
+	variable := flagShowVariable
+	if variable == "" {
+		variable = "FullSemVer"
+	}
+
+	// 1. Open repository and load configuration.
+	repo, err := git.Open(flagPath)
+	if err != nil {
+		return fmt.Errorf("opening repository: %w", err)
+	}
+	cfg, err := loadConfig(repo.WorkingDirectory())
+	if err != nil {
+		return fmt.Errorf("loading configuration: %w", err)
+	}
+
+	// 2. Resolve both branches.
+	store := git.NewMemoizedRepositoryStore(repo).WithContext(commandContext(cmd))
+	source, err := store.GetTargetBranch(flagWhatIfSource)
+	if err != nil {
+		return err
+	}
+	target, err := whatIfTarget(store, cfg)
+	if err != nil {
+		return err
+	}
+	if source.Tip == nil || target.Tip == nil {
+		return fmt.Errorf("branches %q and %q must both have commits", source.FriendlyName(), target.FriendlyName())
+	}
+	base, found, err := store.FindMergeBaseFromCommits(*source.Tip, *target.Tip)
+	if err != nil {
+		return err
+	}
+	if found && base.Sha == source.Tip.Sha {
+		return fmt.Errorf("%q is already merged into %q", source.FriendlyName(), target.FriendlyName())
+	}
+	if !found {
+		base = git.Commit{}
+	}
+
+	// 3. Calculate the target's current version.
+	calc := calculator.NewNextVersionCalculator(store, strategy.AllStrategies(store))
+	ctx, err := commitContext(store, cfg, target, *target.Tip)
+	if err != nil {
+		return err
+	}
+	current, _, err := calculateWhatIf(calc, ctx, false)
+	if err != nil {
+		return fmt.Errorf("calculating current version: %w", err)
+	}
+
+	// 4. Add the virtual merge commit on top of the target.
+	sourceCommits, err := store.GetCommitLog(base, *source.Tip)
+	if err != nil {
+		return fmt.Errorf("listing source commits: %w", err)
+	}
+	mode := whatIfModeMerge
+	merge := git.NewVirtualCommit(mergeMessage(source, target, flagWhatIfTitle), time.Now(), target.Tip.Sha, source.Tip.Sha)
+	if flagWhatIfSquash {
+		mode = whatIfModeSquash
+		merge = git.NewVirtualCommit(squashMessage(source, sourceCommits, flagWhatIfTitle), time.Now(), target.Tip.Sha)
+	}
+	overlay := git.NewOverlayRepository(repo, target.FriendlyName(), merge)
+	overlayStore := git.NewRepositoryStore(overlay).WithContext(commandContext(cmd))
+	merged, err := overlayStore.GetTargetBranch(target.FriendlyName())
+	if err != nil {
+		return err
+	}
+
+	// 5. Calculate the version after the merge.
+	overlayCalc := calculator.NewNextVersionCalculator(overlayStore, strategy.AllStrategies(overlayStore))
+	overlayCtx, err := commitContext(overlayStore, cfg, merged, merge)
+	if err != nil {
+		return err
+	}
+	after, result, err := calculateWhatIf(overlayCalc, overlayCtx, explaining())
+	if err != nil {
+		return fmt.Errorf("calculating version after merge: %w", err)
+	}
+
+	// 6. Write explain output and history graph to stderr if requested.
+	if explaining() {
+		if err := writeExplanation(result); err != nil {
+			return fmt.Errorf("writing explanation: %w", err)
+		}
+	}
+	if flagExplainGraph != "" {
+		ec, err := overlayCtx.GetEffectiveConfiguration(merged.FriendlyName())
+		if err != nil {
+			return fmt.Errorf("resolving branch configuration: %w", err)
+		}
+		if err := writeHistoryGraph(overlayCalc, overlayCtx, ec, result); err != nil {
+			return fmt.Errorf("writing history graph: %w", err)
+		}
+	}
+
+	// 7. Write the report.
+	currentValue, ok := current[variable]
+	if !ok {
+		return fmt.Errorf("unknown variable %q", variable)
+	}
+	report := whatIfReport{
+		Target:  target.FriendlyName(),
+		Source:  source.FriendlyName(),
+		Mode:    mode,
+		Current: currentValue,
+		After:   after[variable],
+		Bump:    versionBump(current, after).String(),
+	}
+	return writeWhatIfReport(cmd.OutOrStdout(), report)
+}
+
+// whatIfTarget returns the branch named by --target, or the main branch.
+func whatIfTarget(store *git.RepositoryStore, cfg *config.Config) (git.Branch, error) {
+	if flagWhatIfTarget != "" {
+		return store.GetTargetBranch(flagWhatIfTarget)
+	}
+	b, ok, err := store.FindMainBranch(cfg)
+	if err != nil {
+		return git.Branch{}, err
+	}
+	if !ok {
+		return git.Branch{}, errors.New("no main branch found; use --target to pick the branch to merge into")
+	}
+	return b, nil
+}
+
+// calculateWhatIf calculates the version for ctx and returns its output
+// variables along with the result.
+func calculateWhatIf(calc *calculator.NextVersionCalculator, ctx *configctx.GitVersionContext, explain bool) (map[string]string, calculator.VersionResult, error) {
+	ec, err := ctx.GetEffectiveConfiguration(ctx.CurrentBranch.FriendlyName())
+	if err != nil {
+		return nil, calculator.VersionResult{}, fmt.Errorf("resolving branch configuration: %w", err)
+	}
+	result, err := calc.Calculate(ctx, ec, explain)
+	if err != nil {
+		return nil, calculator.VersionResult{}, err
+	}
+	return output.GetVariables(result.Version, ec), result, nil
+}
+
+// mergeMessage returns the message git gives a merge of source into
+// target, with title as the body when set.
+func mergeMessage(source, target git.Branch, title string) string {
+	msg := fmt.Sprintf("Merge branch '%s' into %s", source.FriendlyName(), target.FriendlyName())
+	if title != "" {
+		msg += "\n\n" + title
+	}
+	return msg
+}
+
+// squashMessage returns the message GitHub gives a squash merge: the title,
+// then each source commit message as a list item, oldest first. The title
+// defaults to the subject of a lone commit or the source branch name.
+func squashMessage(source git.Branch, commits []git.Commit, title string) string {
+	if len(commits) == 1 && title == "" {
+		return commits[0].Message
+	}
+	if title == "" {
+		title = source.FriendlyName()
+	}
+	items := make([]string, 0, len(commits))
+	for _, c := range slices.Backward(commits) {
+		items = append(items, "* "+strings.TrimSpace(c.Message))
+	}
+	if len(items) == 0 {
+		return title
+	}
+	return title + "\n\n" + strings.Join(items, "\n\n")
+}
+
+// versionBump returns the most significant version field that the merge
+// changes, comparing the output variables before and after it.
+func versionBump(current, after map[string]string) semver.VersionField {
+	for _, f := range []struct {
+		name  string
+		field semver.VersionField
+	}{
+		{"Major", semver.VersionFieldMajor},
+		{"Minor", semver.VersionFieldMinor},
+		{"Patch", semver.VersionFieldPatch},
+	} {
+		if current[f.name] != after[f.name] {
+			return f.field
+		}
+	}
+	return semver.VersionFieldNone
+}
+
+// writeWhatIfReport writes report as text, or JSON with --output json.
+func writeWhatIfReport(w io.Writer, report whatIfReport) error {
+	if flagOutput == "json" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshaling report: %w", err)
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	}
+	_, err := fmt.Fprintf(w, "%s: %s now, %s after %s (bump: %s)\n",
+		report.Target, report.Current, report.After, whatIfAction(report), report.Bump)
+	return err
+}
+
+// whatIfAction describes the simulated merge for the text report.
+func whatIfAction(report whatIfReport) string {
+	if report.Mode == whatIfModeSquash {
+		return "squashing " + report.Source
+	}
+	return "merging " + report.Source
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/git"
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/testutil"
+
+	"github.com/stretchr/testify/require"
+)
+
+func runWhatIf(t *testing.T, path, source string) (string, error) {
+	t.Helper()
+	flagPath = path
+	flagWhatIfSource = source
+	defer func() {
+		flagPath = "."
+		flagWhatIfSource = ""
+	}()
+
+	var stdout bytes.Buffer
+	whatIfCmd.SetOut(&stdout)
+	defer whatIfCmd.SetOut(nil)
+
+	err := whatIfRunE(whatIfCmd, nil)
+	return stdout.String(), err
+}
+
+// whatIfTestRepo returns a repository with master at 1.0.1+1 and a
+// feature branch holding a breaking change.
+func whatIfTestRepo(t *testing.T) *testutil.TestRepo {
+	t.Helper()
+	repo := testutil.NewTestRepo(t)
+	initial := repo.AddCommit("initial")
+	repo.CreateTag("v1.0.0", initial)
+	repo.CreateBranch("feature/login", initial)
+	repo.AddCommit("fix: typo")
+	repo.Checkout("feature/login")
+	repo.AddCommit("feat: add login")
+	repo.AddCommit("refactor: drop v1 endpoint\n\nBREAKING CHANGE: v1 is gone")
+	repo.Checkout("master")
+	return repo
+}
+
+func TestWhatIf_Merge(t *testing.T) {
+	repo := whatIfTestRepo(t)
+	head := repo.HeadSha()
+
+	out, err := runWhatIf(t, repo.Path(), "feature/login")
+	require.NoError(t, err)
+	require.Equal(t, "master: 1.0.1+1 now, 2.0.0+4 after merging feature/login (bump: Major)\n", out)
+	require.Equal(t, head, repo.HeadSha())
+}
+
+func TestWhatIf_SquashJSON(t *testing.T) {
+	repo := whatIfTestRepo(t)
+
+	flagWhatIfSquash = true
+	flagWhatIfTitle = "feat: add login"
+	flagOutput = "json"
+	flagShowVariable = "SemVer"
+	defer func() {
+		flagWhatIfSquash = false
+		flagWhatIfTitle = ""
+		flagOutput = ""
+		flagShowVariable = ""
+	}()
+
+	out, err := runWhatIf(t, repo.Path(), "feature/login")
+	require.NoError(t, err)
+
+	var report whatIfReport
+	require.NoError(t, json.Unmarshal([]byte(out), &report))
+	require.Equal(t, whatIfReport{
+		Target:  "master",
+		Source:  "feature/login",
+		Mode:    whatIfModeSquash,
+		Current: "1.0.1",
+		After:   "2.0.0",
+		Bump:    "Major",
+	}, report)
+}
+
+func TestWhatIf_Target(t *testing.T) {
+	repo := testutil.NewTestRepo(t)
+	initial := repo.AddCommit("initial")
+	repo.CreateTag("v1.0.0", initial)
+	repo.CreateBranch("feature/typo", initial)
+	repo.Checkout("feature/typo")
+	repo.AddCommit("fix: typo")
+
+	flagWhatIfTarget = "master"
+	flagShowVariable = "SemVer"
+	defer func() {
+		flagWhatIfTarget = ""
+		flagShowVariable = ""
+	}()
+
+	out, err := runWhatIf(t, repo.Path(), "feature/typo")
+	require.NoError(t, err)
+	require.Equal(t, "master: 1.0.0 now, 1.0.1 after merging feature/typo (bump: Patch)\n", out)
+}
+
+func TestWhatIf_Errors(t *testing.T) {
+	repo := whatIfTestRepo(t)
+
+	_, err := runWhatIf(t, repo.Path(), "")
+	require.ErrorContains(t, err, "--source is required")
+
+	_, err = runWhatIf(t, repo.Path(), "feature/missing")
+	require.ErrorContains(t, err, `branch "feature/missing" not found`)
+
+	repo.CreateBranch("feature/old", repo.HeadSha())
+	_, err = runWhatIf(t, repo.Path(), "feature/old")
+	require.ErrorContains(t, err, `"feature/old" is already merged into "master"`)
+}
+
+func TestSquashMessage(t *testing.T) {
+	source := git.Branch{Name: git.NewBranchReferenceName("feature/login")}
+	commits := []git.Commit{{Message: "fix: review"}, {Message: "feat: add login\n\nDetails."}}
+
+	require.Equal(t, "feat: add login (#12)\n\n* feat: add login\n\nDetails.\n\n* fix: review",
+		squashMessage(source, commits, "feat: add login (#12)"))
+	require.Equal(t, "feature/login\n\n* feat: add login\n\nDetails.\n\n* fix: review",
+		squashMessage(source, commits, ""))
+	require.Equal(t, "fix: review", squashMessage(source, commits[:1], ""))
+}
@@ -0,0 +1,10 @@
+// Package examples embeds the example configurations so that
+// go-gitsemver init can use them as presets.
+package examples
+
+import "embed"
+
+// FS holds the example configuration files, one per preset.
+//
+//go:embed *.yml
+var FS embed.FS
@@ -399,6 +399,27 @@ func TestE2E_FeatureBranch_PreReleaseTag(t *testing.T) {
 	require.Equal(t, "feature/login", vars["BranchName"])
 }
 
+func TestE2E_FeatureBranch_NamedGroupLabel(t *testing.T) {
+	repo := testutil.NewTestRepo(t)
+	sha := repo.AddCommit("initial on main")
+	repo.CreateTag("v1.0.0", sha)
+	repo.CreateBranch("users/alice/JIRA-42-login", sha)
+	repo.Checkout("users/alice/JIRA-42-login")
+	repo.AddCommit("feat: add login page")
+
+	vars := runPipelineWithConfig(t, repo.Path(), `branches:
+  user:
+    regex: ^users/[^/]+/(?P<ticket>[A-Z]+-\d+)
+    tag: '{ticket}'
+    increment: Inherit
+    source-branches: [main]
+    priority: 55
+`)
+
+	require.Equal(t, "JIRA-42", vars["PreReleaseLabel"])
+	require.Equal(t, "user", vars["BranchConfigName"])
+}
+
 // ---------------------------------------------------------------------------
 // Build Metadata
 // ---------------------------------------------------------------------------
@@ -870,3 +891,24 @@ func TestE2E_OutputVariables_Extended(t *testing.T) {
 		require.Contains(t, vars, key, "missing variable: %s", key)
 	}
 }
+
+func TestE2E_TrackMergeTarget(t *testing.T) {
+	repo := testutil.NewTestRepo(t)
+	sha := repo.AddCommit("initial on main")
+	repo.CreateTag("v1.0.0", sha)
+	repo.CreateBranch("feature/login", sha)
+	repo.Checkout("feature/login")
+	featureSha := repo.AddCommit("feat: login")
+	repo.Checkout("master")
+	merge := repo.MergeCommit("Merge branch 'feature/login'", featureSha)
+	repo.CreateTag("v2.0.0", merge)
+	repo.Checkout("feature/login")
+
+	// Without track-merge-target the tag on main is invisible to the feature.
+	vars := runPipeline(t, repo.Path())
+	require.Equal(t, "1", vars["Major"])
+
+	// With it, the tag on the merge commit applies to the merged commit.
+	vars = runPipelineWithConfig(t, repo.Path(), "branches:\n  feature:\n    track-merge-target: true\n")
+	require.Equal(t, "2.0.0", vars["MajorMinorPatch"])
+}
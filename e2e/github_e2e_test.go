@@ -7,6 +7,7 @@
 package e2e
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -770,7 +771,7 @@ func TestGitHub_RemoteConfig(t *testing.T) {
 	)
 
 	// Fetch config from remote.
-	content, err := ghRepo.FetchFileContent("go-gitsemver.yml")
+	content, err := ghRepo.FetchFileContent(context.Background(), "go-gitsemver.yml")
 	require.NoError(t, err)
 
 	userCfg, err := config.LoadFromBytes([]byte(content))
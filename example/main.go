@@ -16,6 +16,7 @@ import (
 	"sort"
 
 	"github.com/MyCarrier-DevOps/go-gitsemver/pkg/sdk"
+	"github.com/MyCarrier-DevOps/go-gitsemver/pkg/semver"
 )
 
 func main() {
@@ -74,6 +75,11 @@ func remoteVersion() {
 func printVersion(label string, result *sdk.Result) {
 	fmt.Printf("=== %s Version ===\n", label)
 
+	v := result.Version
+	fmt.Printf("Version %s on %s (%d commits since %s)\n",
+		v, v.Branch, v.CommitsSinceVersionSource, v.VersionSourceSha)
+	fmt.Printf("Next major: %s\n\n", v.Bump(semver.Major))
+
 	keys := make([]string, 0, len(result.Variables))
 	for k := range result.Variables {
 		keys = append(keys, k)
@@ -3,6 +3,7 @@ package calculator
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/MyCarrier-DevOps/go-gitsemver/internal/config"
 	"github.com/MyCarrier-DevOps/go-gitsemver/internal/context"
@@ -37,6 +38,22 @@ type BaseVersionResult struct {
 	BaseVersion            strategy.BaseVersion
 	EffectiveConfiguration config.EffectiveConfiguration
 	AllCandidates          []strategy.BaseVersion
+	Evaluations            []CandidateEvaluation // Evaluations[i] describes AllCandidates[i]
+}
+
+// CandidateEvaluation records how one candidate fared during selection.
+type CandidateEvaluation struct {
+	// IgnoredReason says why the ignore config removed the candidate.
+	// Empty for candidates that were ranked.
+	IgnoredReason string
+
+	// EffectiveVersion is the version the candidate was ranked by: its
+	// version, incremented by the branch increment when ShouldIncrement.
+	EffectiveVersion semver.SemanticVersion
+
+	// Rank is the candidate's position in the ranking, 1 for the winner.
+	// Zero for ignored candidates.
+	Rank int
 }
 
 // Calculate runs all strategies, selects the highest effective version,
@@ -62,78 +79,82 @@ func (c *BaseVersionCalculator) Calculate(
 	}
 
 	// Filter by ignore config.
-	candidates := filterCandidates(allCandidates, ec)
-	if len(candidates) == 0 {
+	evaluations := make([]CandidateEvaluation, len(allCandidates))
+	var eligible []int
+	for i, bv := range allCandidates {
+		if reason := ignoreReason(bv, ec); reason != "" {
+			evaluations[i].IgnoredReason = reason
+			continue
+		}
+		eligible = append(eligible, i)
+	}
+	if len(eligible) == 0 {
 		return BaseVersionResult{}, errors.New("all base versions were filtered out by ignore config")
 	}
 
-	// Select winner by computing effective versions.
-	winner := c.selectWinner(ctx, candidates, ec)
+	// Rank the rest by effective version; the first is the winner.
+	for _, i := range eligible {
+		evaluations[i].EffectiveVersion = c.effectiveVersion(ctx, allCandidates[i], ec)
+	}
+	ranking := rankCandidates(allCandidates, evaluations, eligible)
+	for r, i := range ranking {
+		evaluations[i].Rank = r + 1
+	}
 
 	return BaseVersionResult{
-		BaseVersion:            winner,
+		BaseVersion:            allCandidates[ranking[0]],
 		EffectiveConfiguration: ec,
 		AllCandidates:          allCandidates,
+		Evaluations:            evaluations,
 	}, nil
 }
 
-// filterCandidates removes base versions that match ignore config.
-func filterCandidates(candidates []strategy.BaseVersion, ec config.EffectiveConfiguration) []strategy.BaseVersion {
-	if len(ec.IgnoreSha) == 0 && ec.IgnoreCommitsBefore == nil {
-		return candidates
+// ignoreReason returns why the ignore config removes bv, or "" when it
+// does not.
+func ignoreReason(bv strategy.BaseVersion, ec config.EffectiveConfiguration) string {
+	source := bv.BaseVersionSource
+	if source == nil {
+		return ""
 	}
-
-	ignoreShaSet := make(map[string]struct{}, len(ec.IgnoreSha))
 	for _, sha := range ec.IgnoreSha {
-		ignoreShaSet[sha] = struct{}{}
-	}
-
-	var filtered []strategy.BaseVersion
-	for _, bv := range candidates {
-		if bv.BaseVersionSource != nil {
-			if _, ignored := ignoreShaSet[bv.BaseVersionSource.Sha]; ignored {
-				continue
-			}
-			if ec.IgnoreCommitsBefore != nil && bv.BaseVersionSource.When.Before(*ec.IgnoreCommitsBefore) {
-				continue
-			}
+		if sha == source.Sha {
+			return fmt.Sprintf("source commit %s is listed in ignore.sha", source.ShortSha())
 		}
-		filtered = append(filtered, bv)
 	}
-	return filtered
+	if ec.IgnoreCommitsBefore != nil && source.When.Before(*ec.IgnoreCommitsBefore) {
+		return fmt.Sprintf("source commit %s from %s is before ignore.commits-before %s",
+			source.ShortSha(), source.When.Format(time.DateOnly), ec.IgnoreCommitsBefore.Format(time.DateOnly))
+	}
+	return ""
 }
 
-// selectWinner selects the base version with the highest "effective version."
-// If ShouldIncrement, tentatively increment to compute effective version
-// for ranking. The actual increment happens later in the pipeline.
-// Tie-break: oldest BaseVersionSource (more commits → more accurate count).
-func (c *BaseVersionCalculator) selectWinner(
-	ctx *context.GitVersionContext,
-	candidates []strategy.BaseVersion,
-	ec config.EffectiveConfiguration,
-) strategy.BaseVersion {
-	best := candidates[0]
-	bestEffective := c.effectiveVersion(ctx, best, ec)
-
-	for _, bv := range candidates[1:] {
-		effective := c.effectiveVersion(ctx, bv, ec)
-
-		cmp := effective.CompareTo(bestEffective)
-		if cmp > 0 {
-			best = bv
-			bestEffective = effective
-		} else if cmp == 0 {
-			// Tie-break: oldest source commit wins (more history = more accurate count).
-			if bv.BaseVersionSource != nil && best.BaseVersionSource != nil {
-				if bv.BaseVersionSource.When.Before(best.BaseVersionSource.When) {
-					best = bv
-					bestEffective = effective
-				}
+// rankCandidates orders the eligible candidate indices from best to worst.
+// The best candidate has the highest effective version. Tie-break: oldest
+// BaseVersionSource (more commits → more accurate count), then strategy order.
+func rankCandidates(candidates []strategy.BaseVersion, evaluations []CandidateEvaluation, eligible []int) []int {
+	remaining := append([]int(nil), eligible...)
+	ranking := make([]int, 0, len(remaining))
+	for len(remaining) > 0 {
+		best := 0
+		for j := 1; j < len(remaining); j++ {
+			if outranks(candidates, evaluations, remaining[j], remaining[best]) {
+				best = j
 			}
 		}
+		ranking = append(ranking, remaining[best])
+		remaining = append(remaining[:best], remaining[best+1:]...)
 	}
+	return ranking
+}
 
-	return best
+// outranks reports whether candidate i beats candidate j.
+func outranks(candidates []strategy.BaseVersion, evaluations []CandidateEvaluation, i, j int) bool {
+	cmp := evaluations[i].EffectiveVersion.CompareTo(evaluations[j].EffectiveVersion)
+	if cmp != 0 {
+		return cmp > 0
+	}
+	si, sj := candidates[i].BaseVersionSource, candidates[j].BaseVersionSource
+	return si != nil && sj != nil && si.When.Before(sj.When)
 }
 
 // effectiveVersion computes the version that would result if we incremented.
@@ -74,6 +74,8 @@ func TestBaseVersionCalculator_TieBreakOldest(t *testing.T) {
 	require.NoError(t, err)
 	// Tie-break: oldest source wins.
 	require.Equal(t, "older", result.BaseVersion.Source)
+	require.Equal(t, 2, result.Evaluations[0].Rank)
+	require.Equal(t, 1, result.Evaluations[1].Rank)
 }
 
 func TestBaseVersionCalculator_EffectiveVersionRanking(t *testing.T) {
@@ -103,6 +105,10 @@ func TestBaseVersionCalculator_EffectiveVersionRanking(t *testing.T) {
 	require.NoError(t, err)
 	// 1.0.0+patch → effective 1.0.1 > 0.9.0
 	require.Equal(t, "branch", result.BaseVersion.Source)
+	require.Equal(t, []CandidateEvaluation{
+		{EffectiveVersion: semver.SemanticVersion{Minor: 9}, Rank: 2},
+		{EffectiveVersion: semver.SemanticVersion{Major: 1, Patch: 1}, Rank: 1},
+	}, result.Evaluations)
 }
 
 func TestBaseVersionCalculator_NoCandidates(t *testing.T) {
@@ -141,6 +147,9 @@ func TestBaseVersionCalculator_FilterIgnoreSha(t *testing.T) {
 	result, err := calc.Calculate(ctx, ec, false)
 	require.NoError(t, err)
 	require.Equal(t, "v2", result.BaseVersion.Source)
+	require.Equal(t, "source commit aaa0000 is listed in ignore.sha", result.Evaluations[0].IgnoredReason)
+	require.Zero(t, result.Evaluations[0].Rank)
+	require.Equal(t, 1, result.Evaluations[1].Rank)
 }
 
 func TestBaseVersionCalculator_FilterIgnoreDate(t *testing.T) {
@@ -169,6 +178,8 @@ func TestBaseVersionCalculator_FilterIgnoreDate(t *testing.T) {
 	result, err := calc.Calculate(ctx, ec, false)
 	require.NoError(t, err)
 	require.Equal(t, "new-v", result.BaseVersion.Source)
+	require.Equal(t, "source commit aaa0000 from 2020-01-01 is before ignore.commits-before 2023-01-01", result.Evaluations[0].IgnoredReason)
+	require.Empty(t, result.Evaluations[1].IgnoredReason)
 }
 
 func TestBaseVersionCalculator_AllFiltered(t *testing.T) {
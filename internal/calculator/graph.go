@@ -0,0 +1,145 @@
+package calculator
+
+import (
+	"fmt"
+
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/config"
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/context"
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/git"
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/semver"
+)
+
+// HistoryGraph is the commit DAG between the selected base version source
+// and the current commit, annotated with what each commit contributed to
+// the calculation.
+type HistoryGraph struct {
+	// Nodes are in reverse chronological order; the first is the current
+	// commit.
+	Nodes []GraphNode
+
+	// BranchPoint is the commit the current branch was branched from, or
+	// nil when its config has no source branches or none share history.
+	BranchPoint *git.BranchCommit
+}
+
+// GraphNode is one commit in a HistoryGraph.
+type GraphNode struct {
+	Commit git.Commit
+
+	// Parents lists the parents of Commit that are also in the graph.
+	Parents []string
+
+	// Tags lists the version tags on the commit.
+	Tags []string
+
+	// Merge is the parsed merge message; empty when the message matches no
+	// merge format.
+	Merge git.MergeMessage
+
+	// Bump is the increment the commit message contributes. Always None for
+	// the base version source, which is not scanned.
+	Bump semver.VersionField
+
+	IsHead        bool
+	IsBranchPoint bool
+
+	// Selected is the winning candidate when the commit is its base version
+	// source.
+	Selected *SelectedCandidate
+}
+
+// SelectedCandidate describes the winning base version on its source commit.
+type SelectedCandidate struct {
+	Source  string
+	Version semver.SemanticVersion
+}
+
+// HistoryGraph builds the commit graph behind result: the commits scanned
+// for increments plus the base version source, with version tags, parsed
+// merge messages, the branch point, and each commit's bump.
+func (c *NextVersionCalculator) HistoryGraph(
+	ctx *context.GitVersionContext,
+	ec config.EffectiveConfiguration,
+	result VersionResult,
+) (HistoryGraph, error) {
+	// 1. Collect the commits. A tagged current commit is its own base.
+	commits := []git.Commit{ctx.CurrentCommit}
+	source := result.BaseVersion.BaseVersionSource
+	if !ctx.IsCurrentCommitTagged {
+		from := git.Commit{}
+		if source != nil {
+			from = *source
+		}
+		log, err := c.store.GetCommitLog(from, ctx.CurrentCommit)
+		if err != nil {
+			return HistoryGraph{}, fmt.Errorf("loading commit log: %w", err)
+		}
+		commits = log
+		if source != nil && !containsCommit(commits, source.Sha) {
+			commits = append(commits, *source)
+		}
+	}
+
+	// 2. Find the branch point; it is shown even when it predates the base.
+	var graph HistoryGraph
+	bc, err := c.store.FindCommitBranchWasBranchedFrom(ctx.CurrentBranch, ctx.FullConfiguration)
+	if err != nil {
+		return HistoryGraph{}, fmt.Errorf("finding branch point: %w", err)
+	}
+	if !bc.Commit.IsEmpty() {
+		graph.BranchPoint = &bc
+		if !containsCommit(commits, bc.Commit.Sha) {
+			commits = append(commits, bc.Commit)
+		}
+	}
+
+	// 3. Index version tags by commit.
+	tags, err := c.store.GetValidVersionTags(ec.TagPrefix, nil)
+	if err != nil {
+		return HistoryGraph{}, fmt.Errorf("loading version tags: %w", err)
+	}
+	tagsBySha := make(map[string][]string)
+	for _, t := range tags {
+		tagsBySha[t.Commit.Sha] = append(tagsBySha[t.Commit.Sha], t.Tag.Name.Friendly)
+	}
+
+	// 4. Annotate each commit.
+	inGraph := make(map[string]bool, len(commits))
+	for _, commit := range commits {
+		inGraph[commit.Sha] = true
+	}
+	for _, commit := range commits {
+		node := GraphNode{
+			Commit:        commit,
+			Tags:          tagsBySha[commit.Sha],
+			Merge:         git.ParseMergeMessage(commit.Message, ec.MergeMessageFormats),
+			IsHead:        commit.Sha == ctx.CurrentCommit.Sha,
+			IsBranchPoint: graph.BranchPoint != nil && commit.Sha == graph.BranchPoint.Commit.Sha,
+		}
+		for _, p := range commit.Parents {
+			if inGraph[p] {
+				node.Parents = append(node.Parents, p)
+			}
+		}
+		if source != nil && commit.Sha == source.Sha {
+			node.Selected = &SelectedCandidate{
+				Source:  result.BaseVersion.Source,
+				Version: result.BaseVersion.SemanticVersion,
+			}
+		} else if !ctx.IsCurrentCommitTagged {
+			node.Bump = c.incr.AnalyzeCommitIncrement(commit, ec)
+		}
+		graph.Nodes = append(graph.Nodes, node)
+	}
+
+	return graph, nil
+}
+
+func containsCommit(commits []git.Commit, sha string) bool {
+	for _, c := range commits {
+		if c.Sha == sha {
+			return true
+		}
+	}
+	return false
+}
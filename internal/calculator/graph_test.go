@@ -0,0 +1,101 @@
+package calculator
+
+import (
+	"testing"
+
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/config"
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/context"
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/git"
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/semver"
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/strategy"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextVersion_HistoryGraph(t *testing.T) {
+	base := newCommit("aaa0000000000000000000000000000000000000", "initial")
+	feat := newCommit("bbb0000000000000000000000000000000000000", "feat: add login")
+	feat.Parents = []string{base.Sha}
+	fix := newCommit("ddd0000000000000000000000000000000000000", "fix: typo")
+	fix.Parents = []string{base.Sha}
+	merge := newCommit("ccc0000000000000000000000000000000000000", "Merge pull request #12 from org/fix-typo")
+	merge.Parents = []string{feat.Sha, fix.Sha}
+	develop := newCommit("eee0000000000000000000000000000000000000", "develop work")
+
+	commits := map[string]git.Commit{base.Sha: base, feat.Sha: feat, fix.Sha: fix, merge.Sha: merge, develop.Sha: develop}
+	current := git.Branch{Name: git.NewBranchReferenceName("feature/login"), Tip: &merge}
+	repo := &git.MockRepository{
+		CommitLogFunc: func(from, to string, _ ...git.PathFilter) ([]git.Commit, error) {
+			require.Equal(t, base.Sha, from)
+			return []git.Commit{merge, fix, feat}, nil
+		},
+		CommitFromShaFunc: func(sha string) (git.Commit, error) { return commits[sha], nil },
+		TagsFunc: func(...git.PathFilter) ([]git.Tag, error) {
+			return []git.Tag{{Name: git.NewReferenceName("refs/tags/v1.0.0"), TargetSha: base.Sha}}, nil
+		},
+		PeelTagToCommitFunc: func(tag git.Tag) (string, error) { return tag.TargetSha, nil },
+		BranchesFunc: func(...git.PathFilter) ([]git.Branch, error) {
+			return []git.Branch{current, {Name: git.NewBranchReferenceName("develop"), Tip: &develop}}, nil
+		},
+		FindMergeBaseFunc: func(string, string) (string, error) { return base.Sha, nil },
+	}
+	calc := NewNextVersionCalculator(git.NewRepositoryStore(repo), nil)
+
+	cfg, err := config.NewBuilder().Build()
+	require.NoError(t, err)
+	ctx := &context.GitVersionContext{
+		FullConfiguration: cfg,
+		CurrentBranch:     current,
+		CurrentCommit:     merge,
+	}
+	result := VersionResult{
+		BaseVersion: strategy.BaseVersion{
+			Source:            "Git tag 'v1.0.0'",
+			SemanticVersion:   semver.SemanticVersion{Major: 1},
+			BaseVersionSource: &base,
+		},
+	}
+
+	graph, err := calc.HistoryGraph(ctx, defaultEC(), result)
+	require.NoError(t, err)
+	require.NotNil(t, graph.BranchPoint)
+	require.Equal(t, "develop", graph.BranchPoint.Branch.FriendlyName())
+	require.Len(t, graph.Nodes, 4)
+
+	head := graph.Nodes[0]
+	require.True(t, head.IsHead)
+	require.Equal(t, "GitHubPull", head.Merge.FormatName)
+	require.Equal(t, 12, head.Merge.PullRequestNumber)
+	require.Equal(t, []string{feat.Sha, fix.Sha}, head.Parents)
+
+	require.Equal(t, semver.VersionFieldPatch, graph.Nodes[1].Bump)
+	require.Equal(t, semver.VersionFieldMinor, graph.Nodes[2].Bump)
+
+	root := graph.Nodes[3]
+	require.Equal(t, base.Sha, root.Commit.Sha)
+	require.Equal(t, []string{"v1.0.0"}, root.Tags)
+	require.True(t, root.IsBranchPoint)
+	require.Equal(t, &SelectedCandidate{Source: "Git tag 'v1.0.0'", Version: semver.SemanticVersion{Major: 1}}, root.Selected)
+	require.Equal(t, semver.VersionFieldNone, root.Bump)
+	require.Empty(t, root.Parents)
+}
+
+func TestNextVersion_HistoryGraphTaggedCommit(t *testing.T) {
+	tip := newCommit("aaa0000000000000000000000000000000000000", "release")
+
+	calc := NewNextVersionCalculator(git.NewRepositoryStore(&git.MockRepository{}), nil)
+	cfg, err := config.NewBuilder().Build()
+	require.NoError(t, err)
+	ctx := &context.GitVersionContext{
+		FullConfiguration:     cfg,
+		CurrentBranch:         git.Branch{Name: git.NewBranchReferenceName("main"), Tip: &tip},
+		CurrentCommit:         tip,
+		IsCurrentCommitTagged: true,
+	}
+
+	graph, err := calc.HistoryGraph(ctx, defaultEC(), VersionResult{})
+	require.NoError(t, err)
+	require.Len(t, graph.Nodes, 1)
+	require.True(t, graph.Nodes[0].IsHead)
+	require.Nil(t, graph.Nodes[0].Selected)
+}
@@ -18,6 +18,11 @@ import (
 // IncrementExplanation records the reasoning behind an increment decision.
 type IncrementExplanation struct {
 	Steps []string
+
+	// DecidingCommit is the first scanned commit that produced DecidingField,
+	// the highest increment found in commit messages. Nil when no commit bumped.
+	DecidingCommit *git.Commit
+	DecidingField  semver.VersionField
 }
 
 // Add appends a reasoning step. Nil-safe.
@@ -34,6 +39,15 @@ func (e *IncrementExplanation) Addf(format string, args ...any) {
 	}
 }
 
+// Decide records c as the deciding commit if field is higher than any seen so
+// far. Nil-safe.
+func (e *IncrementExplanation) Decide(c git.Commit, field semver.VersionField) {
+	if e != nil && field > e.DecidingField {
+		e.DecidingCommit = &c
+		e.DecidingField = field
+	}
+}
+
 // IncrementResult holds the determined increment and optional reasoning.
 type IncrementResult struct {
 	Field       semver.VersionField
@@ -116,6 +130,7 @@ func (f *IncrementStrategyFinder) DetermineIncrementedFieldExplained(
 			}
 			convention := conventionName(c.Message, ec)
 			exp.Addf("commit %s %q -> %s (%s)", c.ShortSha(), firstLine, field, convention)
+			exp.Decide(c, field)
 		}
 		if field > highest {
 			highest = field
@@ -174,6 +189,12 @@ func (f *IncrementStrategyFinder) analyzeCommit(
 	c git.Commit,
 	ec config.EffectiveConfiguration,
 ) semver.VersionField {
+	return commitIncrement(c, ec)
+}
+
+// commitIncrement returns the version bump c causes under the commit message
+// settings of ec.
+func commitIncrement(c git.Commit, ec config.EffectiveConfiguration) semver.VersionField {
 	// MergeMessageOnly: only analyze merge commits.
 	if ec.CommitMessageIncrementing == semver.CommitMessageIncrementMergeMessageOnly && !c.IsMerge() {
 		return semver.VersionFieldNone
@@ -199,6 +220,18 @@ func (f *IncrementStrategyFinder) analyzeCommit(
 	return highest
 }
 
+// ConventionalCommitType returns the lower-cased type of a Conventional
+// Commits message, such as "feat" or "chore", or "" when the first line does
+// not follow the convention.
+func ConventionalCommitType(msg string) string {
+	firstLine, _, _ := strings.Cut(msg, "\n")
+	matches := ccTypeRe.FindStringSubmatch(firstLine)
+	if matches == nil {
+		return ""
+	}
+	return strings.ToLower(matches[1])
+}
+
 // analyzeConventionalCommit parses a Conventional Commits message.
 // feat: → Minor, fix: → Patch, feat!: or BREAKING CHANGE: footer → Major
 func analyzeConventionalCommit(msg string) semver.VersionField {
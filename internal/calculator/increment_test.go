@@ -64,6 +64,12 @@ func TestConventionalCommit_NotConventional(t *testing.T) {
 	require.Equal(t, semver.VersionFieldNone, analyzeConventionalCommit("update readme"))
 }
 
+func TestConventionalCommitType(t *testing.T) {
+	require.Equal(t, "feat", ConventionalCommitType("feat(auth): add login"))
+	require.Equal(t, "chore", ConventionalCommitType("Chore!: drop node 18\n\nbody"))
+	require.Equal(t, "", ConventionalCommitType("update readme\n\nfix: not a subject"))
+}
+
 func TestBumpDirective_Major(t *testing.T) {
 	ec := defaultEC()
 	require.Equal(t, semver.VersionFieldMajor, analyzeBumpDirective("some change +semver: major", ec))
@@ -303,6 +309,37 @@ func TestDetermineIncrementExplained_RecordsSteps(t *testing.T) {
 	require.NotEmpty(t, result.Explanation.Steps)
 }
 
+func TestDetermineIncrementExplained_RecordsDecidingCommit(t *testing.T) {
+	fix := newCommit("ccc0000000000000000000000000000000000000", "fix: typo")
+	breaking := newCommit("aaa0000000000000000000000000000000000000", "feat!: drop v1 API")
+	feat := newCommit("ddd0000000000000000000000000000000000000", "feat: add login")
+	source := newCommit("bbb0000000000000000000000000000000000000", "initial")
+
+	mock := &git.MockRepository{
+		CommitLogFunc: func(from, to string, filters ...git.PathFilter) ([]git.Commit, error) {
+			return []git.Commit{fix, breaking, feat, source}, nil
+		},
+	}
+	store := git.NewRepositoryStore(mock)
+
+	ctx := &context.GitVersionContext{CurrentCommit: fix}
+	bv := strategy.BaseVersion{
+		SemanticVersion:   semver.SemanticVersion{Major: 1},
+		ShouldIncrement:   true,
+		BaseVersionSource: &source,
+	}
+	ec := defaultEC()
+	ec.CommitMessageConvention = semver.CommitMessageConventionConventionalCommits
+
+	finder := NewIncrementStrategyFinder(store)
+	result, err := finder.DetermineIncrementedFieldExplained(ctx, bv, ec, true)
+	require.NoError(t, err)
+	require.Equal(t, semver.VersionFieldMajor, result.Field)
+	require.NotNil(t, result.Explanation.DecidingCommit)
+	require.Equal(t, breaking.Sha, result.Explanation.DecidingCommit.Sha)
+	require.Equal(t, semver.VersionFieldMajor, result.Explanation.DecidingField)
+}
+
 func TestDetermineIncrementExplained_NoExplain(t *testing.T) {
 	tip := newCommit("aaa0000000000000000000000000000000000000", "feat: add login")
 	source := newCommit("bbb0000000000000000000000000000000000000", "initial")
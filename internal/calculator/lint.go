@@ -0,0 +1,143 @@
+package calculator
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/config"
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/git"
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/semver"
+)
+
+// DefaultCommitTypes are the Conventional Commits types LintCommit
+// accepts unless told otherwise.
+var DefaultCommitTypes = []string{
+	"build", "chore", "ci", "docs", "feat", "fix", "perf", "refactor", "revert", "style", "test",
+}
+
+// ccHeaderAttemptRe matches first lines shaped like a Conventional Commits
+// header, "type(scope)!:", whether or not ccTypeRe accepts them.
+var ccHeaderAttemptRe = regexp.MustCompile(`^\w+(?:\(.*?\)?)?!?\s*:`)
+
+// CommitLint is the result of checking one commit message.
+type CommitLint struct {
+	// Type is the Conventional Commits type, or "" when the header is not one.
+	Type string
+
+	// Bump is the increment the calculator takes from the message.
+	Bump semver.VersionField
+
+	// Convention names the convention that decided Bump; empty when the
+	// message does not bump.
+	Convention string
+
+	// Problems lists what is wrong with the message, if anything.
+	Problems []string
+}
+
+// LintCommit checks the message of c against the commit message settings of
+// ec with the same patterns the calculator uses, and reports the bump the
+// commit would cause. types lists the accepted Conventional Commits types.
+// For a commit not yet made, pass a Commit holding only the message.
+func LintCommit(c git.Commit, ec config.EffectiveConfiguration, types []string) CommitLint {
+	msg := c.Message
+	lint := CommitLint{Type: ConventionalCommitType(msg)}
+	lint.Bump = commitIncrement(c, ec)
+	if lint.Bump != semver.VersionFieldNone {
+		lint.Convention = conventionName(msg, ec)
+	}
+
+	convention := ec.CommitMessageConvention
+	usesCC := convention == semver.CommitMessageConventionConventionalCommits || convention == semver.CommitMessageConventionBoth
+	usesDirectives := convention == semver.CommitMessageConventionBumpDirective || convention == semver.CommitMessageConventionBoth
+
+	// 1. Header.
+	header, _, _ := strings.Cut(msg, "\n")
+	if usesCC {
+		switch {
+		case lint.Type != "":
+			if m := ccTypeRe.FindString(header); strings.TrimSpace(header[len(m):]) == "" {
+				lint.add("header %q has no description after the colon", header)
+			}
+			if !slices.Contains(types, lint.Type) {
+				lint.add("unknown type %q (expected one of %s)", lint.Type, strings.Join(types, ", "))
+			}
+		case ccHeaderAttemptRe.MatchString(header):
+			lint.add("header %q is not a valid Conventional Commits header: expected \"type(scope): description\" with a space after the colon", header)
+		case convention == semver.CommitMessageConventionConventionalCommits && git.ParseMergeMessage(msg, ec.MergeMessageFormats).IsEmpty():
+			lint.add("header %q does not follow Conventional Commits, so the commit does not bump", header)
+		}
+		if lint.Type == "" && breakingFooterRe.MatchString(msg) {
+			lint.add("BREAKING CHANGE footer is ignored without a Conventional Commits header")
+		}
+	}
+
+	// 2. Bump directives.
+	directives := matchBumpDirectives(msg, ec)
+	if len(directives) > 0 && !usesDirectives {
+		lint.add("bump directive %q is ignored: commit-message-convention is %s", directives[0].text, convention)
+		return lint
+	}
+	var bumping []bumpDirective
+	for _, d := range directives {
+		if d.field != semver.VersionFieldNone {
+			bumping = append(bumping, d)
+		}
+	}
+	if len(bumping) > 1 {
+		lint.add("conflicting bump directives %q and %q; %s is used", bumping[0].text, bumping[1].text, lint.Bump)
+	}
+	cc := semver.VersionFieldNone
+	if usesCC {
+		cc = analyzeConventionalCommit(msg)
+	}
+	if len(bumping) > 0 && cc != semver.VersionFieldNone && cc != bumping[0].field {
+		lint.add("%q means %s but %q means %s; %s is used", strings.TrimSpace(ccTypeRe.FindString(header)), cc, bumping[0].text, bumping[0].field, lint.Bump)
+	}
+	for _, d := range directives {
+		if d.field == semver.VersionFieldNone && lint.Bump != semver.VersionFieldNone {
+			lint.add("%q does not prevent the bump: the commit still bumps %s", d.text, lint.Bump)
+		}
+	}
+
+	return lint
+}
+
+func (l *CommitLint) add(format string, args ...any) {
+	l.Problems = append(l.Problems, fmt.Sprintf(format, args...))
+}
+
+// bumpDirective is a bump directive found in a commit message.
+type bumpDirective struct {
+	text  string
+	field semver.VersionField // None for a no-bump directive
+}
+
+// matchBumpDirectives returns the bump directives in msg, highest first.
+func matchBumpDirectives(msg string, ec config.EffectiveConfiguration) []bumpDirective {
+	patterns := []struct {
+		pattern string
+		field   semver.VersionField
+	}{
+		{ec.MajorVersionBumpMessage, semver.VersionFieldMajor},
+		{ec.MinorVersionBumpMessage, semver.VersionFieldMinor},
+		{ec.PatchVersionBumpMessage, semver.VersionFieldPatch},
+		{ec.NoBumpMessage, semver.VersionFieldNone},
+	}
+	var found []bumpDirective
+	for _, p := range patterns {
+		if p.pattern == "" {
+			continue
+		}
+		re, err := regexp.Compile(p.pattern)
+		if err != nil {
+			continue
+		}
+		if text := re.FindString(msg); text != "" {
+			found = append(found, bumpDirective{text: text, field: p.field})
+		}
+	}
+	return found
+}
@@ -0,0 +1,128 @@
+package calculator
+
+import (
+	"testing"
+
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/git"
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/semver"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLintCommit(t *testing.T) {
+	conventional := semver.CommitMessageConventionConventionalCommits
+	tests := []struct {
+		name       string
+		msg        string
+		convention *semver.CommitMessageConvention // nil for Both
+		bump       semver.VersionField
+		problems   []string
+	}{
+		{
+			name: "valid feature",
+			msg:  "feat(auth): add login",
+			bump: semver.VersionFieldMinor,
+		},
+		{
+			name: "breaking footer",
+			msg:  "fix: drop v1 endpoint\n\nBREAKING CHANGE: v1 is gone",
+			bump: semver.VersionFieldMajor,
+		},
+		{
+			name:     "missing space",
+			msg:      "feat:add login",
+			problems: []string{`header "feat:add login" is not a valid Conventional Commits header: expected "type(scope): description" with a space after the colon`},
+		},
+		{
+			name:     "unclosed scope",
+			msg:      "fix(auth: typo",
+			problems: []string{`header "fix(auth: typo" is not a valid Conventional Commits header: expected "type(scope): description" with a space after the colon`},
+		},
+		{
+			name:     "empty description",
+			msg:      "fix: ",
+			bump:     semver.VersionFieldPatch,
+			problems: []string{`header "fix: " has no description after the colon`},
+		},
+		{
+			name:     "unknown type",
+			msg:      "feature: add login",
+			problems: []string{`unknown type "feature" (expected one of build, chore, ci, docs, feat, fix, perf, refactor, revert, style, test)`},
+		},
+		{
+			name:     "directive conflicts with type",
+			msg:      "fix: rename flag +semver: major",
+			bump:     semver.VersionFieldMajor,
+			problems: []string{`"fix:" means Patch but "+semver: major" means Major; Major is used`},
+		},
+		{
+			name:     "conflicting directives",
+			msg:      "chore: bump +semver: minor +semver: patch",
+			bump:     semver.VersionFieldMinor,
+			problems: []string{`conflicting bump directives "+semver: minor" and "+semver: patch"; Minor is used`},
+		},
+		{
+			name:     "no-bump directive has no effect",
+			msg:      "feat: add flag +semver: none",
+			bump:     semver.VersionFieldMinor,
+			problems: []string{`"+semver: none" does not prevent the bump: the commit still bumps Minor`},
+		},
+		{
+			name: "plain message with both conventions",
+			msg:  "Update README",
+		},
+		{
+			name:       "plain message with Conventional Commits only",
+			msg:        "Update README",
+			convention: &conventional,
+			problems:   []string{`header "Update README" does not follow Conventional Commits, so the commit does not bump`},
+		},
+		{
+			name:       "merge message with Conventional Commits only",
+			msg:        "Merge branch 'feature/x' into main",
+			convention: &conventional,
+		},
+		{
+			name:       "directive ignored",
+			msg:        "docs: readme +semver: minor",
+			convention: &conventional,
+			problems:   []string{`bump directive "+semver: minor" is ignored: commit-message-convention is ConventionalCommits`},
+		},
+		{
+			name:     "breaking footer ignored",
+			msg:      "Drop v1\n\nBREAKING CHANGE: v1 is gone",
+			problems: []string{"BREAKING CHANGE footer is ignored without a Conventional Commits header"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ec := defaultEC()
+			if tt.convention != nil {
+				ec.CommitMessageConvention = *tt.convention
+			}
+			lint := LintCommit(git.Commit{Message: tt.msg}, ec, DefaultCommitTypes)
+			require.Equal(t, tt.bump, lint.Bump)
+			require.Equal(t, tt.problems, lint.Problems)
+		})
+	}
+}
+
+func TestLintCommit_Convention(t *testing.T) {
+	lint := LintCommit(git.Commit{Message: "chore: tidy +semver: minor"}, defaultEC(), DefaultCommitTypes)
+	require.Equal(t, "chore", lint.Type)
+	require.Equal(t, semver.VersionFieldMinor, lint.Bump)
+	require.Equal(t, "Bump Directive", lint.Convention)
+	require.Empty(t, lint.Problems)
+}
+
+func TestLintCommit_MergeMessageOnly(t *testing.T) {
+	ec := defaultEC()
+	ec.CommitMessageIncrementing = semver.CommitMessageIncrementMergeMessageOnly
+
+	lint := LintCommit(git.Commit{Message: "feat: add login"}, ec, DefaultCommitTypes)
+	require.Equal(t, semver.VersionFieldNone, lint.Bump)
+
+	merge := git.Commit{Message: "feat: add login", Parents: []string{"a", "b"}}
+	require.Equal(t, semver.VersionFieldMinor, LintCommit(merge, ec, DefaultCommitTypes).Bump)
+}
@@ -114,6 +114,7 @@ func (m *MainlineVersionCalculator) eachCommitVersion(
 
 		if field != semver.VersionFieldNone {
 			ver = ver.IncrementField(field)
+			exp.Decide(c, field)
 		} else if bv.ShouldIncrement {
 			ver = ver.IncrementField(defaultField)
 		}
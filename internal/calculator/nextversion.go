@@ -2,6 +2,8 @@ package calculator
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
 
 	"github.com/MyCarrier-DevOps/go-gitsemver/internal/config"
 	"github.com/MyCarrier-DevOps/go-gitsemver/internal/context"
@@ -17,8 +19,17 @@ type VersionResult struct {
 	BranchName           string
 	CommitsSince         int64
 	AllCandidates        []strategy.BaseVersion
-	IncrementExplanation *IncrementExplanation // nil when explain is false
-	PreReleaseSteps      []string              // nil when explain is false
+	Evaluations          []CandidateEvaluation    // Evaluations[i] describes AllCandidates[i]
+	IncrementExplanation *IncrementExplanation    // nil when explain is false
+	PreReleaseSteps      []string                 // nil when explain is false
+	BranchConfig         *BranchConfigExplanation // nil when explain is false
+}
+
+// BranchConfigExplanation records which branch configurations matched the
+// branch being versioned. Matches are in selection order; the first won.
+type BranchConfigExplanation struct {
+	Branch  string
+	Matches []config.BranchMatch
 }
 
 // NextVersionCalculator orchestrates the full version calculation pipeline.
@@ -49,11 +60,17 @@ func (c *NextVersionCalculator) Calculate(
 	ec config.EffectiveConfiguration,
 	explain bool,
 ) (VersionResult, error) {
+	var branchExp *BranchConfigExplanation
+	if explain {
+		branchExp = explainBranchConfig(ctx)
+	}
+
 	// Step 1: If current commit is already tagged, return the tagged version.
 	if ctx.IsCurrentCommitTagged {
 		return VersionResult{
-			Version:    ctx.CurrentCommitTaggedVersion,
-			BranchName: branchNameForTag(ctx, ec),
+			Version:      ctx.CurrentCommitTaggedVersion,
+			BranchName:   branchNameForTag(ctx, ec),
+			BranchConfig: branchExp,
 		}, nil
 	}
 
@@ -97,11 +114,27 @@ func (c *NextVersionCalculator) Calculate(
 		BranchName:           branchName,
 		CommitsSince:         commitsSince,
 		AllCandidates:        baseResult.AllCandidates,
+		Evaluations:          baseResult.Evaluations,
 		IncrementExplanation: incrExp,
 		PreReleaseSteps:      preReleaseSteps,
+		BranchConfig:         branchExp,
 	}, nil
 }
 
+// explainBranchConfig lists the branch configurations matching the current
+// branch. Returns nil when there is no configuration to match against.
+func explainBranchConfig(ctx *context.GitVersionContext) *BranchConfigExplanation {
+	if ctx.FullConfiguration == nil {
+		return nil
+	}
+	branch := ctx.CurrentBranch.FriendlyName()
+	matches, err := ctx.FullConfiguration.MatchBranchConfigurations(branch)
+	if err != nil {
+		return nil
+	}
+	return &BranchConfigExplanation{Branch: branch, Matches: matches}
+}
+
 // standardModeVersion applies the increment pipeline for ContinuousDelivery
 // and ContinuousDeployment modes.
 func (c *NextVersionCalculator) standardModeVersion(
@@ -138,7 +171,7 @@ func (c *NextVersionCalculator) updatePreReleaseTag(
 		return ver, nil
 	}
 
-	tagName := config.GetBranchSpecificTag(branchName, ec.Tag)
+	tagName := config.GetBranchSpecificTag(branchName, ec.Tag, ec.BranchRegex)
 	if tagName == "" {
 		return ver, nil
 	}
@@ -148,6 +181,19 @@ func (c *NextVersionCalculator) updatePreReleaseTag(
 		steps = append(steps, fmt.Sprintf("branch config tag=%q -> %q", ec.Tag, tagName))
 	}
 
+	if ec.TagNumberPattern != "" || strings.Contains(tagName, "{number}") {
+		var step string
+		tagName, step = applyTagNumber(tagName, branchName, ec.TagNumberPattern)
+		if explain {
+			steps = append(steps, step)
+		}
+		// A label that was only the placeholder is empty now; as with an
+		// empty tag, the version gets no pre-release.
+		if tagName == "" {
+			return ver, steps
+		}
+	}
+
 	var number int64
 
 	if ec.BranchMode == semver.VersioningModeMainline {
@@ -188,6 +234,35 @@ func (c *NextVersionCalculator) updatePreReleaseTag(
 	return ver.WithPreReleaseTag(semver.PreReleaseTag{Name: tagName, Number: &number}), steps
 }
 
+// applyTagNumber adds the number captured by the "number" group of pattern
+// in branchName to the pre-release label: it replaces a {number} placeholder
+// in tagName, or is appended to it (pull/123/merge: PullRequest ->
+// PullRequest123, pr.{number} -> pr.123). Returns the label and an
+// explanation step.
+func applyTagNumber(tagName, branchName, pattern string) (string, string) {
+	number := ""
+	if re, err := regexp.Compile(pattern); err == nil {
+		if m := re.FindStringSubmatch(branchName); m != nil {
+			if i := re.SubexpIndex("number"); i > 0 {
+				number = m[i]
+			}
+		}
+	}
+
+	if number == "" {
+		// Drop an unfilled placeholder along with its separator.
+		label := strings.ReplaceAll(tagName, "{number}", "")
+		label = strings.Trim(strings.ReplaceAll(label, "..", "."), ".-")
+		return label, fmt.Sprintf("tag-number-pattern %q found no number in %q -> %q", pattern, branchName, label)
+	}
+
+	label := tagName + number
+	if strings.Contains(tagName, "{number}") {
+		label = strings.ReplaceAll(tagName, "{number}", number)
+	}
+	return label, fmt.Sprintf("tag-number-pattern %q matched %s in %q -> %q", pattern, number, branchName, label)
+}
+
 // effectiveBranchName returns the branch name to use for pre-release tags.
 func effectiveBranchName(
 	ctx *context.GitVersionContext,
@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/config"
 	"github.com/MyCarrier-DevOps/go-gitsemver/internal/context"
 	"github.com/MyCarrier-DevOps/go-gitsemver/internal/git"
 	"github.com/MyCarrier-DevOps/go-gitsemver/internal/semver"
@@ -327,3 +328,107 @@ func TestNextVersion_ReleaseBranchNoPreRelease(t *testing.T) {
 	// Release branches don't get pre-release tags.
 	require.False(t, result.Version.PreReleaseTag.HasTag())
 }
+
+func TestNextVersion_ExplainBranchConfig(t *testing.T) {
+	tip := newCommit("aaa0000000000000000000000000000000000000", "tagged")
+
+	store := git.NewRepositoryStore(&git.MockRepository{})
+	calc := NewNextVersionCalculator(store, nil)
+
+	cfg, err := config.NewBuilder().Build()
+	require.NoError(t, err)
+	ctx := &context.GitVersionContext{
+		FullConfiguration:          cfg,
+		CurrentBranch:              git.Branch{Name: git.NewReferenceName("refs/heads/feature/login")},
+		CurrentCommit:              tip,
+		IsCurrentCommitTagged:      true,
+		CurrentCommitTaggedVersion: semver.SemanticVersion{Major: 1},
+	}
+
+	result, err := calc.Calculate(ctx, defaultEC(), false)
+	require.NoError(t, err)
+	require.Nil(t, result.BranchConfig)
+
+	result, err = calc.Calculate(ctx, defaultEC(), true)
+	require.NoError(t, err)
+	require.NotNil(t, result.BranchConfig)
+	require.Equal(t, "feature/login", result.BranchConfig.Branch)
+	require.Len(t, result.BranchConfig.Matches, 2)
+	require.Equal(t, "feature", result.BranchConfig.Matches[0].Name)
+	require.Equal(t, "unknown", result.BranchConfig.Matches[1].Name)
+}
+
+func TestNextVersion_TagNumberPattern(t *testing.T) {
+	tip := newCommit("aaa0000000000000000000000000000000000000", "fix: typo")
+	source := newCommit("bbb0000000000000000000000000000000000000", "initial")
+
+	mock := &git.MockRepository{
+		CommitLogFunc: func(from, to string, filters ...git.PathFilter) ([]git.Commit, error) {
+			return []git.Commit{tip, source}, nil
+		},
+		TagsFunc: func(filters ...git.PathFilter) ([]git.Tag, error) { return nil, nil },
+	}
+	store := git.NewRepositoryStore(mock)
+
+	vs := &stubStrategy{
+		name: "test",
+		versions: []strategy.BaseVersion{
+			{
+				Source:            "tag",
+				SemanticVersion:   semver.SemanticVersion{Major: 1},
+				ShouldIncrement:   true,
+				BaseVersionSource: &source,
+			},
+		},
+	}
+	calc := NewNextVersionCalculator(store, []strategy.VersionStrategy{vs})
+
+	ctx := &context.GitVersionContext{
+		CurrentBranch: git.Branch{
+			Name: git.NewReferenceName("refs/heads/pull/123/merge"),
+			Tip:  &tip,
+		},
+		CurrentCommit: tip,
+	}
+	ec := defaultEC()
+	ec.Tag = "PullRequest"
+	ec.TagNumberPattern = `[/-](?<number>\d+)`
+
+	result, err := calc.Calculate(ctx, ec, true)
+	require.NoError(t, err)
+	require.Equal(t, "1.0.1-PullRequest123.1", result.Version.SemVer())
+	require.Contains(t, result.PreReleaseSteps, `tag-number-pattern "[/-](?<number>\\d+)" matched 123 in "pull/123/merge" -> "PullRequest123"`)
+
+	ec.Tag = "pr.{number}"
+	result, err = calc.Calculate(ctx, ec, false)
+	require.NoError(t, err)
+	require.Equal(t, "1.0.1-pr.123.1", result.Version.SemVer())
+
+	// Nothing is left of a bare placeholder without a number: no
+	// pre-release, rather than a numeric-only one.
+	ec.Tag = "{number}"
+	ec.TagNumberPattern = `^release/(?<number>\d+)`
+	result, err = calc.Calculate(ctx, ec, false)
+	require.NoError(t, err)
+	require.Equal(t, "1.0.1", result.Version.SemVer())
+}
+
+func TestApplyTagNumber(t *testing.T) {
+	tests := []struct {
+		tag, branch, pattern, want string
+	}{
+		{"PullRequest", "pull/42/merge", `[/-](?<number>\d+)`, "PullRequest42"},
+		{"pr.{number}", "pr-7", `[/-](?<number>\d+)`, "pr.7"},
+		{"pr{number}", "pull-requests/9", `[/-](?<number>\d+)`, "pr9"},
+		{"PullRequest", "pull/merge", `[/-](?<number>\d+)`, "PullRequest"},
+		{"pr.{number}", "pull/merge", `[/-](?<number>\d+)`, "pr"},
+		{"{number}", "pull/merge", `[/-](?<number>\d+)`, ""},
+		{"PullRequest", "pull/42/merge", `[/-](\d+)`, "PullRequest"},
+		{"PullRequest", "pull/42/merge", `(`, "PullRequest"},
+	}
+	for _, tt := range tests {
+		got, step := applyTagNumber(tt.tag, tt.branch, tt.pattern)
+		require.Equal(t, tt.want, got, "%s with %s", tt.tag, tt.branch)
+		require.Contains(t, step, "tag-number-pattern")
+	}
+}
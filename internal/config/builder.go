@@ -2,7 +2,9 @@ package config
 
 import (
 	"fmt"
+	"reflect"
 	"regexp"
+	"strings"
 
 	"github.com/MyCarrier-DevOps/go-gitsemver/internal/semver"
 )
@@ -10,8 +12,19 @@ import (
 // Builder constructs a Config by layering overrides on top of defaults.
 type Builder struct {
 	overrides []*Config
+	sources   []string
 }
 
+// Source labels used by BuildAnnotated for values not set by a named layer.
+const (
+	SourceDefaults = "defaults"
+	SourceOverride = "override"
+)
+
+// Sources maps dotted YAML key paths ("mode", "branches.main.regex") to the
+// label of the layer that last set them.
+type Sources map[string]string
+
 // NewBuilder creates a new configuration builder.
 func NewBuilder() *Builder {
 	return &Builder{}
@@ -20,8 +33,23 @@ func NewBuilder() *Builder {
 // Add adds a configuration override. Overrides are applied in order:
 // later overrides take precedence over earlier ones.
 func (b *Builder) Add(override *Config) *Builder {
+	return b.AddLayer(SourceOverride, override)
+}
+
+// AddLayer adds a configuration override labelled with its source, such as
+// the file it was loaded from.
+func (b *Builder) AddLayer(source string, override *Config) *Builder {
 	if override != nil {
 		b.overrides = append(b.overrides, override)
+		b.sources = append(b.sources, source)
+	}
+	return b
+}
+
+// AddLayers adds each layer in order.
+func (b *Builder) AddLayers(layers []Layer) *Builder {
+	for _, l := range layers {
+		b.AddLayer(l.Source, l.Config)
 	}
 	return b
 }
@@ -29,19 +57,86 @@ func (b *Builder) Add(override *Config) *Builder {
 // Build constructs the final configuration by starting with defaults,
 // applying all overrides, finalizing branch configs, and validating.
 func (b *Builder) Build() (*Config, error) {
+	cfg, _, err := b.BuildAnnotated()
+	return cfg, err
+}
+
+// BuildAnnotated is like Build and also reports which layer set each value.
+// Branch values inherited from a global setting take the global's source.
+func (b *Builder) BuildAnnotated() (*Config, Sources, error) {
 	cfg := CreateDefaultConfiguration()
+	sources := make(Sources)
+	recordSources(sources, "", reflect.ValueOf(cfg).Elem(), SourceDefaults)
 
-	for _, override := range b.overrides {
+	for i, override := range b.overrides {
+		recordSources(sources, "", reflect.ValueOf(override).Elem(), b.sources[i])
 		mergeConfig(cfg, override)
 	}
 
 	finalizeBranches(cfg)
+	recordInherited(sources, cfg)
 
 	if err := validate(cfg); err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	return cfg, sources, nil
+}
+
+// recordSources records source for every non-nil value in v, a Config or
+// one of its nested structs, under YAML key paths beneath prefix.
+func recordSources(sources Sources, prefix string, v reflect.Value, source string) {
+	t := v.Type()
+	for i := range t.NumField() {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("yaml"), ",")
+		if name == "" || name == "-" || name == "extends" {
+			continue
+		}
+		recordValue(sources, joinPath(prefix, name), v.Field(i), source)
 	}
+}
 
-	return cfg, nil
+func recordValue(sources Sources, p string, v reflect.Value, source string) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return
+		}
+		if v.Elem().Kind() == reflect.Struct && v.Elem().Type() == reflect.TypeOf(BranchConfig{}) {
+			recordSources(sources, p, v.Elem(), source)
+			return
+		}
+		sources[p] = source
+	case reflect.Struct:
+		recordSources(sources, p, v, source)
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			recordValue(sources, joinPath(p, iter.Key().String()), iter.Value(), source)
+		}
+	case reflect.Slice:
+		if !v.IsNil() {
+			sources[p] = source
+		}
+	default:
+		sources[p] = source
+	}
+}
+
+// recordInherited attributes branch values filled in by finalizeBranches
+// to the global setting they were copied from.
+func recordInherited(sources Sources, cfg *Config) {
+	inherited := []string{"increment", "mode", "commit-message-incrementing"}
+	for name := range cfg.Branches {
+		for _, key := range inherited {
+			p := "branches." + name + "." + key
+			if _, ok := sources[p]; !ok {
+				if src, ok := sources[key]; ok {
+					sources[p] = src
+				}
+			}
+		}
+	}
 }
 
 // mergeConfig applies non-nil fields from src to dst.
@@ -172,7 +267,8 @@ func finalizeBranches(cfg *Config) {
 	}
 
 	// Process is-source-branch-for (inverse source-branches)
-	for name, branch := range cfg.Branches {
+	for _, name := range sortedKeys(cfg.Branches) {
+		branch := cfg.Branches[name]
 		if branch.IsSourceBranchFor == nil {
 			continue
 		}
@@ -220,3 +220,35 @@ func TestBuilder_InheritCommitMessageIncrementing(t *testing.T) {
 			"branch %s should have CommitMessageIncrementing set", name)
 	}
 }
+
+func TestBuilder_BuildAnnotated(t *testing.T) {
+	base := &Config{
+		Mode:      versioningModePtr(semver.VersioningModeMainline),
+		TagPrefix: stringPtr("v"),
+	}
+	repo := &Config{
+		TagPrefix: stringPtr("release-"),
+		Branches: map[string]*BranchConfig{
+			"docs": {Regex: stringPtr(`^docs/`)},
+		},
+	}
+
+	cfg, sources, err := NewBuilder().AddLayer("org.yml", base).AddLayer("go-gitsemver.yml", repo).BuildAnnotated()
+	require.NoError(t, err)
+	require.Equal(t, "release-", *cfg.TagPrefix)
+
+	require.Equal(t, "org.yml", sources["mode"])
+	require.Equal(t, "go-gitsemver.yml", sources["tag-prefix"])
+	require.Equal(t, SourceDefaults, sources["base-version"])
+	require.Equal(t, SourceDefaults, sources["branches.main.regex"])
+	require.Equal(t, "go-gitsemver.yml", sources["branches.docs.regex"])
+	// Inherited from the global mode set by org.yml.
+	require.Equal(t, "org.yml", sources["branches.docs.mode"])
+	require.NotContains(t, sources, "next-version")
+}
+
+func TestBuilder_AddLabelsOverride(t *testing.T) {
+	_, sources, err := NewBuilder().Add(&Config{NextVersion: stringPtr("2.0.0")}).BuildAnnotated()
+	require.NoError(t, err)
+	require.Equal(t, SourceOverride, sources["next-version"])
+}
@@ -7,6 +7,9 @@ import "github.com/MyCarrier-DevOps/go-gitsemver/internal/semver"
 // Config is the root configuration for gitsemver. All optional fields are
 // pointers to support merge semantics during configuration building.
 type Config struct {
+	// Extends names base configuration files layered beneath this one.
+	// It is resolved at load time and never merged.
+	Extends                          StringList                         `yaml:"extends"`
 	Mode                             *semver.VersioningMode             `yaml:"mode"`
 	TagPrefix                        *string                            `yaml:"tag-prefix"`
 	BaseVersion                      *string                            `yaml:"base-version"`
@@ -1,6 +1,10 @@
 package config
 
 import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/MyCarrier-DevOps/go-gitsemver/internal/semver"
@@ -31,6 +35,7 @@ type EffectiveConfiguration struct {
 	MainlineIncrement                semver.MainlineIncrementMode
 
 	// Branch-specific fields
+	BranchConfigName                      string // key of the matched branch config; set by the caller
 	BranchRegex                           string
 	BranchIncrement                       semver.IncrementStrategy
 	BranchMode                            semver.VersioningMode
@@ -106,6 +111,120 @@ func NewEffectiveConfiguration(cfg *Config, branch *BranchConfig) EffectiveConfi
 	return ec
 }
 
+// EffectiveSetting is one resolved value of an EffectiveConfiguration.
+type EffectiveSetting struct {
+	// Name is the EffectiveConfiguration field name.
+	Name string `json:"name" yaml:"name"`
+
+	// Value is the resolved value. Enumerations are shown by name.
+	Value any `json:"value" yaml:"value"`
+
+	// Key is the configuration key the value was read from.
+	Key string `json:"key" yaml:"key"`
+
+	// Source is the layer that set Key, as recorded by BuildAnnotated, or
+	// SourceDefaults when the built-in fallback applied.
+	Source string `json:"source" yaml:"source"`
+}
+
+// effectiveKeys maps each EffectiveConfiguration field to its configuration
+// key. "%s" stands for the branch config name.
+var effectiveKeys = []struct{ field, key string }{
+	{"Mode", "mode"},
+	{"TagPrefix", "tag-prefix"},
+	{"BaseVersion", "base-version"},
+	{"NextVersion", "next-version"},
+	{"Increment", "increment"},
+	{"ContinuousDeploymentFallbackTag", "continuous-delivery-fallback-tag"},
+	{"CommitMessageIncrementing", "commit-message-incrementing"},
+	{"CommitMessageConvention", "commit-message-convention"},
+	{"MajorVersionBumpMessage", "major-version-bump-message"},
+	{"MinorVersionBumpMessage", "minor-version-bump-message"},
+	{"PatchVersionBumpMessage", "patch-version-bump-message"},
+	{"NoBumpMessage", "no-bump-message"},
+	{"CommitDateFormat", "commit-date-format"},
+	{"UpdateBuildNumber", "update-build-number"},
+	{"TagPreReleaseWeight", "tag-pre-release-weight"},
+	{"LegacySemVerPadding", "legacy-semver-padding"},
+	{"BuildMetaDataPadding", "build-metadata-padding"},
+	{"CommitsSinceVersionSourcePadding", "commits-since-version-source-padding"},
+	{"MainlineIncrement", "mainline-increment"},
+	{"BranchRegex", "branches.%s.regex"},
+	{"BranchIncrement", "branches.%s.increment"},
+	{"BranchMode", "branches.%s.mode"},
+	{"Tag", "branches.%s.tag"},
+	{"SourceBranches", "branches.%s.source-branches"},
+	{"IsMainline", "branches.%s.is-mainline"},
+	{"IsReleaseBranch", "branches.%s.is-release-branch"},
+	{"TracksReleaseBranches", "branches.%s.tracks-release-branches"},
+	{"PreventIncrementOfMergedBranchVersion", "branches.%s.prevent-increment-of-merged-branch-version"},
+	{"TrackMergeTarget", "branches.%s.track-merge-target"},
+	{"TagNumberPattern", "branches.%s.tag-number-pattern"},
+	{"BranchCommitMessageIncrementing", "branches.%s.commit-message-incrementing"},
+	{"PreReleaseWeight", "branches.%s.pre-release-weight"},
+	{"Priority", "branches.%s.priority"},
+	{"IgnoreCommitsBefore", "ignore.commits-before"},
+	{"IgnoreSha", "ignore.sha"},
+	{"MergeMessageFormats", "merge-message-formats"},
+}
+
+// Settings lists every value of ec in field order with the configuration
+// key it was resolved from and the layer that set it. sources comes from
+// Builder.BuildAnnotated; BranchConfigName must be set.
+func (ec EffectiveConfiguration) Settings(sources Sources) []EffectiveSetting {
+	v := reflect.ValueOf(ec)
+	settings := make([]EffectiveSetting, 0, len(effectiveKeys))
+	for _, k := range effectiveKeys {
+		key := k.key
+		if strings.Contains(key, "%s") {
+			key = fmt.Sprintf(key, ec.BranchConfigName)
+		}
+		settings = append(settings, EffectiveSetting{
+			Name:   k.field,
+			Value:  settingValue(v.FieldByName(k.field)),
+			Key:    key,
+			Source: settingSource(sources, key),
+		})
+	}
+	return settings
+}
+
+// settingValue returns v for display, naming enumerations and
+// dereferencing pointers.
+func settingValue(v reflect.Value) any {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if s, ok := v.Interface().(fmt.Stringer); ok && v.Kind() == reflect.Int {
+		return s.String()
+	}
+	return v.Interface()
+}
+
+// settingSource returns the layer that set key, or the layers that set its
+// entries when key is a map.
+func settingSource(sources Sources, key string) string {
+	if src, ok := sources[key]; ok {
+		return src
+	}
+	seen := make(map[string]bool)
+	var layers []string
+	for p, src := range sources {
+		if strings.HasPrefix(p, key+".") && !seen[src] {
+			seen[src] = true
+			layers = append(layers, src)
+		}
+	}
+	if len(layers) == 0 {
+		return SourceDefaults
+	}
+	sort.Strings(layers)
+	return strings.Join(layers, ", ")
+}
+
 func derefString(p *string, fallback string) string {
 	if p != nil {
 		return *p
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"reflect"
 	"testing"
 	"time"
 
@@ -141,3 +142,45 @@ func TestNewEffectiveConfiguration_BranchModeOverridesGlobal(t *testing.T) {
 	require.Equal(t, semver.VersioningModeContinuousDelivery, ec.Mode) // global
 	require.Equal(t, semver.VersioningModeMainline, ec.BranchMode)     // branch override
 }
+
+func TestEffectiveConfiguration_Settings(t *testing.T) {
+	mode := semver.VersioningModeContinuousDeployment
+	builder := NewBuilder().AddLayer("go-gitsemver.yml", &Config{
+		Branches: map[string]*BranchConfig{"feature": {Mode: &mode}},
+	})
+	builder.AddLayer("--set", &Config{MergeMessageFormats: map[string]string{"azure": `^Merged PR (?P<PullRequestNumber>\d+)`}})
+	cfg, sources, err := builder.BuildAnnotated()
+	require.NoError(t, err)
+
+	ec := NewEffectiveConfiguration(cfg, cfg.Branches["feature"])
+	ec.BranchConfigName = "feature"
+	settings := ec.Settings(sources)
+
+	byName := make(map[string]EffectiveSetting)
+	for _, s := range settings {
+		byName[s.Name] = s
+	}
+	require.Equal(t, EffectiveSetting{Name: "BranchMode", Value: "ContinuousDeployment", Key: "branches.feature.mode", Source: "go-gitsemver.yml"}, byName["BranchMode"])
+	require.Equal(t, EffectiveSetting{Name: "Mode", Value: "ContinuousDelivery", Key: "mode", Source: SourceDefaults}, byName["Mode"])
+	require.Equal(t, "{BranchName}", byName["Tag"].Value)
+	require.Equal(t, SourceDefaults, byName["Tag"].Source)
+	require.Equal(t, "--set", byName["MergeMessageFormats"].Source)
+	require.Equal(t, SourceDefaults, byName["IgnoreSha"].Source)
+	require.Nil(t, byName["IgnoreCommitsBefore"].Value)
+}
+
+func TestEffectiveConfiguration_SettingsCoverEveryField(t *testing.T) {
+	settings := EffectiveConfiguration{BranchConfigName: "main"}.Settings(nil)
+	names := make(map[string]bool)
+	for _, s := range settings {
+		names[s.Name] = true
+	}
+
+	typ := reflect.TypeOf(EffectiveConfiguration{})
+	for i := range typ.NumField() {
+		if name := typ.Field(i).Name; name != "BranchConfigName" {
+			require.True(t, names[name], "%s has no configuration key", name)
+		}
+	}
+	require.Len(t, settings, typ.NumField()-1)
+}
@@ -7,17 +7,45 @@ import (
 	"strings"
 )
 
-type branchMatch struct {
-	name     string
-	branch   *BranchConfig
-	priority int
+// BranchMatch is a branch configuration whose regex matched a branch name.
+type BranchMatch struct {
+	Name     string
+	Regex    string
+	Priority int
+	Config   *BranchConfig
 }
 
 // GetBranchConfiguration returns the best-matching BranchConfig for the given
 // branch name, using priority-ordered regex matching. Returns the
 // matched BranchConfig, the branch config key name, and any error.
 func (cfg *Config) GetBranchConfiguration(branchName string) (*BranchConfig, string, error) {
-	var matches []branchMatch
+	matches, err := cfg.MatchBranchConfigurations(branchName)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(matches) == 0 {
+		return nil, "", fmt.Errorf("no branch configuration matches %q", branchName)
+	}
+	return matches[0].Config, matches[0].Name, nil
+}
+
+// GetEffectiveConfiguration resolves the effective configuration for a
+// branch name using the best-matching branch configuration.
+func (cfg *Config) GetEffectiveConfiguration(branchName string) (EffectiveConfiguration, error) {
+	bc, name, err := cfg.GetBranchConfiguration(branchName)
+	if err != nil {
+		return EffectiveConfiguration{}, err
+	}
+	ec := NewEffectiveConfiguration(cfg, bc)
+	ec.BranchConfigName = name
+	return ec, nil
+}
+
+// MatchBranchConfigurations returns every branch configuration whose regex
+// matches branchName, ordered by priority descending and then by name. The
+// first entry is the one GetBranchConfiguration selects.
+func (cfg *Config) MatchBranchConfigurations(branchName string) ([]BranchMatch, error) {
+	var matches []BranchMatch
 
 	for name, branch := range cfg.Branches {
 		if branch.Regex == nil {
@@ -25,34 +53,31 @@ func (cfg *Config) GetBranchConfiguration(branchName string) (*BranchConfig, str
 		}
 		re, err := regexp.Compile(*branch.Regex)
 		if err != nil {
-			return nil, "", fmt.Errorf("invalid regex for branch %q: %w", name, err)
+			return nil, fmt.Errorf("invalid regex for branch %q: %w", name, err)
 		}
 		if re.MatchString(branchName) {
 			p := 0
 			if branch.Priority != nil {
 				p = *branch.Priority
 			}
-			matches = append(matches, branchMatch{
-				name:     name,
-				branch:   branch,
-				priority: p,
+			matches = append(matches, BranchMatch{
+				Name:     name,
+				Regex:    *branch.Regex,
+				Priority: p,
+				Config:   branch,
 			})
 		}
 	}
 
-	if len(matches) == 0 {
-		return nil, "", fmt.Errorf("no branch configuration matches %q", branchName)
-	}
-
 	// Sort by priority descending, then by name ascending for determinism
 	sort.Slice(matches, func(i, j int) bool {
-		if matches[i].priority != matches[j].priority {
-			return matches[i].priority > matches[j].priority
+		if matches[i].Priority != matches[j].Priority {
+			return matches[i].Priority > matches[j].Priority
 		}
-		return matches[i].name < matches[j].name
+		return matches[i].Name < matches[j].Name
 	})
 
-	return matches[0].branch, matches[0].name, nil
+	return matches, nil
 }
 
 // GetReleaseBranchConfig returns all branch configurations where
@@ -99,16 +124,54 @@ var branchPrefixes = []string{
 
 var branchNameCleaner = regexp.MustCompile(`[^a-zA-Z0-9-]`)
 
-// GetBranchSpecificTag resolves the pre-release tag for a branch,
-// replacing {BranchName} with the actual branch name (with prefix stripped
-// and special characters replaced with hyphens).
-func GetBranchSpecificTag(branchName, tag string) string {
-	if !strings.Contains(tag, "{BranchName}") {
+// GetBranchSpecificTag resolves the pre-release tag for a branch.
+// {BranchName} is replaced with the branch name (with prefix stripped), and
+// each named group of branchRegex, such as (?P<ticket>...), fills the
+// matching placeholder ({ticket}). A group named BranchName overrides the
+// default, which suits prefixes outside the built-in list. Values have
+// special characters replaced with hyphens. A group that is empty or did
+// not take part in the match falls back to the {BranchName} value, so the
+// label never silently becomes empty.
+func GetBranchSpecificTag(branchName, tag, branchRegex string) string {
+	if !strings.Contains(tag, "{") {
 		return tag
 	}
-	cleaned := stripBranchPrefix(branchName)
-	cleaned = branchNameCleaner.ReplaceAllString(cleaned, "-")
-	return strings.ReplaceAll(tag, "{BranchName}", cleaned)
+
+	branchValue := stripBranchPrefix(branchName)
+	groups := make(map[string]string)
+	if branchRegex != "" {
+		if re, err := regexp.Compile(branchRegex); err == nil {
+			m := re.FindStringSubmatch(branchName)
+			for i, name := range re.SubexpNames() {
+				if name == "" {
+					continue
+				}
+				groups[name] = ""
+				if m != nil {
+					groups[name] = m[i]
+				}
+			}
+		}
+	}
+	if v := groups["BranchName"]; v != "" {
+		branchValue = v
+	}
+
+	values := map[string]string{"BranchName": branchValue}
+	for name, value := range groups {
+		if value == "" {
+			value = branchValue
+		}
+		values[name] = value
+	}
+
+	for name, value := range values {
+		placeholder := "{" + name + "}"
+		if strings.Contains(tag, placeholder) {
+			tag = strings.ReplaceAll(tag, placeholder, branchNameCleaner.ReplaceAllString(value, "-"))
+		}
+	}
+	return tag
 }
 
 func stripBranchPrefix(name string) string {
@@ -237,8 +237,56 @@ func TestGetBranchSpecificTag(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := GetBranchSpecificTag(tt.branchName, tt.tag)
+			got := GetBranchSpecificTag(tt.branchName, tt.tag, "")
 			require.Equal(t, tt.want, got)
 		})
 	}
 }
+
+func TestGetBranchSpecificTag_NamedGroups(t *testing.T) {
+	tests := []struct {
+		name       string
+		branchName string
+		tag        string
+		regex      string
+		want       string
+	}{
+		{"ticket group", "feature/ABC-123-login-page", "{ticket}", `^feature/(?P<ticket>[A-Z]+-\d+)-.*`, "ABC-123"},
+		{"angle bracket syntax", "task/ops_42", "task-{id}", `^task/(?<id>.+)`, "task-ops-42"},
+		{"BranchName group overrides prefix list", "users/alice/new.thing", "{BranchName}", `^users/[^/]+/(?P<BranchName>.+)`, "new-thing"},
+		{"mixed placeholders", "feature/ABC-1-x", "{ticket}.{BranchName}", `^feature/(?P<ticket>[A-Z]+-\d+)`, "ABC-1.ABC-1-x"},
+		{"unmatched group falls back to BranchName", "feature/login", "pre{ticket}", `^feature/(?P<ticket>[A-Z]+-\d+)?`, "prelogin"},
+		{"empty group falls back to BranchName", "feature/-login", "{ticket}", `^feature/(?P<ticket>[A-Z]*)-`, "-login"},
+		{"regex does not match falls back to BranchName", "other", "{ticket}", `^feature/(?P<ticket>.+)`, "other"},
+		{"fallback uses BranchName group", "users/alice/login", "{ticket}", `^users/[^/]+/(?P<ticket>[A-Z]+-\d+)?(?P<BranchName>.+)`, "login"},
+		{"unknown placeholder kept", "feature/login", "{nope}", `^feature/(?P<ticket>.+)`, "{nope}"},
+		{"invalid regex ignored", "feature/login", "{BranchName}", `(`, "login"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, GetBranchSpecificTag(tt.branchName, tt.tag, tt.regex))
+		})
+	}
+}
+
+func TestMatchBranchConfigurations(t *testing.T) {
+	cfg, err := NewBuilder().Build()
+	require.NoError(t, err)
+
+	matches, err := cfg.MatchBranchConfigurations("feature/login")
+	require.NoError(t, err)
+	require.Len(t, matches, 2)
+	require.Equal(t, "feature", matches[0].Name)
+	require.Equal(t, 50, matches[0].Priority)
+	require.Equal(t, `^features?[/-]`, matches[0].Regex)
+	require.Same(t, cfg.Branches["feature"], matches[0].Config)
+	require.Equal(t, "unknown", matches[1].Name)
+	require.Equal(t, 0, matches[1].Priority)
+}
+
+func TestMatchBranchConfigurations_NoMatch(t *testing.T) {
+	cfg := &Config{Branches: map[string]*BranchConfig{"main": {Regex: stringPtr(`^main$`)}}}
+	matches, err := cfg.MatchBranchConfigurations("develop")
+	require.NoError(t, err)
+	require.Empty(t, matches)
+}
@@ -3,6 +3,9 @@ package config
 import (
 	"fmt"
 	"os"
+	"path"
+	"path/filepath"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
@@ -17,10 +20,169 @@ func LoadFromFile(path string) (*Config, error) {
 }
 
 // LoadFromBytes parses gitsemver configuration from raw YAML bytes.
+// GitVersion 6 key names are accepted; see parseConfig.
 func LoadFromBytes(data []byte) (*Config, error) {
+	cfg, _, err := parseConfig(data)
+	return cfg, err
+}
+
+// parseConfig parses data, accepting GitVersion 6 key names and mode values
+// in place of their go-gitsemver equivalents, and returns a deprecation
+// warning for each one used.
+func parseConfig(data []byte) (*Config, []Issue, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, nil, fmt.Errorf("parsing config: %w", err)
+	}
+
 	var cfg Config
-	if err := yaml.Unmarshal(data, &cfg); err != nil {
-		return nil, fmt.Errorf("parsing config: %w", err)
+	if len(doc.Content) == 0 {
+		return &cfg, nil, nil
+	}
+	root := doc.Content[0]
+	var warnings []Issue
+	if root.Kind == yaml.MappingNode {
+		warnings = upgradeDocument(root, isGitVersion6(root))
+	}
+	if err := root.Decode(&cfg); err != nil {
+		return nil, nil, fmt.Errorf("parsing config: %w", err)
+	}
+	return &cfg, warnings, nil
+}
+
+// Layer is one configuration file in an extends chain.
+type Layer struct {
+	// Source is the path the configuration was loaded from.
+	Source string
+	Config *Config
+	// Warnings lists deprecated syntax used by the file.
+	Warnings []Issue
+}
+
+// ReadFunc reads a configuration file by path.
+type ReadFunc func(path string) ([]byte, error)
+
+// LoadLayers reads the configuration file at p and every file it extends.
+// See LoadLayersFromBytes.
+func LoadLayers(p string, read ReadFunc) ([]Layer, error) {
+	data, err := readConfig(p, read)
+	if err != nil {
+		return nil, err
+	}
+	return LoadLayersFromBytes(p, data, read)
+}
+
+// LoadLayersFromBytes parses data, read from p, and loads every file it
+// extends, recursively. Layers are returned in the order they should be
+// added to a Builder: bases first, in the order listed, and p last.
+//
+// When read is nil, files come from the local filesystem and relative
+// extends paths resolve against the directory of the file naming them.
+// Otherwise paths are slash-separated repository paths, and a leading "/"
+// refers to the repository root.
+func LoadLayersFromBytes(p string, data []byte, read ReadFunc) ([]Layer, error) {
+	l := &layerLoader{read: read, loaded: make(map[string]bool)}
+	if err := l.load(p, data, nil); err != nil {
+		return nil, err
+	}
+	return l.layers, nil
+}
+
+// layerLoader walks an extends graph depth-first. A file reached twice
+// through different branches of the graph is only layered once; a file
+// reached again through its own chain is a cycle.
+type layerLoader struct {
+	read   ReadFunc
+	loaded map[string]bool
+	layers []Layer
+}
+
+func (l *layerLoader) load(p string, data []byte, chain []string) error {
+	key := l.key(p)
+	for _, c := range chain {
+		if l.key(c) == key {
+			return fmt.Errorf("config extends cycle: %s", strings.Join(append(chain, p), " -> "))
+		}
+	}
+	if l.loaded[key] {
+		return nil
+	}
+
+	cfg, warnings, err := parseConfig(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", p, err)
+	}
+
+	chain = append(chain, p)
+	for _, ref := range cfg.Extends {
+		base := l.resolve(p, ref)
+		baseData, err := readConfig(base, l.read)
+		if err != nil {
+			return fmt.Errorf("loading %s extended by %s: %w", ref, p, err)
+		}
+		if err := l.load(base, baseData, chain); err != nil {
+			return err
+		}
+	}
+
+	l.loaded[key] = true
+	l.layers = append(l.layers, Layer{Source: p, Config: cfg, Warnings: warnings})
+	return nil
+}
+
+// resolve returns the path of ref relative to the file from.
+func (l *layerLoader) resolve(from, ref string) string {
+	if l.read != nil {
+		if strings.HasPrefix(ref, "/") {
+			return path.Clean(ref[1:])
+		}
+		return path.Join(path.Dir(from), ref)
+	}
+	if filepath.IsAbs(ref) {
+		return filepath.Clean(ref)
+	}
+	return filepath.Join(filepath.Dir(from), ref)
+}
+
+// key normalizes p for cycle and duplicate detection.
+func (l *layerLoader) key(p string) string {
+	if l.read != nil {
+		return path.Clean(strings.TrimPrefix(p, "/"))
+	}
+	if abs, err := filepath.Abs(p); err == nil {
+		return abs
+	}
+	return filepath.Clean(p)
+}
+
+func readConfig(p string, read ReadFunc) ([]byte, error) {
+	if read != nil {
+		return read(p)
+	}
+	data, err := os.ReadFile(p)
+	if err != nil {
+		return nil, fmt.Errorf("reading config file: %w", err)
+	}
+	return data, nil
+}
+
+// StringList is a list of strings that also accepts a single scalar in YAML.
+type StringList []string
+
+// UnmarshalYAML accepts either "value" or ["value", ...].
+func (s *StringList) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		var one string
+		if err := value.Decode(&one); err != nil {
+			return err
+		}
+		*s = StringList{one}
+		return nil
+	}
+	var many []string
+	if err := value.Decode(&many); err != nil {
+		return err
 	}
-	return &cfg, nil
+	*s = many
+	return nil
 }
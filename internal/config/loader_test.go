@@ -149,3 +149,116 @@ func TestLoadFromFile_NotFound(t *testing.T) {
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "reading config file")
 }
+
+func writeConfigFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func layerSources(layers []Layer) []string {
+	var out []string
+	for _, l := range layers {
+		out = append(out, filepath.Base(l.Source))
+	}
+	return out
+}
+
+func TestLoadLayers_ExtendsChain(t *testing.T) {
+	dir := t.TempDir()
+	writeConfigFile(t, dir, "policy/org.yml", "mode: Mainline\ntag-prefix: v\n")
+	writeConfigFile(t, dir, "policy/team.yml", "extends: org.yml\nnext-version: 2.0.0\n")
+	path := writeConfigFile(t, dir, "go-gitsemver.yml", "extends: [policy/team.yml]\ntag-prefix: release-\n")
+
+	layers, err := LoadLayers(path, nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"org.yml", "team.yml", "go-gitsemver.yml"}, layerSources(layers))
+
+	cfg, err := NewBuilder().AddLayers(layers).Build()
+	require.NoError(t, err)
+	require.Equal(t, semver.VersioningModeMainline, *cfg.Mode)
+	require.Equal(t, "2.0.0", *cfg.NextVersion)
+	require.Equal(t, "release-", *cfg.TagPrefix)
+}
+
+func TestLoadLayers_DiamondLoadsBaseOnce(t *testing.T) {
+	dir := t.TempDir()
+	writeConfigFile(t, dir, "base.yml", "mode: Mainline\n")
+	writeConfigFile(t, dir, "a.yml", "extends: base.yml\n")
+	writeConfigFile(t, dir, "b.yml", "extends: ./base.yml\n")
+	path := writeConfigFile(t, dir, "go-gitsemver.yml", "extends: [a.yml, b.yml]\n")
+
+	layers, err := LoadLayers(path, nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"base.yml", "a.yml", "b.yml", "go-gitsemver.yml"}, layerSources(layers))
+}
+
+func TestLoadLayers_Cycle(t *testing.T) {
+	dir := t.TempDir()
+	writeConfigFile(t, dir, "a.yml", "extends: b.yml\n")
+	writeConfigFile(t, dir, "b.yml", "extends: a.yml\n")
+	path := writeConfigFile(t, dir, "go-gitsemver.yml", "extends: a.yml\n")
+
+	_, err := LoadLayers(path, nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "config extends cycle")
+	require.Contains(t, err.Error(), "a.yml -> ")
+}
+
+func TestLoadLayers_MissingBase(t *testing.T) {
+	dir := t.TempDir()
+	path := writeConfigFile(t, dir, "go-gitsemver.yml", "extends: missing.yml\n")
+
+	_, err := LoadLayers(path, nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "loading missing.yml extended by")
+}
+
+func TestLoadLayers_InvalidBase(t *testing.T) {
+	dir := t.TempDir()
+	writeConfigFile(t, dir, "base.yml", "mode: Sideways\n")
+	path := writeConfigFile(t, dir, "go-gitsemver.yml", "extends: base.yml\n")
+
+	_, err := LoadLayers(path, nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "base.yml")
+	require.Contains(t, err.Error(), "unknown versioning mode")
+}
+
+func TestLoadLayersFromBytes_RepositoryPaths(t *testing.T) {
+	files := map[string]string{
+		"policy/versioning.yml": "mode: Mainline\n",
+		".github/shared.yml":    "tag-prefix: v\n",
+	}
+	var reads []string
+	read := func(path string) ([]byte, error) {
+		reads = append(reads, path)
+		content, ok := files[path]
+		if !ok {
+			return nil, os.ErrNotExist
+		}
+		return []byte(content), nil
+	}
+
+	layers, err := LoadLayersFromBytes(".github/GitVersion.yml",
+		[]byte("extends: [/policy/versioning.yml, shared.yml]\n"), read)
+	require.NoError(t, err)
+	require.Equal(t, []string{"policy/versioning.yml", ".github/shared.yml"}, reads)
+	require.Len(t, layers, 3)
+	require.Equal(t, ".github/GitVersion.yml", layers[2].Source)
+}
+
+func TestStringList_Unmarshal(t *testing.T) {
+	cfg, err := LoadFromBytes([]byte("extends: base.yml\n"))
+	require.NoError(t, err)
+	require.Equal(t, StringList{"base.yml"}, cfg.Extends)
+
+	cfg, err = LoadFromBytes([]byte("extends: [a.yml, b.yml]\n"))
+	require.NoError(t, err)
+	require.Equal(t, StringList{"a.yml", "b.yml"}, cfg.Extends)
+
+	_, err = LoadFromBytes([]byte("extends: {a: b}\n"))
+	require.Error(t, err)
+}
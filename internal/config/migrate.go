@@ -0,0 +1,301 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// gitVersion6Renames maps GitVersion 6 branch option names to their
+// go-gitsemver equivalents.
+var gitVersion6Renames = map[string]string{
+	"label":                "tag",
+	"label-number-pattern": "tag-number-pattern",
+	"is-main-branch":       "is-mainline",
+}
+
+// gitVersion6Keys are keys that only appear in GitVersion 6 configs.
+var gitVersion6Keys = map[string]bool{
+	"workflow":                  true,
+	"label":                     true,
+	"label-number-pattern":      true,
+	"is-main-branch":            true,
+	"prevent-increment":         true,
+	"semantic-version-format":   true,
+	"strategies":                true,
+	"version-in-branch-pattern": true,
+	"track-merge-message":       true,
+}
+
+// unsupportedKeys lists GitVersion 5 and 6 keys that go-gitsemver does not
+// support, with a hint for each.
+var unsupportedKeys = map[string]string{
+	"assembly-versioning-scheme":      "assembly versions are .NET specific; use the output variables instead",
+	"assembly-file-versioning-scheme": "assembly versions are .NET specific; use the output variables instead",
+	"assembly-informational-format":   "assembly versions are .NET specific; use the output variables instead",
+	"assembly-versioning-format":      "assembly versions are .NET specific; use the output variables instead",
+	"assembly-file-versioning-format": "assembly versions are .NET specific; use the output variables instead",
+	"semantic-version-format":         "versions are always parsed loosely",
+	"version-in-branch-pattern":       "versions in release branch names are always detected",
+	"strategies":                      "all version strategies always run; Mainline maps to mode: Mainline",
+	"workflow":                        "the built-in branch defaults follow GitFlow; TrunkBased maps to mode: Mainline",
+	"track-merge-message":             "merge messages are always considered",
+	"label":                           "set tag on each branch instead",
+	"paths":                           "path filters are not supported",
+}
+
+// Migrate converts a GitVersion 5 or 6 configuration to go-gitsemver
+// syntax. from is 5 or 6, or 0 to detect the version from the keys used.
+// GitVersion 6 names are renamed, mode values are translated, and keys
+// without an equivalent are removed. Returns the migrated YAML and a
+// warning for each change, located in data.
+func Migrate(data []byte, from int) ([]byte, []Issue, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, nil, fmt.Errorf("parsing config: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil, nil
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, nil, errors.New("configuration must be a mapping")
+	}
+
+	var v6 bool
+	switch from {
+	case 0:
+		v6 = isGitVersion6(root)
+	case 5, 6:
+		v6 = from == 6
+	default:
+		return nil, nil, fmt.Errorf("unsupported GitVersion version %d, expected 5 or 6", from)
+	}
+
+	issues := upgradeDocument(root, v6)
+	issues = append(issues, prune(root, reflect.TypeOf(Config{}), "")...)
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, nil, fmt.Errorf("writing config: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, nil, fmt.Errorf("writing config: %w", err)
+	}
+	return buf.Bytes(), issues, nil
+}
+
+// isGitVersion6 reports whether root uses GitVersion 6 keys or mode values.
+func isGitVersion6(root *yaml.Node) bool {
+	check := func(m *yaml.Node) bool {
+		for i := 0; i+1 < len(m.Content); i += 2 {
+			key, value := m.Content[i], m.Content[i+1]
+			if gitVersion6Keys[key.Value] {
+				return true
+			}
+			if key.Value == "mode" && strings.EqualFold(value.Value, "ManualDeployment") {
+				return true
+			}
+		}
+		return false
+	}
+	if check(root) {
+		return true
+	}
+	if branches := mappingValue(root, "branches"); branches != nil && branches.Kind == yaml.MappingNode {
+		for i := 1; i < len(branches.Content); i += 2 {
+			if b := branches.Content[i]; b.Kind == yaml.MappingNode && check(b) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// upgradeDocument rewrites GitVersion 6 syntax in root, a config mapping,
+// in place and returns a warning for each change. v6 selects the GitVersion
+// 6 meaning of mode values, which differ from GitVersion 5.
+func upgradeDocument(root *yaml.Node, v6 bool) []Issue {
+	u := &upgrader{v6: v6}
+	u.options(root, "", true)
+	if branches := mappingValue(root, "branches"); branches != nil && branches.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(branches.Content); i += 2 {
+			if b := branches.Content[i+1]; b.Kind == yaml.MappingNode {
+				u.options(b, "branches."+branches.Content[i].Value, false)
+			}
+		}
+	}
+	return u.issues
+}
+
+type upgrader struct {
+	v6     bool
+	issues []Issue
+}
+
+func (u *upgrader) warn(node *yaml.Node, path, format string, args ...any) {
+	u.issues = append(u.issues, Issue{
+		Severity: SeverityWarning,
+		Line:     node.Line,
+		Column:   node.Column,
+		Path:     path,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// options upgrades the options of the root config or of one branch.
+func (u *upgrader) options(m *yaml.Node, path string, root bool) {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		key, value := m.Content[i], m.Content[i+1]
+		child := joinPath(path, key.Value)
+
+		switch {
+		case key.Value == "mode" && u.v6:
+			u.mode(value, child)
+
+		case key.Value == "strategies" && root && u.v6:
+			var strategies []string
+			if value.Decode(&strategies) == nil && containsFold(strategies, "Mainline") {
+				u.setMode(m, "Mainline", key, child)
+			}
+
+		case key.Value == "workflow" && root:
+			if strings.HasPrefix(value.Value, "TrunkBased") && mappingValue(m, "mode") == nil {
+				u.setMode(m, "Mainline", key, child)
+			}
+
+		case key.Value == "prevent-increment" && !root && value.Kind == yaml.MappingNode:
+			u.preventIncrement(m, i, child)
+
+		case gitVersion6Renames[key.Value] != "" && !root:
+			to := gitVersion6Renames[key.Value]
+			if mappingValue(m, to) != nil {
+				u.warn(key, child, "GitVersion 6 key %q is ignored because %q is also set", key.Value, to)
+				continue
+			}
+			u.warn(key, child, "%q is the GitVersion 6 name of %q and is deprecated", key.Value, to)
+			key.Value = to
+			if to == "tag-number-pattern" {
+				// GitVersion 6 names the group "Number".
+				value.Value = strings.ReplaceAll(value.Value, "<Number>", "<number>")
+			}
+		}
+	}
+}
+
+// mode translates a GitVersion 6 mode value.
+func (u *upgrader) mode(value *yaml.Node, path string) {
+	switch {
+	case strings.EqualFold(value.Value, "ManualDeployment"):
+		u.warn(value, path, "GitVersion 6 mode ManualDeployment is ContinuousDelivery")
+		value.Value = "ContinuousDelivery"
+	case strings.EqualFold(value.Value, "ContinuousDelivery"):
+		u.warn(value, path, "GitVersion 6 mode ContinuousDelivery is ContinuousDeployment")
+		value.Value = "ContinuousDeployment"
+	case strings.EqualFold(value.Value, "ContinuousDeployment"):
+		u.warn(value, path, "GitVersion 6 mode ContinuousDeployment drops pre-release labels; set tag: '' on branches that should produce stable versions")
+	}
+}
+
+// setMode sets the mode of m to mode, adding the key when missing.
+func (u *upgrader) setMode(m *yaml.Node, mode string, from *yaml.Node, path string) {
+	u.warn(from, path, "%s maps to mode: %s", from.Value, mode)
+	if value := mappingValue(m, "mode"); value != nil {
+		value.Value = mode
+		return
+	}
+	m.Content = append(m.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "mode"},
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: mode})
+}
+
+// preventIncrement replaces the GitVersion 6 prevent-increment mapping at
+// m.Content[i] with prevent-increment-of-merged-branch-version.
+func (u *upgrader) preventIncrement(m *yaml.Node, i int, path string) {
+	key, value := m.Content[i], m.Content[i+1]
+	var merged *yaml.Node
+	for j := 0; j+1 < len(value.Content); j += 2 {
+		sub := value.Content[j]
+		if sub.Value == "of-merged-branch" {
+			merged = value.Content[j+1]
+			continue
+		}
+		u.warn(sub, joinPath(path, sub.Value), "GitVersion 6 option prevent-increment.%s is not supported", sub.Value)
+	}
+	if merged == nil {
+		return
+	}
+	u.warn(key, path, "GitVersion 6 option prevent-increment.of-merged-branch is prevent-increment-of-merged-branch-version")
+	key.Value = "prevent-increment-of-merged-branch-version"
+	m.Content[i+1] = merged
+}
+
+// prune removes keys that have no field in t from node, recursively, and
+// returns a warning for each.
+func prune(node *yaml.Node, t reflect.Type, path string) []Issue {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	var issues []Issue
+	switch t.Kind() {
+	case reflect.Struct:
+		fields := yamlFields(t)
+		kept := node.Content[:0]
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			child := joinPath(path, key.Value)
+			field, ok := fields[key.Value]
+			if !ok {
+				msg := fmt.Sprintf("removed unsupported key %q", key.Value)
+				if hint := unsupportedKeys[key.Value]; hint != "" {
+					msg += ": " + hint
+				}
+				issues = append(issues, Issue{
+					Severity: SeverityWarning,
+					Line:     key.Line,
+					Column:   key.Column,
+					Path:     child,
+					Message:  msg,
+				})
+				continue
+			}
+			issues = append(issues, prune(value, field, child)...)
+			kept = append(kept, key, value)
+		}
+		node.Content = kept
+	case reflect.Map:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			issues = append(issues, prune(node.Content[i+1], t.Elem(), joinPath(path, node.Content[i].Value))...)
+		}
+	}
+	return issues
+}
+
+// mappingValue returns the value of key in mapping m, or nil.
+func mappingValue(m *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func containsFold(ss []string, s string) bool {
+	for _, item := range ss {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,142 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/semver"
+
+	"github.com/stretchr/testify/require"
+)
+
+const gitVersion6Config = `workflow: GitFlow/v1
+mode: ManualDeployment
+tag-prefix: '[vV]?'
+label: '{BranchName}'
+assembly-versioning-scheme: MajorMinorPatch
+branches:
+  develop:
+    mode: ContinuousDelivery
+    label: alpha
+    is-main-branch: false
+  pull-request:
+    label: PullRequest
+    label-number-pattern: '[/-](?<Number>\d+)'
+    prevent-increment:
+      of-merged-branch: true
+      when-current-commit-tagged: false
+ignore:
+  sha: []
+  paths: [docs/]
+`
+
+func TestMigrate_GitVersion6(t *testing.T) {
+	out, issues, err := Migrate([]byte(gitVersion6Config), 0)
+	require.NoError(t, err)
+	require.Equal(t, `mode: ContinuousDelivery
+tag-prefix: '[vV]?'
+branches:
+  develop:
+    mode: ContinuousDeployment
+    tag: alpha
+    is-mainline: false
+  pull-request:
+    tag: PullRequest
+    tag-number-pattern: '[/-](?<number>\d+)'
+    prevent-increment-of-merged-branch-version: true
+ignore:
+  sha: []
+`, string(out))
+
+	var paths []string
+	for _, i := range issues {
+		require.Equal(t, SeverityWarning, i.Severity)
+		require.Positive(t, i.Line)
+		paths = append(paths, i.Path)
+	}
+	require.ElementsMatch(t, []string{
+		"mode",
+		"branches.develop.mode",
+		"branches.develop.label",
+		"branches.develop.is-main-branch",
+		"branches.pull-request.label",
+		"branches.pull-request.label-number-pattern",
+		"branches.pull-request.prevent-increment.when-current-commit-tagged",
+		"branches.pull-request.prevent-increment",
+		"workflow",
+		"label",
+		"assembly-versioning-scheme",
+		"ignore.paths",
+	}, paths)
+
+	require.Empty(t, Validate(out))
+}
+
+func TestMigrate_GitVersion5KeepsModes(t *testing.T) {
+	data := []byte("mode: ContinuousDelivery\nbranches:\n  feature:\n    tag: useBranchName\nassembly-informational-format: x\n")
+	out, issues, err := Migrate(data, 0)
+	require.NoError(t, err)
+	require.Equal(t, "mode: ContinuousDelivery\nbranches:\n  feature:\n    tag: useBranchName\n", string(out))
+	require.Len(t, issues, 1)
+	require.Contains(t, issues[0].Message, `removed unsupported key "assembly-informational-format"`)
+
+	// Forcing GitVersion 6 translates the same value.
+	out, _, err = Migrate(data, 6)
+	require.NoError(t, err)
+	require.Contains(t, string(out), "mode: ContinuousDeployment")
+}
+
+func TestMigrate_MainlineStrategy(t *testing.T) {
+	out, issues, err := Migrate([]byte("strategies: [ConfiguredNextVersion, Mainline]\n"), 0)
+	require.NoError(t, err)
+	require.Equal(t, "mode: Mainline\n", string(out))
+	require.Contains(t, issues[0].Message, "maps to mode: Mainline")
+
+	out, _, err = Migrate([]byte("workflow: TrunkBased/preview1\n"), 0)
+	require.NoError(t, err)
+	require.Equal(t, "mode: Mainline\n", string(out))
+}
+
+func TestMigrate_Errors(t *testing.T) {
+	_, _, err := Migrate([]byte("mode: [unclosed"), 0)
+	require.Error(t, err)
+	_, _, err = Migrate([]byte("- a\n"), 0)
+	require.Error(t, err)
+	_, _, err = Migrate([]byte("mode: Mainline\n"), 4)
+	require.ErrorContains(t, err, "expected 5 or 6")
+
+	out, issues, err := Migrate(nil, 0)
+	require.NoError(t, err)
+	require.Empty(t, out)
+	require.Empty(t, issues)
+}
+
+func TestLoadFromBytes_GitVersion6Keys(t *testing.T) {
+	cfg, warnings, err := parseConfig([]byte(gitVersion6Config))
+	require.NoError(t, err)
+	require.Equal(t, semver.VersioningModeContinuousDelivery, *cfg.Mode)
+	require.Equal(t, semver.VersioningModeContinuousDeployment, *cfg.Branches["develop"].Mode)
+	require.Equal(t, "alpha", *cfg.Branches["develop"].Tag)
+	require.False(t, *cfg.Branches["develop"].IsMainline)
+	require.Equal(t, `[/-](?<number>\d+)`, *cfg.Branches["pull-request"].TagNumberPattern)
+	require.True(t, *cfg.Branches["pull-request"].PreventIncrementOfMergedBranchVersion)
+	require.NotEmpty(t, warnings)
+	require.Contains(t, warnings[0].Message, "ManualDeployment")
+}
+
+func TestLoadFromBytes_RenamedKeyDoesNotOverride(t *testing.T) {
+	cfg, warnings, err := parseConfig([]byte("branches:\n  main:\n    tag: ''\n    label: beta\n"))
+	require.NoError(t, err)
+	require.Empty(t, *cfg.Branches["main"].Tag)
+	require.Len(t, warnings, 1)
+	require.Contains(t, warnings[0].Message, `is ignored because "tag" is also set`)
+}
+
+func TestValidate_GitVersion6Keys(t *testing.T) {
+	issues := Validate([]byte("branches:\n  feature:\n    label: beta\nsemantic-version-format: Strict\n"))
+	require.Len(t, issues, 2)
+	require.Equal(t, "branches.feature.label", issues[0].Path)
+	require.Contains(t, issues[0].Message, "deprecated")
+	require.Equal(t, "semantic-version-format", issues[1].Path)
+	require.Contains(t, issues[1].Message, "not supported and is ignored")
+	require.False(t, HasErrors(issues))
+}
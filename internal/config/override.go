@@ -0,0 +1,152 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// EnvPrefix is the prefix of environment variables that override config keys.
+const EnvPrefix = "GITSEMVER_"
+
+// EnvOverrides converts GITSEMVER_* entries of environ ("NAME=value", as
+// returned by os.Environ) into "key=value" assignments for ParseOverrides.
+// After the prefix, "__" separates path segments and "_" becomes "-", all
+// lowercased: GITSEMVER_BRANCHES__FEATURE__TAG=alpha sets
+// branches.feature.tag. Assignments are sorted by key.
+func EnvOverrides(environ []string) []string {
+	var out []string
+	for _, kv := range environ {
+		name, value, ok := strings.Cut(kv, "=")
+		if !ok || !strings.HasPrefix(name, EnvPrefix) || len(name) == len(EnvPrefix) {
+			continue
+		}
+		segments := strings.Split(strings.ToLower(name[len(EnvPrefix):]), "__")
+		for i, s := range segments {
+			segments[i] = strings.ReplaceAll(s, "_", "-")
+		}
+		out = append(out, strings.Join(segments, ".")+"="+value)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// ParseOverrides builds a Config from "key=value" assignments, where key is
+// a dotted YAML path such as "mode" or "branches.main.increment". Values
+// are decoded as the key's type; list values accept YAML flow syntax
+// ("[a, b]") or a comma-separated list. Later assignments win. Returns nil
+// when assignments is empty.
+func ParseOverrides(assignments []string) (*Config, error) {
+	if len(assignments) == 0 {
+		return nil, nil
+	}
+
+	cfg := &Config{}
+	for _, a := range assignments {
+		key, value, ok := strings.Cut(a, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid override %q, expected key=value", a)
+		}
+		one, err := parseOverride(key, value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid override %q: %w", a, err)
+		}
+		mergeConfig(cfg, one)
+	}
+	return cfg, nil
+}
+
+// parseOverride decodes a single assignment into an otherwise empty Config
+// by building the equivalent YAML tree.
+func parseOverride(key, value string) (*Config, error) {
+	segments := strings.Split(key, ".")
+	leaf, err := overrideType(segments)
+	if err != nil {
+		return nil, err
+	}
+
+	node, err := overrideValue(leaf, value)
+	if err != nil {
+		return nil, err
+	}
+	for i := len(segments) - 1; i >= 0; i-- {
+		node = &yaml.Node{
+			Kind: yaml.MappingNode,
+			Content: []*yaml.Node{
+				{Kind: yaml.ScalarNode, Tag: "!!str", Value: segments[i]},
+				node,
+			},
+		}
+	}
+
+	var cfg Config
+	if err := node.Decode(&cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// overrideType returns the Go type addressed by the path segments.
+func overrideType(segments []string) (reflect.Type, error) {
+	t := reflect.TypeOf(Config{})
+	for i, seg := range segments {
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		path := strings.Join(segments[:i+1], ".")
+		switch t.Kind() {
+		case reflect.Struct:
+			field, ok := yamlFields(t)[seg]
+			if !ok || seg == "extends" {
+				return nil, fmt.Errorf("unknown config key %q", path)
+			}
+			t = field
+		case reflect.Map:
+			if seg == "" {
+				return nil, fmt.Errorf("empty name in config key %q", path)
+			}
+			t = t.Elem()
+		default:
+			return nil, fmt.Errorf("unknown config key %q", path)
+		}
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Map || (t.Kind() == reflect.Struct && t.PkgPath() == reflect.TypeOf(Config{}).PkgPath()) {
+		return nil, fmt.Errorf("config key %q is not a single value", strings.Join(segments, "."))
+	}
+	return t, nil
+}
+
+// overrideValue builds the YAML node for value as type t. Strings are kept
+// verbatim so regexes like "[vV]" are not read as YAML lists.
+func overrideValue(t reflect.Type, value string) (*yaml.Node, error) {
+	switch t.Kind() {
+	case reflect.Slice:
+		if strings.HasPrefix(strings.TrimSpace(value), "[") {
+			var node yaml.Node
+			if err := yaml.Unmarshal([]byte(value), &node); err != nil {
+				return nil, err
+			}
+			return node.Content[0], nil
+		}
+		seq := &yaml.Node{Kind: yaml.SequenceNode}
+		if value != "" {
+			for _, item := range strings.Split(value, ",") {
+				seq.Content = append(seq.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: strings.TrimSpace(item)})
+			}
+		}
+		return seq, nil
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		// Let YAML resolve the scalar; enums decode their own names.
+		return &yaml.Node{Kind: yaml.ScalarNode, Value: value}, nil
+	default:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}, nil
+	}
+}
@@ -0,0 +1,101 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/semver"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvOverrides(t *testing.T) {
+	environ := []string{
+		"PATH=/usr/bin",
+		"GITSEMVER_MODE=Mainline",
+		"GITSEMVER_BRANCHES__FEATURE__TAG=alpha",
+		"GITSEMVER_BRANCHES__PULL_REQUEST__TAG_NUMBER_PATTERN=[/-](?<number>\\d+)",
+		"GITSEMVER_TAG_PREFIX=",
+		"GITSEMVER_=ignored",
+		"GO_GITSEMVER_SemVer=1.0.0",
+	}
+	require.Equal(t, []string{
+		`branches.feature.tag=alpha`,
+		`branches.pull-request.tag-number-pattern=[/-](?<number>\d+)`,
+		`mode=Mainline`,
+		`tag-prefix=`,
+	}, EnvOverrides(environ))
+}
+
+func TestParseOverrides(t *testing.T) {
+	cfg, err := ParseOverrides([]string{
+		"mode=Mainline",
+		"tag-prefix=[vV]",
+		"tag-pre-release-weight=500",
+		"branches.main.increment=Minor",
+		"branches.main.is-mainline=false",
+		"branches.main.tag=",
+		"branches.feature.source-branches=main, develop",
+		"branches.hotfix.source-branches=[main]",
+		"merge-message-formats.custom=^Merged (?P<SourceBranch>.+)$",
+		"ignore.commits-before=2024-01-01",
+		"mode=ContinuousDeployment",
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, semver.VersioningModeContinuousDeployment, *cfg.Mode)
+	require.Equal(t, "[vV]", *cfg.TagPrefix)
+	require.Equal(t, int64(500), *cfg.TagPreReleaseWeight)
+	require.Equal(t, semver.IncrementStrategyMinor, *cfg.Branches["main"].Increment)
+	require.False(t, *cfg.Branches["main"].IsMainline)
+	require.Equal(t, "", *cfg.Branches["main"].Tag)
+	require.Equal(t, []string{"main", "develop"}, *cfg.Branches["feature"].SourceBranches)
+	require.Equal(t, []string{"main"}, *cfg.Branches["hotfix"].SourceBranches)
+	require.Equal(t, "^Merged (?P<SourceBranch>.+)$", cfg.MergeMessageFormats["custom"])
+	require.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), *cfg.Ignore.CommitsBefore)
+}
+
+func TestParseOverrides_Empty(t *testing.T) {
+	cfg, err := ParseOverrides(nil)
+	require.NoError(t, err)
+	require.Nil(t, cfg)
+}
+
+func TestParseOverrides_Errors(t *testing.T) {
+	tests := []struct {
+		assignment string
+		want       string
+	}{
+		{"mode", "expected key=value"},
+		{"=Mainline", "expected key=value"},
+		{"tag-prefx=v", `unknown config key "tag-prefx"`},
+		{"branches.main.tagg=x", `unknown config key "branches.main.tagg"`},
+		{"mode.sub=x", `unknown config key "mode.sub"`},
+		{"branches.main=x", "not a single value"},
+		{"extends=base.yml", "unknown config key"},
+		{"mode=Sideways", "unknown versioning mode"},
+		{"branches.main.priority=high", "cannot unmarshal"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.assignment, func(t *testing.T) {
+			_, err := ParseOverrides([]string{tt.assignment})
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestParseOverrides_FinalLayer(t *testing.T) {
+	file := &Config{Mode: versioningModePtr(semver.VersioningModeContinuousDelivery)}
+	set, err := ParseOverrides([]string{"mode=Mainline", "branches.feature.tag=alpha"})
+	require.NoError(t, err)
+
+	cfg, sources, err := NewBuilder().AddLayer("go-gitsemver.yml", file).AddLayer("--set", set).BuildAnnotated()
+	require.NoError(t, err)
+	require.Equal(t, semver.VersioningModeMainline, *cfg.Mode)
+	require.Equal(t, "alpha", *cfg.Branches["feature"].Tag)
+	// Untouched branch fields keep their defaults.
+	require.Equal(t, `^features?[/-]`, *cfg.Branches["feature"].Regex)
+	require.Equal(t, "--set", sources["branches.feature.tag"])
+	require.Equal(t, SourceDefaults, sources["branches.feature.regex"])
+}
@@ -0,0 +1,496 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"regexp/syntax"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Severity classifies a validation Issue.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Issue is a single problem found by Validate. Line and Column are 1-based
+// and zero when the problem is not tied to a location in the file.
+type Issue struct {
+	Severity Severity `json:"severity"`
+	Line     int      `json:"line,omitempty"`
+	Column   int      `json:"column,omitempty"`
+	Path     string   `json:"path,omitempty"`
+	Message  string   `json:"message"`
+}
+
+// String formats the issue as "line:column: severity: message (path)".
+func (i Issue) String() string {
+	var sb strings.Builder
+	if i.Line > 0 {
+		fmt.Fprintf(&sb, "%d:%d: ", i.Line, i.Column)
+	}
+	fmt.Fprintf(&sb, "%s: %s", i.Severity, i.Message)
+	if i.Path != "" {
+		fmt.Fprintf(&sb, " (%s)", i.Path)
+	}
+	return sb.String()
+}
+
+// HasErrors reports whether any issue has error severity.
+func HasErrors(issues []Issue) bool {
+	for _, i := range issues {
+		if i.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks raw configuration YAML and returns every problem found,
+// ordered by line. It reports YAML syntax errors, unknown keys, values of
+// the wrong type, invalid regexes, source branches that reference undefined
+// branch configs, and branch regexes that overlap at equal priority.
+// Semantic checks see the file layered over bases, the configs it extends.
+func Validate(data []byte, bases ...*Config) []Issue {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return []Issue{syntaxIssue(err)}
+	}
+	if len(doc.Content) == 0 {
+		return nil
+	}
+
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return []Issue{{
+			Severity: SeverityError,
+			Line:     root.Line,
+			Column:   root.Column,
+			Message:  "configuration must be a mapping",
+		}}
+	}
+
+	// GitVersion 6 names are accepted with a deprecation warning, as when
+	// loading; line numbers are unaffected.
+	v := &validator{keys: make(map[string]*yaml.Node)}
+	v.issues = upgradeDocument(root, isGitVersion6(root))
+	v.walk(root, reflect.TypeOf(Config{}), "")
+
+	// Semantic checks need a decoded config. Unknown keys don't prevent
+	// decoding, so a typo doesn't hide invalid regexes or references;
+	// values that fail to decode are already reported.
+	if cfg, err := LoadFromBytes(data); err == nil {
+		v.checkSemantics(append(bases, cfg))
+	}
+
+	sort.SliceStable(v.issues, func(i, j int) bool {
+		return v.issues[i].Line < v.issues[j].Line
+	})
+	return v.issues
+}
+
+var yamlLineRe = regexp.MustCompile(`line (\d+): `)
+
+// syntaxIssue converts a yaml.v3 parse error into an Issue, extracting the
+// line number from the message.
+func syntaxIssue(err error) Issue {
+	msg := strings.TrimPrefix(err.Error(), "yaml: ")
+	issue := Issue{Severity: SeverityError}
+	if m := yamlLineRe.FindStringSubmatch(msg); m != nil {
+		issue.Line, _ = strconv.Atoi(m[1])
+		msg = strings.Replace(msg, m[0], "", 1)
+	}
+	issue.Message = "invalid YAML: " + msg
+	return issue
+}
+
+// validator accumulates issues while walking the YAML tree. keys maps each
+// dotted path to the key node that introduced it, for locating later
+// semantic issues.
+type validator struct {
+	issues []Issue
+	keys   map[string]*yaml.Node
+}
+
+func (v *validator) add(sev Severity, node *yaml.Node, path, format string, args ...any) {
+	issue := Issue{Severity: sev, Path: path, Message: fmt.Sprintf(format, args...)}
+	if node != nil {
+		issue.Line, issue.Column = node.Line, node.Column
+	}
+	v.issues = append(v.issues, issue)
+}
+
+var unmarshalerType = reflect.TypeOf((*yaml.Unmarshaler)(nil)).Elem()
+
+// walk checks node against the Go type t: mapping keys must match yaml
+// tags of struct fields, and leaf values must decode into the field type.
+func (v *validator) walk(node *yaml.Node, t reflect.Type, path string) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+
+	switch {
+	case t.Kind() == reflect.Struct && t.PkgPath() == reflect.TypeOf(Config{}).PkgPath():
+		if node.Kind != yaml.MappingNode {
+			v.add(SeverityError, node, path, "expected a mapping")
+			return
+		}
+		fields := yamlFields(t)
+		// Types with custom decoding (IgnoreConfig) only get their keys
+		// checked here; their values are decoded as a whole below.
+		custom := reflect.PointerTo(t).Implements(unmarshalerType)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			child := joinPath(path, key.Value)
+			v.keys[child] = key
+			field, ok := fields[key.Value]
+			if hint, known := unsupportedKeys[key.Value]; !ok && known {
+				v.add(SeverityWarning, key, child, "GitVersion key %q is not supported and is ignored: %s", key.Value, hint)
+				continue
+			}
+			if !ok {
+				v.add(SeverityError, key, child, "unknown key %q%s", key.Value, suggest(key.Value, fields))
+				continue
+			}
+			if !custom {
+				v.walk(value, field, child)
+			}
+		}
+		if custom {
+			v.decode(node, t, path)
+		}
+
+	case t.Kind() == reflect.Map:
+		if node.Kind != yaml.MappingNode {
+			v.add(SeverityError, node, path, "expected a mapping")
+			return
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			child := joinPath(path, key.Value)
+			v.keys[child] = key
+			v.walk(value, t.Elem(), child)
+		}
+
+	default:
+		v.decode(node, t, path)
+	}
+}
+
+// decode reports an issue when node cannot be decoded into a value of t.
+func (v *validator) decode(node *yaml.Node, t reflect.Type, path string) {
+	if err := node.Decode(reflect.New(t).Interface()); err != nil {
+		msg := strings.TrimPrefix(err.Error(), "yaml: unmarshal errors:\n")
+		msg = strings.TrimSpace(yamlLineRe.ReplaceAllString(msg, ""))
+		v.add(SeverityError, node, path, "invalid value: %s", msg)
+	}
+}
+
+// yamlFields maps yaml tag names to field types for struct type t.
+func yamlFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type, t.NumField())
+	for i := range t.NumField() {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+		if name != "" && name != "-" {
+			fields[name] = f.Type
+		}
+	}
+	return fields
+}
+
+func joinPath(parent, key string) string {
+	if parent == "" {
+		return key
+	}
+	return parent + "." + key
+}
+
+// suggest returns a "did you mean" hint for the known key closest to key,
+// or "" when none is close.
+func suggest(key string, known map[string]reflect.Type) string {
+	best, bestDist := "", 3
+	for name := range known {
+		if d := editDistance(key, name); d < bestDist || (d == bestDist && best != "" && name < best) {
+			best, bestDist = name, d
+		}
+	}
+	if best == "" {
+		return ""
+	}
+	return fmt.Sprintf(", did you mean %q?", best)
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+// checkSemantics runs checks that need the user configs merged with defaults.
+func (v *validator) checkSemantics(layers []*Config) {
+	cfg := CreateDefaultConfiguration()
+	for _, layer := range layers {
+		mergeConfig(cfg, layer)
+	}
+	finalizeBranches(cfg)
+
+	// 1. Global regexes.
+	v.checkRegex("tag-prefix", cfg.TagPrefix)
+	v.checkRegex("major-version-bump-message", cfg.MajorVersionBumpMessage)
+	v.checkRegex("minor-version-bump-message", cfg.MinorVersionBumpMessage)
+	v.checkRegex("patch-version-bump-message", cfg.PatchVersionBumpMessage)
+	v.checkRegex("no-bump-message", cfg.NoBumpMessage)
+	for _, name := range sortedKeys(cfg.MergeMessageFormats) {
+		format := cfg.MergeMessageFormats[name]
+		v.checkRegex("merge-message-formats."+name, &format)
+	}
+
+	// 2. Per-branch regexes and references to other branch configs.
+	names := sortedKeys(cfg.Branches)
+	for _, name := range names {
+		branch := cfg.Branches[name]
+		path := "branches." + name
+		if branch.Regex == nil {
+			v.add(SeverityError, v.keys[path], path, "branch %q has no regex", name)
+		} else {
+			v.checkRegex(path+".regex", branch.Regex)
+		}
+		v.checkRegex(path+".tag-number-pattern", branch.TagNumberPattern)
+		if p := branch.TagNumberPattern; p != nil {
+			if re, err := regexp.Compile(*p); err == nil && re.SubexpIndex("number") < 0 {
+				v.add(SeverityWarning, v.node(path+".tag-number-pattern"), path+".tag-number-pattern",
+					"tag-number-pattern %q has no (?<number>...) group; no number will be extracted", *p)
+			}
+		}
+		v.checkPlaceholders(path, branch)
+		v.checkReferences(cfg, path+".source-branches", branch.SourceBranches)
+		v.checkReferences(cfg, path+".is-source-branch-for", branch.IsSourceBranchFor)
+	}
+
+	// 3. Overlapping regexes at equal priority: the winner is decided only
+	// by name order, which is rarely what the author intended.
+	for i, a := range names {
+		for _, b := range names[i+1:] {
+			ba, bb := cfg.Branches[a], cfg.Branches[b]
+			if ba.Regex == nil || bb.Regex == nil || priorityOf(ba) != priorityOf(bb) {
+				continue
+			}
+			if !regexesOverlap(*ba.Regex, *bb.Regex) {
+				continue
+			}
+			// Attribute the warning to whichever branch the user wrote.
+			loc := "branches." + b
+			if v.keys[loc] == nil {
+				loc = "branches." + a
+			}
+			v.add(SeverityWarning, v.keys[loc], loc,
+				"branches %q and %q have overlapping regexes at equal priority %d; %q wins by name order",
+				a, b, priorityOf(ba), a)
+		}
+	}
+}
+
+// checkRegex reports an invalid regex at path. Nil patterns are skipped.
+func (v *validator) checkRegex(path string, pattern *string) {
+	if pattern == nil {
+		return
+	}
+	if _, err := regexp.Compile(*pattern); err != nil {
+		v.add(SeverityError, v.node(path), path, "invalid regex %q: %v", *pattern, err)
+	}
+}
+
+var placeholderRe = regexp.MustCompile(`\{(\w+)\}`)
+
+// checkPlaceholders reports placeholders in the branch tag that nothing
+// fills: not {BranchName}, {number}, or a named group of the branch regex.
+func (v *validator) checkPlaceholders(path string, branch *BranchConfig) {
+	if branch.Tag == nil {
+		return
+	}
+	known := map[string]bool{"BranchName": true, "number": true}
+	if branch.Regex != nil {
+		if re, err := regexp.Compile(*branch.Regex); err == nil {
+			for _, name := range re.SubexpNames() {
+				known[name] = true
+			}
+		}
+	}
+	for _, m := range placeholderRe.FindAllStringSubmatch(*branch.Tag, -1) {
+		if !known[m[1]] {
+			v.add(SeverityWarning, v.node(path+".tag"), path+".tag",
+				"placeholder %s is not a named group of the branch regex", m[0])
+		}
+	}
+}
+
+// checkReferences reports branch names in refs that have no branch config.
+func (v *validator) checkReferences(cfg *Config, path string, refs *[]string) {
+	if refs == nil {
+		return
+	}
+	for _, ref := range *refs {
+		if _, ok := cfg.Branches[ref]; !ok {
+			v.add(SeverityError, v.node(path), path, "references undefined branch %q", ref)
+		}
+	}
+}
+
+// node returns the key node for path, or for its nearest ancestor that
+// appears in the file.
+func (v *validator) node(path string) *yaml.Node {
+	for path != "" {
+		if n, ok := v.keys[path]; ok {
+			return n
+		}
+		idx := strings.LastIndexByte(path, '.')
+		if idx < 0 {
+			break
+		}
+		path = path[:idx]
+	}
+	return nil
+}
+
+func priorityOf(bc *BranchConfig) int {
+	if bc.Priority == nil {
+		return 0
+	}
+	return *bc.Priority
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// regexesOverlap reports whether some branch name is likely matched by both
+// a and b. It generates sample strings from each pattern and tries them
+// against the other, so it can miss overlaps but does not invent them.
+func regexesOverlap(a, b string) bool {
+	reA, errA := regexp.Compile(a)
+	reB, errB := regexp.Compile(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+	for _, s := range regexSamples(a) {
+		if reB.MatchString(s) {
+			return true
+		}
+	}
+	for _, s := range regexSamples(b) {
+		if reA.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// maxSamples bounds the sample set generated for one regex.
+const maxSamples = 32
+
+// regexSamples returns strings matched by pattern, one per alternative
+// where possible.
+func regexSamples(pattern string) []string {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return nil
+	}
+	re = re.Simplify()
+	var out []string
+	for _, s := range samplesOf(re) {
+		if m, _ := regexp.MatchString(pattern, s); m {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func samplesOf(re *syntax.Regexp) []string {
+	switch re.Op {
+	case syntax.OpLiteral:
+		return []string{string(re.Rune)}
+	case syntax.OpCharClass:
+		if len(re.Rune) == 0 {
+			return nil
+		}
+		return []string{string(re.Rune[0])}
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		return []string{"x"}
+	case syntax.OpCapture, syntax.OpPlus:
+		return samplesOf(re.Sub[0])
+	case syntax.OpStar, syntax.OpQuest:
+		return capSamples(append([]string{""}, samplesOf(re.Sub[0])...))
+	case syntax.OpRepeat:
+		sub := samplesOf(re.Sub[0])
+		if re.Min == 0 {
+			return capSamples(append([]string{""}, sub...))
+		}
+		out := make([]string, 0, len(sub))
+		for _, s := range sub {
+			out = append(out, strings.Repeat(s, re.Min))
+		}
+		return out
+	case syntax.OpConcat:
+		out := []string{""}
+		for _, sub := range re.Sub {
+			subSamples := samplesOf(sub)
+			var next []string
+			for _, prefix := range out {
+				for _, s := range subSamples {
+					next = append(next, prefix+s)
+				}
+			}
+			out = capSamples(next)
+		}
+		return out
+	case syntax.OpAlternate:
+		var out []string
+		for _, sub := range re.Sub {
+			out = append(out, samplesOf(sub)...)
+		}
+		return capSamples(out)
+	case syntax.OpNoMatch:
+		return nil
+	default:
+		// Anchors, word boundaries and empty matches consume nothing.
+		return []string{""}
+	}
+}
+
+func capSamples(ss []string) []string {
+	if len(ss) > maxSamples {
+		return ss[:maxSamples]
+	}
+	return ss
+}
@@ -0,0 +1,277 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate_Valid(t *testing.T) {
+	data := []byte(`
+mode: Mainline
+tag-prefix: '[vV]'
+branches:
+  main:
+    regex: ^main$
+    increment: Patch
+  docs:
+    regex: ^docs/
+    source-branches: [main]
+    priority: 10
+ignore:
+  sha: [abc123]
+  commits-before: 2024-01-01
+merge-message-formats:
+  custom: '^Merged (?P<SourceBranch>.+)$'
+`)
+	require.Empty(t, Validate(data))
+}
+
+func TestValidate_Empty(t *testing.T) {
+	require.Empty(t, Validate(nil))
+}
+
+func TestValidate_SyntaxError(t *testing.T) {
+	issues := Validate([]byte("mode: Mainline\nbranches:\n  main:\n    regex: [unclosed\n"))
+	require.Len(t, issues, 1)
+	require.Equal(t, SeverityError, issues[0].Severity)
+	require.Positive(t, issues[0].Line)
+	require.Contains(t, issues[0].Message, "invalid YAML")
+}
+
+func TestValidate_NotAMapping(t *testing.T) {
+	issues := Validate([]byte("- a\n- b\n"))
+	require.Len(t, issues, 1)
+	require.Contains(t, issues[0].Message, "must be a mapping")
+}
+
+func TestValidate_UnknownKeys(t *testing.T) {
+	data := []byte(`mode: Mainline
+tag-prefx: v
+branches:
+  feature:
+    tagg: beta
+ignore:
+  shas: [abc]
+`)
+	issues := Validate(data)
+	require.Len(t, issues, 3)
+
+	require.Equal(t, 2, issues[0].Line)
+	require.Equal(t, "tag-prefx", issues[0].Path)
+	require.Contains(t, issues[0].Message, `unknown key "tag-prefx", did you mean "tag-prefix"?`)
+
+	require.Equal(t, 5, issues[1].Line)
+	require.Equal(t, "branches.feature.tagg", issues[1].Path)
+	require.Contains(t, issues[1].Message, `did you mean "tag"?`)
+
+	require.Equal(t, 7, issues[2].Line)
+	require.Equal(t, "ignore.shas", issues[2].Path)
+}
+
+func TestValidate_UnknownKeysDoNotHideSemanticIssues(t *testing.T) {
+	data := []byte(`tag-prefx: v
+branches:
+  docs:
+    regex: '^docs/(('
+    source-branches: [mian]
+`)
+	issues := Validate(data)
+
+	var paths []string
+	for _, i := range issues {
+		paths = append(paths, i.Path)
+	}
+	require.Equal(t, []string{
+		"tag-prefx",
+		"branches.docs.regex",
+		"branches.docs.source-branches",
+	}, paths)
+}
+
+func TestValidate_InvalidValues(t *testing.T) {
+	data := []byte(`mode: Sideways
+tag-pre-release-weight: heavy
+branches:
+  main:
+    is-mainline: maybe
+`)
+	issues := Validate(data)
+	require.Len(t, issues, 3)
+	require.Equal(t, "mode", issues[0].Path)
+	require.Equal(t, 1, issues[0].Line)
+	require.Equal(t, "tag-pre-release-weight", issues[1].Path)
+	require.Equal(t, "branches.main.is-mainline", issues[2].Path)
+	require.Equal(t, 5, issues[2].Line)
+}
+
+func TestValidate_InvalidRegexes(t *testing.T) {
+	data := []byte(`tag-prefix: '[v'
+major-version-bump-message: '(breaking'
+branches:
+  feature:
+    regex: '^feature/(('
+merge-message-formats:
+  custom: '*bad'
+`)
+	issues := Validate(data)
+
+	var paths []string
+	for _, i := range issues {
+		require.Equal(t, SeverityError, i.Severity)
+		require.Contains(t, i.Message, "invalid regex")
+		paths = append(paths, i.Path)
+	}
+	require.Equal(t, []string{
+		"tag-prefix",
+		"major-version-bump-message",
+		"branches.feature.regex",
+		"merge-message-formats.custom",
+	}, paths)
+	require.Equal(t, 5, issues[2].Line)
+}
+
+func TestValidate_UndefinedSourceBranches(t *testing.T) {
+	data := []byte(`branches:
+  docs:
+    regex: ^docs/
+    priority: 10
+    source-branches: [main, mian]
+    is-source-branch-for: [nope]
+`)
+	issues := Validate(data)
+	require.Len(t, issues, 2)
+	require.Equal(t, "branches.docs.source-branches", issues[0].Path)
+	require.Equal(t, 5, issues[0].Line)
+	require.Contains(t, issues[0].Message, `undefined branch "mian"`)
+	require.Equal(t, "branches.docs.is-source-branch-for", issues[1].Path)
+}
+
+func TestValidate_MissingBranchRegex(t *testing.T) {
+	issues := Validate([]byte("branches:\n  docs:\n    priority: 10\n"))
+	require.Len(t, issues, 1)
+	require.Equal(t, "branches.docs", issues[0].Path)
+	require.Equal(t, 2, issues[0].Line)
+	require.Contains(t, issues[0].Message, "has no regex")
+}
+
+func TestValidate_ShadowingRegexes(t *testing.T) {
+	data := []byte(`branches:
+  story:
+    regex: ^features?/story-
+    priority: 50
+`)
+	issues := Validate(data)
+	require.Len(t, issues, 1)
+	require.Equal(t, SeverityWarning, issues[0].Severity)
+	require.Equal(t, "branches.story", issues[0].Path)
+	require.Contains(t, issues[0].Message, `"feature" and "story"`)
+	require.Contains(t, issues[0].Message, "equal priority 50")
+	require.False(t, HasErrors(issues))
+}
+
+func TestValidate_NoPriorityTiesWithUnknown(t *testing.T) {
+	issues := Validate([]byte("branches:\n  docs:\n    regex: ^docs/\n"))
+	require.Len(t, issues, 1)
+	require.Equal(t, SeverityWarning, issues[0].Severity)
+	require.Contains(t, issues[0].Message, `"docs" and "unknown"`)
+}
+
+func TestRegexesOverlap(t *testing.T) {
+	require.True(t, regexesOverlap(`^features?[/-]`, `^feature/`))
+	require.True(t, regexesOverlap(`^master$|^main$`, `^main$`))
+	require.True(t, regexesOverlap(`.*`, `^docs/`))
+	require.False(t, regexesOverlap(`^releases?[/-]`, `^hotfix(es)?[/-]`))
+	require.False(t, regexesOverlap(`^main$`, `^develop$`))
+}
+
+func TestIssue_String(t *testing.T) {
+	i := Issue{Severity: SeverityError, Line: 3, Column: 5, Path: "mode", Message: "bad"}
+	require.Equal(t, "3:5: error: bad (mode)", i.String())
+	require.Equal(t, "warning: w", Issue{Severity: SeverityWarning, Message: "w"}.String())
+}
+
+// The published JSON schema must list exactly the keys the validator accepts.
+func TestValidate_SchemaMatchesConfig(t *testing.T) {
+	data, err := os.ReadFile("../../go-gitsemver-schema.json")
+	require.NoError(t, err)
+
+	var schema struct {
+		Properties map[string]json.RawMessage `json:"properties"`
+		Defs       map[string]struct {
+			Properties map[string]json.RawMessage `json:"properties"`
+		} `json:"$defs"`
+	}
+	require.NoError(t, json.Unmarshal(data, &schema))
+
+	require.Equal(t, fieldNames(reflect.TypeOf(Config{})), sortedKeys(schema.Properties))
+	// GitVersion 6 aliases are accepted by the loader and listed as
+	// deprecated properties alongside the go-gitsemver names.
+	branchProps := schema.Defs["branchConfig"].Properties
+	aliases := []string{"prevent-increment"}
+	for from := range gitVersion6Renames {
+		aliases = append(aliases, from)
+	}
+	sort.Strings(aliases)
+	var deprecated []string
+	for name, raw := range branchProps {
+		var prop struct {
+			Deprecated bool `json:"deprecated"`
+		}
+		require.NoError(t, json.Unmarshal(raw, &prop))
+		if prop.Deprecated {
+			deprecated = append(deprecated, name)
+			delete(branchProps, name)
+		}
+	}
+	sort.Strings(deprecated)
+	require.Equal(t, aliases, deprecated)
+	require.Equal(t, fieldNames(reflect.TypeOf(BranchConfig{})), sortedKeys(branchProps))
+	require.Equal(t, fieldNames(reflect.TypeOf(IgnoreConfig{})), sortedKeys(schema.Defs["ignoreConfig"].Properties))
+}
+
+func fieldNames(t reflect.Type) []string {
+	var names []string
+	for name := range yamlFields(t) {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestValidate_SourceBranchesDefinedInBase(t *testing.T) {
+	base := &Config{Branches: map[string]*BranchConfig{
+		"staging": {Regex: stringPtr(`^staging$`), Priority: intPtr(95)},
+	}}
+	data := []byte("extends: org.yml\nbranches:\n  release:\n    source-branches: [staging]\n")
+	require.NotEmpty(t, Validate(data))
+	require.Empty(t, Validate(data, base))
+}
+
+func TestValidate_TagNumberPatternWithoutGroup(t *testing.T) {
+	issues := Validate([]byte("branches:\n  pull-request:\n    tag-number-pattern: '[/-](\\d+)'\n"))
+	require.Len(t, issues, 1)
+	require.Equal(t, SeverityWarning, issues[0].Severity)
+	require.Equal(t, "branches.pull-request.tag-number-pattern", issues[0].Path)
+	require.Contains(t, issues[0].Message, "no (?<number>...) group")
+}
+
+func TestValidate_TagPlaceholders(t *testing.T) {
+	data := []byte(`branches:
+  feature:
+    regex: ^feature/(?P<ticket>[A-Z]+-\d+)
+    tag: '{ticket}-{BranchName}'
+  hotfix:
+    tag: '{tikcet}'
+`)
+	issues := Validate(data)
+	require.Len(t, issues, 1)
+	require.Equal(t, SeverityWarning, issues[0].Severity)
+	require.Equal(t, "branches.hotfix.tag", issues[0].Path)
+	require.Equal(t, 6, issues[0].Line)
+	require.Contains(t, issues[0].Message, "placeholder {tikcet}")
+}
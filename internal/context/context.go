@@ -34,9 +34,5 @@ type GitVersionContext struct {
 // GetEffectiveConfiguration resolves the effective configuration for the
 // given branch, using the context's full configuration.
 func (ctx *GitVersionContext) GetEffectiveConfiguration(branchName string) (config.EffectiveConfiguration, error) {
-	bc, _, err := ctx.FullConfiguration.GetBranchConfiguration(branchName)
-	if err != nil {
-		return config.EffectiveConfiguration{}, err
-	}
-	return config.NewEffectiveConfiguration(ctx.FullConfiguration, bc), nil
+	return ctx.FullConfiguration.GetEffectiveConfiguration(branchName)
 }
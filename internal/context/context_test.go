@@ -28,6 +28,7 @@ func TestGetEffectiveConfiguration_Release(t *testing.T) {
 
 	ec, err := ctx.GetEffectiveConfiguration("release/1.0")
 	require.NoError(t, err)
+	require.Equal(t, "release", ec.BranchConfigName)
 	require.True(t, ec.IsReleaseBranch)
 	require.False(t, ec.IsMainline)
 }
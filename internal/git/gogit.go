@@ -1,6 +1,7 @@
 package git
 
 import (
+	"context"
 	"fmt"
 	"path/filepath"
 	"time"
@@ -60,7 +61,10 @@ func (r *GoGitRepository) IsHeadDetached() bool {
 	return !ref.Name().IsBranch()
 }
 
-func (r *GoGitRepository) Head() (Branch, error) {
+func (r *GoGitRepository) Head(ctx context.Context) (Branch, error) {
+	if err := ctx.Err(); err != nil {
+		return Branch{}, err
+	}
 	ref, err := r.repo.Head()
 	if err != nil {
 		return Branch{}, fmt.Errorf("getting HEAD: %w", err)
@@ -82,7 +86,7 @@ func (r *GoGitRepository) Head() (Branch, error) {
 	}, nil
 }
 
-func (r *GoGitRepository) Branches(_ ...PathFilter) ([]Branch, error) {
+func (r *GoGitRepository) Branches(ctx context.Context, _ ...PathFilter) ([]Branch, error) {
 	var branches []Branch
 
 	// Local branches.
@@ -91,6 +95,9 @@ func (r *GoGitRepository) Branches(_ ...PathFilter) ([]Branch, error) {
 		return nil, fmt.Errorf("listing local branches: %w", err)
 	}
 	err = localIter.ForEach(func(ref *plumbing.Reference) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		commit, err := r.commitFromHash(ref.Hash())
 		if err != nil {
 			return nil // skip branches we can't resolve
@@ -112,6 +119,9 @@ func (r *GoGitRepository) Branches(_ ...PathFilter) ([]Branch, error) {
 		return nil, fmt.Errorf("listing references: %w", err)
 	}
 	err = refIter.ForEach(func(ref *plumbing.Reference) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		if !ref.Name().IsRemote() {
 			return nil
 		}
@@ -133,9 +143,12 @@ func (r *GoGitRepository) Branches(_ ...PathFilter) ([]Branch, error) {
 	return branches, nil
 }
 
-func (r *GoGitRepository) Tags(_ ...PathFilter) ([]Tag, error) {
-	var tags []Tag
+func (r *GoGitRepository) Tags(ctx context.Context, _ ...PathFilter) ([]Tag, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 
+	var tags []Tag
 	iter, err := r.repo.Tags()
 	if err != nil {
 		return nil, fmt.Errorf("listing tags: %w", err)
@@ -155,12 +168,24 @@ func (r *GoGitRepository) Tags(_ ...PathFilter) ([]Tag, error) {
 	return tags, nil
 }
 
-func (r *GoGitRepository) CommitFromSha(sha string) (Commit, error) {
-	hash := plumbing.NewHash(sha)
-	return r.commitFromHash(hash)
+func (r *GoGitRepository) CommitFromSha(ctx context.Context, sha string) (Commit, error) {
+	if err := ctx.Err(); err != nil {
+		return Commit{}, err
+	}
+	return r.commitFromHash(plumbing.NewHash(sha))
+}
+
+// ResolveRevision returns the commit SHA a revision such as a branch, tag,
+// "HEAD~2" or abbreviated SHA refers to.
+func (r *GoGitRepository) ResolveRevision(rev string) (string, error) {
+	hash, err := r.repo.ResolveRevision(plumbing.Revision(rev))
+	if err != nil {
+		return "", fmt.Errorf("resolving revision %q: %w", rev, err)
+	}
+	return hash.String(), nil
 }
 
-func (r *GoGitRepository) CommitLog(from, to string, _ ...PathFilter) ([]Commit, error) {
+func (r *GoGitRepository) CommitLog(ctx context.Context, from, to string, _ ...PathFilter) ([]Commit, error) {
 	toHash := plumbing.NewHash(to)
 
 	iter, err := r.repo.Log(&gogit.LogOptions{
@@ -178,6 +203,9 @@ func (r *GoGitRepository) CommitLog(from, to string, _ ...PathFilter) ([]Commit,
 
 	var commits []Commit
 	err = iter.ForEach(func(c *object.Commit) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		if c.Hash == fromHash {
 			return storer.ErrStop
 		}
@@ -191,7 +219,7 @@ func (r *GoGitRepository) CommitLog(from, to string, _ ...PathFilter) ([]Commit,
 	return commits, nil
 }
 
-func (r *GoGitRepository) MainlineCommitLog(from, to string, _ ...PathFilter) ([]Commit, error) {
+func (r *GoGitRepository) MainlineCommitLog(ctx context.Context, from, to string, _ ...PathFilter) ([]Commit, error) {
 	toHash := plumbing.NewHash(to)
 
 	current, err := r.repo.CommitObject(toHash)
@@ -208,6 +236,9 @@ func (r *GoGitRepository) MainlineCommitLog(from, to string, _ ...PathFilter) ([
 	// This skips commits introduced via merge side branches.
 	var commits []Commit
 	for current.Hash != fromHash {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		commits = append(commits, convertCommit(current))
 		if current.NumParents() == 0 {
 			break
@@ -222,16 +253,16 @@ func (r *GoGitRepository) MainlineCommitLog(from, to string, _ ...PathFilter) ([
 	return commits, nil
 }
 
-func (r *GoGitRepository) BranchCommits(branch Branch, _ ...PathFilter) ([]Commit, error) {
+func (r *GoGitRepository) BranchCommits(ctx context.Context, branch Branch, _ ...PathFilter) ([]Commit, error) {
 	if branch.Tip == nil {
 		return nil, nil
 	}
 
-	return r.CommitLog("", branch.Tip.Sha)
+	return r.CommitLog(ctx, "", branch.Tip.Sha)
 }
 
-func (r *GoGitRepository) CommitsPriorTo(olderThan time.Time, branch Branch) ([]Commit, error) {
-	allCommits, err := r.BranchCommits(branch)
+func (r *GoGitRepository) CommitsPriorTo(ctx context.Context, olderThan time.Time, branch Branch) ([]Commit, error) {
+	allCommits, err := r.BranchCommits(ctx, branch)
 	if err != nil {
 		return nil, err
 	}
@@ -246,7 +277,11 @@ func (r *GoGitRepository) CommitsPriorTo(olderThan time.Time, branch Branch) ([]
 	return result, nil
 }
 
-func (r *GoGitRepository) FindMergeBase(sha1, sha2 string) (string, error) {
+func (r *GoGitRepository) FindMergeBase(ctx context.Context, sha1, sha2 string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
 	hash1 := plumbing.NewHash(sha1)
 	hash2 := plumbing.NewHash(sha2)
 
@@ -272,15 +307,18 @@ func (r *GoGitRepository) FindMergeBase(sha1, sha2 string) (string, error) {
 	return bases[0].Hash.String(), nil
 }
 
-func (r *GoGitRepository) BranchesContainingCommit(sha string) ([]Branch, error) {
+func (r *GoGitRepository) BranchesContainingCommit(ctx context.Context, sha string) ([]Branch, error) {
 	targetHash := plumbing.NewHash(sha)
-	allBranches, err := r.Branches()
+	allBranches, err := r.Branches(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	var result []Branch
 	for _, b := range allBranches {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		if b.Tip == nil {
 			continue
 		}
@@ -314,7 +352,11 @@ func (r *GoGitRepository) BranchesContainingCommit(sha string) ([]Branch, error)
 	return result, nil
 }
 
-func (r *GoGitRepository) NumberOfUncommittedChanges() (int, error) {
+func (r *GoGitRepository) NumberOfUncommittedChanges(ctx context.Context) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
 	wt, err := r.repo.Worktree()
 	if err != nil {
 		return 0, fmt.Errorf("getting worktree: %w", err)
@@ -335,7 +377,11 @@ func (r *GoGitRepository) NumberOfUncommittedChanges() (int, error) {
 	return count, nil
 }
 
-func (r *GoGitRepository) PeelTagToCommit(tag Tag) (string, error) {
+func (r *GoGitRepository) PeelTagToCommit(ctx context.Context, tag Tag) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
 	hash := plumbing.NewHash(tag.TargetSha)
 
 	// Try as an annotated tag first.
@@ -358,6 +404,19 @@ func (r *GoGitRepository) PeelTagToCommit(tag Tag) (string, error) {
 	return tag.TargetSha, nil
 }
 
+// RemoteURL returns the first configured URL of the named remote.
+func (r *GoGitRepository) RemoteURL(name string) (string, error) {
+	remote, err := r.repo.Remote(name)
+	if err != nil {
+		return "", fmt.Errorf("getting remote %s: %w", name, err)
+	}
+	urls := remote.Config().URLs
+	if len(urls) == 0 {
+		return "", fmt.Errorf("remote %s has no URL", name)
+	}
+	return urls[0], nil
+}
+
 // commitFromHash loads a go-git commit and converts it to our Commit type.
 func (r *GoGitRepository) commitFromHash(hash plumbing.Hash) (Commit, error) {
 	c, err := r.repo.CommitObject(hash)
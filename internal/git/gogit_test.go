@@ -1,6 +1,7 @@
 package git
 
 import (
+	"context"
 	"os"
 	"testing"
 	"time"
@@ -50,7 +51,7 @@ func TestOpen_Head(t *testing.T) {
 	repo, err := Open(dir)
 	require.NoError(t, err)
 
-	head, err := repo.Head()
+	head, err := repo.Head(context.Background())
 	require.NoError(t, err)
 	require.NotEmpty(t, head.Name.Friendly)
 	require.NotNil(t, head.Tip)
@@ -64,7 +65,7 @@ func TestOpen_Tags(t *testing.T) {
 	repo, err := Open(dir)
 	require.NoError(t, err)
 
-	tags, err := repo.Tags()
+	tags, err := repo.Tags(context.Background())
 	require.NoError(t, err)
 	// Tags may or may not exist; just verify no error.
 	_ = tags
@@ -77,7 +78,7 @@ func TestOpen_Branches(t *testing.T) {
 	repo, err := Open(dir)
 	require.NoError(t, err)
 
-	branches, err := repo.Branches()
+	branches, err := repo.Branches(context.Background())
 	require.NoError(t, err)
 	require.NotEmpty(t, branches, "expected at least one branch")
 }
@@ -89,7 +90,7 @@ func TestOpen_NumberOfUncommittedChanges(t *testing.T) {
 	repo, err := Open(dir)
 	require.NoError(t, err)
 
-	_, err = repo.NumberOfUncommittedChanges()
+	_, err = repo.NumberOfUncommittedChanges(context.Background())
 	require.NoError(t, err)
 }
 
@@ -100,10 +101,10 @@ func TestOpen_CommitFromSha(t *testing.T) {
 	repo, err := Open(dir)
 	require.NoError(t, err)
 
-	head, err := repo.Head()
+	head, err := repo.Head(context.Background())
 	require.NoError(t, err)
 
-	commit, err := repo.CommitFromSha(head.Tip.Sha)
+	commit, err := repo.CommitFromSha(context.Background(), head.Tip.Sha)
 	require.NoError(t, err)
 	require.Equal(t, head.Tip.Sha, commit.Sha)
 	require.NotEmpty(t, commit.Message)
@@ -117,7 +118,7 @@ func TestOpen_CommitFromSha_Invalid(t *testing.T) {
 	repo, err := Open(dir)
 	require.NoError(t, err)
 
-	_, err = repo.CommitFromSha("0000000000000000000000000000000000000000")
+	_, err = repo.CommitFromSha(context.Background(), "0000000000000000000000000000000000000000")
 	require.Error(t, err)
 }
 
@@ -128,16 +129,34 @@ func TestOpen_CommitLog(t *testing.T) {
 	repo, err := Open(dir)
 	require.NoError(t, err)
 
-	head, err := repo.Head()
+	head, err := repo.Head(context.Background())
 	require.NoError(t, err)
 
 	// Get all commits from HEAD (no "from" bound).
-	commits, err := repo.CommitLog("", head.Tip.Sha)
+	commits, err := repo.CommitLog(context.Background(), "", head.Tip.Sha)
 	require.NoError(t, err)
 	require.NotEmpty(t, commits)
 	require.Equal(t, head.Tip.Sha, commits[0].Sha)
 }
 
+func TestOpen_CommitLog_Cancelled(t *testing.T) {
+	dir, err := os.Getwd()
+	require.NoError(t, err)
+
+	repo, err := Open(dir)
+	require.NoError(t, err)
+
+	head, err := repo.Head(context.Background())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = repo.CommitLog(ctx, "", head.Tip.Sha)
+	require.ErrorIs(t, err, context.Canceled)
+	_, err = repo.MainlineCommitLog(ctx, "", head.Tip.Sha)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
 func TestOpen_MainlineCommitLog(t *testing.T) {
 	dir, err := os.Getwd()
 	require.NoError(t, err)
@@ -145,10 +164,10 @@ func TestOpen_MainlineCommitLog(t *testing.T) {
 	repo, err := Open(dir)
 	require.NoError(t, err)
 
-	head, err := repo.Head()
+	head, err := repo.Head(context.Background())
 	require.NoError(t, err)
 
-	commits, err := repo.MainlineCommitLog("", head.Tip.Sha)
+	commits, err := repo.MainlineCommitLog(context.Background(), "", head.Tip.Sha)
 	require.NoError(t, err)
 	require.NotEmpty(t, commits)
 }
@@ -160,11 +179,11 @@ func TestOpen_BranchCommits(t *testing.T) {
 	repo, err := Open(dir)
 	require.NoError(t, err)
 
-	head, err := repo.Head()
+	head, err := repo.Head(context.Background())
 	require.NoError(t, err)
 
 	branch := Branch{Name: head.Name, Tip: head.Tip}
-	commits, err := repo.BranchCommits(branch)
+	commits, err := repo.BranchCommits(context.Background(), branch)
 	require.NoError(t, err)
 	require.NotEmpty(t, commits)
 }
@@ -177,7 +196,7 @@ func TestOpen_BranchCommits_NilTip(t *testing.T) {
 	require.NoError(t, err)
 
 	branch := Branch{Name: NewReferenceName("refs/heads/test")}
-	commits, err := repo.BranchCommits(branch)
+	commits, err := repo.BranchCommits(context.Background(), branch)
 	require.NoError(t, err)
 	require.Empty(t, commits)
 }
@@ -189,20 +208,20 @@ func TestOpen_CommitsPriorTo(t *testing.T) {
 	repo, err := Open(dir)
 	require.NoError(t, err)
 
-	head, err := repo.Head()
+	head, err := repo.Head(context.Background())
 	require.NoError(t, err)
 
 	branch := Branch{Name: head.Name, Tip: head.Tip}
 
 	// Use a time far in the past — should return no commits.
 	ancient := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
-	commits, err := repo.CommitsPriorTo(ancient, branch)
+	commits, err := repo.CommitsPriorTo(context.Background(), ancient, branch)
 	require.NoError(t, err)
 	require.Empty(t, commits)
 
 	// Use a time in the future — should return all commits.
 	future := time.Now().Add(24 * time.Hour)
-	commits, err = repo.CommitsPriorTo(future, branch)
+	commits, err = repo.CommitsPriorTo(context.Background(), future, branch)
 	require.NoError(t, err)
 	require.NotEmpty(t, commits)
 }
@@ -214,11 +233,11 @@ func TestOpen_FindMergeBase(t *testing.T) {
 	repo, err := Open(dir)
 	require.NoError(t, err)
 
-	head, err := repo.Head()
+	head, err := repo.Head(context.Background())
 	require.NoError(t, err)
 
 	// Merge base of a commit with itself is itself.
-	base, err := repo.FindMergeBase(head.Tip.Sha, head.Tip.Sha)
+	base, err := repo.FindMergeBase(context.Background(), head.Tip.Sha, head.Tip.Sha)
 	require.NoError(t, err)
 	require.Equal(t, head.Tip.Sha, base)
 }
@@ -230,10 +249,10 @@ func TestOpen_BranchesContainingCommit(t *testing.T) {
 	repo, err := Open(dir)
 	require.NoError(t, err)
 
-	head, err := repo.Head()
+	head, err := repo.Head(context.Background())
 	require.NoError(t, err)
 
-	branches, err := repo.BranchesContainingCommit(head.Tip.Sha)
+	branches, err := repo.BranchesContainingCommit(context.Background(), head.Tip.Sha)
 	require.NoError(t, err)
 	require.NotEmpty(t, branches, "HEAD commit should be on at least one branch")
 }
@@ -245,7 +264,7 @@ func TestOpen_PeelTagToCommit(t *testing.T) {
 	repo, err := Open(dir)
 	require.NoError(t, err)
 
-	tags, err := repo.Tags()
+	tags, err := repo.Tags(context.Background())
 	require.NoError(t, err)
 
 	if len(tags) == 0 {
@@ -253,7 +272,7 @@ func TestOpen_PeelTagToCommit(t *testing.T) {
 	}
 
 	// Peel the first tag to a commit SHA.
-	sha, err := repo.PeelTagToCommit(tags[0])
+	sha, err := repo.PeelTagToCommit(context.Background(), tags[0])
 	require.NoError(t, err)
 	require.NotEmpty(t, sha)
 	require.Len(t, sha, 40, "expected full SHA")
@@ -1,11 +1,15 @@
 package git
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
 // Repository provides low-level git operations.
 // This is the key abstraction point for testing and backend swapping.
 // All methods that traverse commits or list refs accept optional PathFilter
-// parameters for monorepo support.
+// parameters for monorepo support. Methods that read from the repository
+// take a context so callers can set deadlines and cancel long walks.
 type Repository interface {
 	// Path returns the path to the .git directory.
 	Path() string
@@ -17,48 +21,56 @@ type Repository interface {
 	IsHeadDetached() bool
 
 	// Head returns the current HEAD branch.
-	Head() (Branch, error)
+	Head(ctx context.Context) (Branch, error)
 
 	// Branches returns all branches in the repository.
-	Branches(filters ...PathFilter) ([]Branch, error)
+	Branches(ctx context.Context, filters ...PathFilter) ([]Branch, error)
 
 	// Tags returns all tags in the repository.
-	Tags(filters ...PathFilter) ([]Tag, error)
+	Tags(ctx context.Context, filters ...PathFilter) ([]Tag, error)
 
 	// CommitFromSha returns the commit with the given SHA.
-	CommitFromSha(sha string) (Commit, error)
+	CommitFromSha(ctx context.Context, sha string) (Commit, error)
 
 	// CommitLog returns commits reachable from 'to' but not from 'from',
 	// in reverse chronological order. If from is empty, all ancestors of
 	// 'to' are returned.
-	CommitLog(from, to string, filters ...PathFilter) ([]Commit, error)
+	CommitLog(ctx context.Context, from, to string, filters ...PathFilter) ([]Commit, error)
 
 	// MainlineCommitLog returns first-parent-only commits reachable from
 	// 'to' but not from 'from'. Used for mainline mode calculations.
-	MainlineCommitLog(from, to string, filters ...PathFilter) ([]Commit, error)
+	MainlineCommitLog(ctx context.Context, from, to string, filters ...PathFilter) ([]Commit, error)
 
 	// BranchCommits returns commits on a specific branch in reverse
 	// chronological order.
-	BranchCommits(branch Branch, filters ...PathFilter) ([]Commit, error)
+	BranchCommits(ctx context.Context, branch Branch, filters ...PathFilter) ([]Commit, error)
 
 	// CommitsPriorTo returns branch commits whose date is older than the
 	// given time.
-	CommitsPriorTo(olderThan time.Time, branch Branch) ([]Commit, error)
+	CommitsPriorTo(ctx context.Context, olderThan time.Time, branch Branch) ([]Commit, error)
 
 	// FindMergeBase returns the best common ancestor of two commits.
 	// Returns an empty string if no merge base exists.
-	FindMergeBase(sha1, sha2 string) (string, error)
+	FindMergeBase(ctx context.Context, sha1, sha2 string) (string, error)
 
 	// BranchesContainingCommit returns all branches that contain the
 	// given commit SHA.
-	BranchesContainingCommit(sha string) ([]Branch, error)
+	BranchesContainingCommit(ctx context.Context, sha string) ([]Branch, error)
 
 	// NumberOfUncommittedChanges returns the count of uncommitted changes
 	// in the working directory.
-	NumberOfUncommittedChanges() (int, error)
+	NumberOfUncommittedChanges(ctx context.Context) (int, error)
 
 	// PeelTagToCommit resolves a tag to its target commit SHA.
 	// For lightweight tags, returns the target directly.
 	// For annotated tags, peels through to the commit.
-	PeelTagToCommit(tag Tag) (string, error)
+	PeelTagToCommit(ctx context.Context, tag Tag) (string, error)
+}
+
+// Prefetcher is implemented by backends that can warm their caches in bulk
+// before calculation (e.g. concurrently over a network API).
+type Prefetcher interface {
+	// Prefetch loads tag peels and the merge bases between head and each
+	// of branches into the backend's cache.
+	Prefetch(ctx context.Context, head Commit, branches []Branch) error
 }
@@ -0,0 +1,131 @@
+package git
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+// storeMemo caches the Repository queries a RepositoryStore repeats when it
+// is shared across calculations, such as one per commit of a branch. The
+// cached results depend only on history and refs, which must not change
+// while the store is in use.
+type storeMemo struct {
+	mu          sync.Mutex
+	branches    []Branch
+	hasBranches bool
+	versionTags map[string][]VersionTag // keyed by tag prefix and filters
+	commitLogs  map[string][]Commit     // keyed by from and to
+	mergeBases  map[string]string       // keyed by both SHAs
+}
+
+// NewMemoizedRepositoryStore creates a RepositoryStore that caches branches,
+// version tags, commit logs and merge bases. Use it to run many
+// calculations against a repository that does not change in between.
+func NewMemoizedRepositoryStore(repo Repository) *RepositoryStore {
+	return &RepositoryStore{
+		repo: repo,
+		ctx:  context.Background(),
+		memo: &storeMemo{
+			versionTags: make(map[string][]VersionTag),
+			commitLogs:  make(map[string][]Commit),
+			mergeBases:  make(map[string]string),
+		},
+	}
+}
+
+// branches returns Repository.Branches, cached when memoizing.
+func (s *RepositoryStore) branches() ([]Branch, error) {
+	if s.memo == nil {
+		return s.repo.Branches(s.ctx)
+	}
+	s.memo.mu.Lock()
+	defer s.memo.mu.Unlock()
+	if !s.memo.hasBranches {
+		branches, err := s.repo.Branches(s.ctx)
+		if err != nil {
+			return nil, err
+		}
+		s.memo.branches, s.memo.hasBranches = branches, true
+	}
+	return s.memo.branches, nil
+}
+
+// commitLog returns Repository.CommitLog, cached when memoizing.
+func (s *RepositoryStore) commitLog(from, to string) ([]Commit, error) {
+	if s.memo == nil {
+		return s.repo.CommitLog(s.ctx, from, to)
+	}
+	key := from + ".." + to
+	s.memo.mu.Lock()
+	commits, ok := s.memo.commitLogs[key]
+	s.memo.mu.Unlock()
+	if ok {
+		return commits, nil
+	}
+
+	commits, err := s.repo.CommitLog(s.ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	s.memo.mu.Lock()
+	s.memo.commitLogs[key] = commits
+	s.memo.mu.Unlock()
+	return commits, nil
+}
+
+// mergeBase returns Repository.FindMergeBase, cached when memoizing.
+func (s *RepositoryStore) mergeBase(sha1, sha2 string) (string, error) {
+	if s.memo == nil {
+		return s.repo.FindMergeBase(s.ctx, sha1, sha2)
+	}
+	if sha2 < sha1 {
+		sha1, sha2 = sha2, sha1
+	}
+	key := sha1 + "..." + sha2
+	s.memo.mu.Lock()
+	base, ok := s.memo.mergeBases[key]
+	s.memo.mu.Unlock()
+	if ok {
+		return base, nil
+	}
+
+	base, err := s.repo.FindMergeBase(s.ctx, sha1, sha2)
+	if err != nil {
+		return "", err
+	}
+	s.memo.mu.Lock()
+	s.memo.mergeBases[key] = base
+	s.memo.mu.Unlock()
+	return base, nil
+}
+
+// cachedVersionTags returns the version tags recorded for tagPrefix and
+// filters, if any.
+func (s *RepositoryStore) cachedVersionTags(tagPrefix string, filters []PathFilter) ([]VersionTag, bool) {
+	if s.memo == nil {
+		return nil, false
+	}
+	s.memo.mu.Lock()
+	defer s.memo.mu.Unlock()
+	tags, ok := s.memo.versionTags[versionTagsKey(tagPrefix, filters)]
+	return tags, ok
+}
+
+// cacheVersionTags records the version tags for tagPrefix and filters.
+func (s *RepositoryStore) cacheVersionTags(tagPrefix string, filters []PathFilter, tags []VersionTag) {
+	if s.memo == nil {
+		return
+	}
+	s.memo.mu.Lock()
+	defer s.memo.mu.Unlock()
+	s.memo.versionTags[versionTagsKey(tagPrefix, filters)] = tags
+}
+
+func versionTagsKey(tagPrefix string, filters []PathFilter) string {
+	parts := []string{tagPrefix}
+	for _, f := range filters {
+		parts = append(parts, string(f))
+	}
+	return strings.Join(parts, "\x00")
+}
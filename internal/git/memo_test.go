@@ -0,0 +1,105 @@
+package git
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoizedRepositoryStore(t *testing.T) {
+	now := time.Now()
+	c1 := newTestCommit("sha1", now.Add(-time.Hour), "commit 1")
+	c2 := newTestCommit("sha2", now.Add(time.Hour), "commit 2", "sha1")
+
+	calls := map[string]int{}
+	mock := &MockRepository{
+		TagsFunc: func(...PathFilter) ([]Tag, error) {
+			calls["Tags"]++
+			return []Tag{tagWithVersion("v1.0.0", "sha1"), tagWithVersion("v2.0.0", "sha2")}, nil
+		},
+		PeelTagToCommitFunc: func(tag Tag) (string, error) { return tag.TargetSha, nil },
+		CommitFromShaFunc: func(sha string) (Commit, error) {
+			if sha == "sha1" {
+				return c1, nil
+			}
+			return c2, nil
+		},
+		BranchesFunc: func(...PathFilter) ([]Branch, error) {
+			calls["Branches"]++
+			return []Branch{branchWithTip("main", &c2)}, nil
+		},
+		CommitLogFunc: func(string, string, ...PathFilter) ([]Commit, error) {
+			calls["CommitLog"]++
+			return []Commit{c2, c1}, nil
+		},
+		FindMergeBaseFunc: func(string, string) (string, error) {
+			calls["FindMergeBase"]++
+			return "sha1", nil
+		},
+	}
+	store := NewMemoizedRepositoryStore(mock)
+
+	for range 3 {
+		all, err := store.GetValidVersionTags("v", nil)
+		require.NoError(t, err)
+		require.Len(t, all, 2)
+
+		// The age filter applies to the cached tags.
+		older, err := store.GetValidVersionTags("v", &now)
+		require.NoError(t, err)
+		require.Len(t, older, 1)
+		require.Equal(t, "sha1", older[0].Commit.Sha)
+
+		_, err = store.GetTargetBranch("main")
+		require.NoError(t, err)
+
+		_, err = store.GetCommitLog(Commit{}, c2)
+		require.NoError(t, err)
+
+		// Merge bases are symmetric.
+		_, _, err = store.FindMergeBaseFromCommits(c1, c2)
+		require.NoError(t, err)
+		_, _, err = store.FindMergeBaseFromCommits(c2, c1)
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, map[string]int{"Tags": 1, "Branches": 1, "CommitLog": 1, "FindMergeBase": 1}, calls)
+
+	// A plain store queries the repository every time.
+	plain := NewRepositoryStore(mock)
+	_, err := plain.GetValidVersionTags("v", nil)
+	require.NoError(t, err)
+	_, err = plain.GetValidVersionTags("v", nil)
+	require.NoError(t, err)
+	require.Equal(t, 3, calls["Tags"])
+}
+
+func TestRepositoryStore_WithContext(t *testing.T) {
+	now := time.Now()
+	c1 := newTestCommit("sha1", now, "commit 1")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	mock := &MockRepository{
+		TagsFunc: func(...PathFilter) ([]Tag, error) {
+			return []Tag{tagWithVersion("v1.0.0", "sha1"), tagWithVersion("v2.0.0", "sha1")}, nil
+		},
+		PeelTagToCommitFunc: func(tag Tag) (string, error) {
+			// Cancelled halfway through peeling.
+			cancel()
+			return tag.TargetSha, nil
+		},
+		CommitFromShaFunc: func(string) (Commit, error) { return c1, nil },
+	}
+	store := NewMemoizedRepositoryStore(mock)
+
+	_, err := store.WithContext(ctx).GetValidVersionTags("v", nil)
+	require.ErrorIs(t, err, context.Canceled)
+
+	// The partial result was not memoized, and the original store still
+	// uses its own context.
+	tags, err := store.GetValidVersionTags("v", nil)
+	require.NoError(t, err)
+	require.Len(t, tags, 2)
+}
@@ -1,13 +1,17 @@
 package git
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
 // Compile-time check that MockRepository implements Repository.
 var _ Repository = (*MockRepository)(nil)
 
 // MockRepository is a configurable mock implementation of Repository for testing.
 // Each method is backed by a function field. If the function field is nil,
-// the method returns sensible zero values.
+// the method returns sensible zero values. Methods taking a context return
+// its error, without calling the function field, once it is done.
 type MockRepository struct {
 	PathFunc                       func() string
 	WorkingDirectoryFunc           func() string
@@ -47,84 +51,120 @@ func (m *MockRepository) IsHeadDetached() bool {
 	return false
 }
 
-func (m *MockRepository) Head() (Branch, error) {
+func (m *MockRepository) Head(ctx context.Context) (Branch, error) {
+	if err := ctx.Err(); err != nil {
+		return Branch{}, err
+	}
 	if m.HeadFunc != nil {
 		return m.HeadFunc()
 	}
 	return Branch{}, nil
 }
 
-func (m *MockRepository) Branches(filters ...PathFilter) ([]Branch, error) {
+func (m *MockRepository) Branches(ctx context.Context, filters ...PathFilter) ([]Branch, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	if m.BranchesFunc != nil {
 		return m.BranchesFunc(filters...)
 	}
 	return nil, nil
 }
 
-func (m *MockRepository) Tags(filters ...PathFilter) ([]Tag, error) {
+func (m *MockRepository) Tags(ctx context.Context, filters ...PathFilter) ([]Tag, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	if m.TagsFunc != nil {
 		return m.TagsFunc(filters...)
 	}
 	return nil, nil
 }
 
-func (m *MockRepository) CommitFromSha(sha string) (Commit, error) {
+func (m *MockRepository) CommitFromSha(ctx context.Context, sha string) (Commit, error) {
+	if err := ctx.Err(); err != nil {
+		return Commit{}, err
+	}
 	if m.CommitFromShaFunc != nil {
 		return m.CommitFromShaFunc(sha)
 	}
 	return Commit{}, nil
 }
 
-func (m *MockRepository) CommitLog(from, to string, filters ...PathFilter) ([]Commit, error) {
+func (m *MockRepository) CommitLog(ctx context.Context, from, to string, filters ...PathFilter) ([]Commit, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	if m.CommitLogFunc != nil {
 		return m.CommitLogFunc(from, to, filters...)
 	}
 	return nil, nil
 }
 
-func (m *MockRepository) MainlineCommitLog(from, to string, filters ...PathFilter) ([]Commit, error) {
+func (m *MockRepository) MainlineCommitLog(ctx context.Context, from, to string, filters ...PathFilter) ([]Commit, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	if m.MainlineCommitLogFunc != nil {
 		return m.MainlineCommitLogFunc(from, to, filters...)
 	}
 	return nil, nil
 }
 
-func (m *MockRepository) BranchCommits(branch Branch, filters ...PathFilter) ([]Commit, error) {
+func (m *MockRepository) BranchCommits(ctx context.Context, branch Branch, filters ...PathFilter) ([]Commit, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	if m.BranchCommitsFunc != nil {
 		return m.BranchCommitsFunc(branch, filters...)
 	}
 	return nil, nil
 }
 
-func (m *MockRepository) CommitsPriorTo(olderThan time.Time, branch Branch) ([]Commit, error) {
+func (m *MockRepository) CommitsPriorTo(ctx context.Context, olderThan time.Time, branch Branch) ([]Commit, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	if m.CommitsPriorToFunc != nil {
 		return m.CommitsPriorToFunc(olderThan, branch)
 	}
 	return nil, nil
 }
 
-func (m *MockRepository) FindMergeBase(sha1, sha2 string) (string, error) {
+func (m *MockRepository) FindMergeBase(ctx context.Context, sha1, sha2 string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
 	if m.FindMergeBaseFunc != nil {
 		return m.FindMergeBaseFunc(sha1, sha2)
 	}
 	return "", nil
 }
 
-func (m *MockRepository) BranchesContainingCommit(sha string) ([]Branch, error) {
+func (m *MockRepository) BranchesContainingCommit(ctx context.Context, sha string) ([]Branch, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	if m.BranchesContainingCommitFunc != nil {
 		return m.BranchesContainingCommitFunc(sha)
 	}
 	return nil, nil
 }
 
-func (m *MockRepository) NumberOfUncommittedChanges() (int, error) {
+func (m *MockRepository) NumberOfUncommittedChanges(ctx context.Context) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
 	if m.NumberOfUncommittedChangesFunc != nil {
 		return m.NumberOfUncommittedChangesFunc()
 	}
 	return 0, nil
 }
 
-func (m *MockRepository) PeelTagToCommit(tag Tag) (string, error) {
+func (m *MockRepository) PeelTagToCommit(ctx context.Context, tag Tag) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
 	if m.PeelTagToCommitFunc != nil {
 		return m.PeelTagToCommitFunc(tag)
 	}
@@ -1,6 +1,7 @@
 package git
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -15,51 +16,51 @@ func TestMockRepository_NilFuncsReturnDefaults(t *testing.T) {
 	require.Equal(t, "", m.WorkingDirectory())
 	require.False(t, m.IsHeadDetached())
 
-	head, err := m.Head()
+	head, err := m.Head(context.Background())
 	require.NoError(t, err)
 	require.Equal(t, Branch{}, head)
 
-	branches, err := m.Branches()
+	branches, err := m.Branches(context.Background())
 	require.NoError(t, err)
 	require.Nil(t, branches)
 
-	tags, err := m.Tags()
+	tags, err := m.Tags(context.Background())
 	require.NoError(t, err)
 	require.Nil(t, tags)
 
-	commit, err := m.CommitFromSha("abc")
+	commit, err := m.CommitFromSha(context.Background(), "abc")
 	require.NoError(t, err)
 	require.Equal(t, Commit{}, commit)
 
-	log, err := m.CommitLog("a", "b")
+	log, err := m.CommitLog(context.Background(), "a", "b")
 	require.NoError(t, err)
 	require.Nil(t, log)
 
-	mainLog, err := m.MainlineCommitLog("a", "b")
+	mainLog, err := m.MainlineCommitLog(context.Background(), "a", "b")
 	require.NoError(t, err)
 	require.Nil(t, mainLog)
 
-	bc, err := m.BranchCommits(Branch{})
+	bc, err := m.BranchCommits(context.Background(), Branch{})
 	require.NoError(t, err)
 	require.Nil(t, bc)
 
-	prior, err := m.CommitsPriorTo(time.Now(), Branch{})
+	prior, err := m.CommitsPriorTo(context.Background(), time.Now(), Branch{})
 	require.NoError(t, err)
 	require.Nil(t, prior)
 
-	mb, err := m.FindMergeBase("a", "b")
+	mb, err := m.FindMergeBase(context.Background(), "a", "b")
 	require.NoError(t, err)
 	require.Equal(t, "", mb)
 
-	containing, err := m.BranchesContainingCommit("abc")
+	containing, err := m.BranchesContainingCommit(context.Background(), "abc")
 	require.NoError(t, err)
 	require.Nil(t, containing)
 
-	changes, err := m.NumberOfUncommittedChanges()
+	changes, err := m.NumberOfUncommittedChanges(context.Background())
 	require.NoError(t, err)
 	require.Equal(t, 0, changes)
 
-	sha, err := m.PeelTagToCommit(Tag{TargetSha: "abc123"})
+	sha, err := m.PeelTagToCommit(context.Background(), Tag{TargetSha: "abc123"})
 	require.NoError(t, err)
 	require.Equal(t, "abc123", sha) // default returns TargetSha
 }
@@ -91,18 +92,18 @@ func TestMockRepository_FuncFieldsCalled(t *testing.T) {
 	require.Equal(t, "/repo", m.WorkingDirectory())
 	require.True(t, m.IsHeadDetached())
 
-	head, err := m.Head()
+	head, err := m.Head(context.Background())
 	require.NoError(t, err)
 	require.Equal(t, "main", head.FriendlyName())
 
-	commit, err := m.CommitFromSha("abc123")
+	commit, err := m.CommitFromSha(context.Background(), "abc123")
 	require.NoError(t, err)
 	require.Equal(t, expectedCommit, commit)
 
-	_, err = m.FindMergeBase("a", "b")
+	_, err = m.FindMergeBase(context.Background(), "a", "b")
 	require.ErrorIs(t, err, expectedErr)
 
-	changes, err := m.NumberOfUncommittedChanges()
+	changes, err := m.NumberOfUncommittedChanges(context.Background())
 	require.NoError(t, err)
 	require.Equal(t, 5, changes)
 }
@@ -116,7 +117,7 @@ func TestMockRepository_TagsWithFilters(t *testing.T) {
 		},
 	}
 
-	tags, err := m.Tags(PathFilter("src/"))
+	tags, err := m.Tags(context.Background(), PathFilter("src/"))
 	require.NoError(t, err)
 	require.Len(t, tags, 1)
 	require.Equal(t, []PathFilter{PathFilter("src/")}, receivedFilters)
@@ -132,7 +133,7 @@ func TestMockRepository_CommitLogWithFilters(t *testing.T) {
 		},
 	}
 
-	commits, err := m.CommitLog("abc", "def")
+	commits, err := m.CommitLog(context.Background(), "abc", "def")
 	require.NoError(t, err)
 	require.Len(t, commits, 1)
 	require.Equal(t, "abc", gotFrom)
@@ -146,7 +147,7 @@ func TestMockRepository_PeelTagToCommitCustom(t *testing.T) {
 		},
 	}
 
-	sha, err := m.PeelTagToCommit(Tag{TargetSha: "original"})
+	sha, err := m.PeelTagToCommit(context.Background(), Tag{TargetSha: "original"})
 	require.NoError(t, err)
 	require.Equal(t, "peeled-sha", sha)
 }
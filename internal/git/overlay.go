@@ -0,0 +1,262 @@
+package git
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Compile-time check that OverlayRepository implements Repository.
+var _ Repository = (*OverlayRepository)(nil)
+
+// OverlayRepository presents a Repository with one extra commit that exists
+// only in memory: a virtual commit on top of existing parents, which
+// becomes the tip of one branch. The underlying repository is never
+// written to. It is used to calculate versions for merges that have not
+// happened yet.
+type OverlayRepository struct {
+	base   Repository
+	branch string // friendly name of the branch whose tip is commit
+	commit Commit
+}
+
+// NewOverlayRepository returns base with commit added as the tip of branch.
+// The parents of commit must exist in base.
+func NewOverlayRepository(base Repository, branch string, commit Commit) *OverlayRepository {
+	return &OverlayRepository{base: base, branch: branch, commit: commit}
+}
+
+// NewVirtualCommit returns a commit with the given message, time and
+// parents, identified by a SHA derived from them. It exists in no
+// repository until added to an OverlayRepository.
+func NewVirtualCommit(message string, when time.Time, parents ...string) Commit {
+	h := sha1.New()
+	fmt.Fprintf(h, "virtual\x00%s\x00%d\x00%s", strings.Join(parents, ","), when.UnixNano(), message)
+	return Commit{
+		Sha:     hex.EncodeToString(h.Sum(nil)),
+		Parents: parents,
+		When:    when,
+		Message: message,
+	}
+}
+
+func (o *OverlayRepository) Path() string { return o.base.Path() }
+
+func (o *OverlayRepository) WorkingDirectory() string { return o.base.WorkingDirectory() }
+
+func (o *OverlayRepository) IsHeadDetached() bool { return o.base.IsHeadDetached() }
+
+func (o *OverlayRepository) Head(ctx context.Context) (Branch, error) {
+	head, err := o.base.Head(ctx)
+	if err != nil {
+		return Branch{}, err
+	}
+	return o.withTip(head), nil
+}
+
+func (o *OverlayRepository) Branches(ctx context.Context, filters ...PathFilter) ([]Branch, error) {
+	branches, err := o.base.Branches(ctx, filters...)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Branch, len(branches))
+	for i, b := range branches {
+		result[i] = o.withTip(b)
+	}
+	return result, nil
+}
+
+func (o *OverlayRepository) Tags(ctx context.Context, filters ...PathFilter) ([]Tag, error) {
+	return o.base.Tags(ctx, filters...)
+}
+
+func (o *OverlayRepository) CommitFromSha(ctx context.Context, sha string) (Commit, error) {
+	if sha == o.commit.Sha {
+		return o.commit, nil
+	}
+	return o.base.CommitFromSha(ctx, sha)
+}
+
+// CommitLog merges the logs of the virtual commit's parents when to is the
+// virtual commit, keeping committer-time order.
+func (o *OverlayRepository) CommitLog(ctx context.Context, from, to string, filters ...PathFilter) ([]Commit, error) {
+	if to != o.commit.Sha {
+		return o.base.CommitLog(ctx, from, to, filters...)
+	}
+	if from == o.commit.Sha {
+		return nil, nil
+	}
+
+	seen := map[string]bool{o.commit.Sha: true}
+	var commits []Commit
+	for _, p := range o.commit.Parents {
+		log, err := o.base.CommitLog(ctx, from, p, filters...)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range log {
+			if !seen[c.Sha] {
+				seen[c.Sha] = true
+				commits = append(commits, c)
+			}
+		}
+	}
+	sort.SliceStable(commits, func(i, j int) bool {
+		return commits[i].When.After(commits[j].When)
+	})
+	return append([]Commit{o.commit}, commits...), nil
+}
+
+func (o *OverlayRepository) MainlineCommitLog(ctx context.Context, from, to string, filters ...PathFilter) ([]Commit, error) {
+	if to != o.commit.Sha {
+		return o.base.MainlineCommitLog(ctx, from, to, filters...)
+	}
+	if from == o.commit.Sha {
+		return nil, nil
+	}
+	if len(o.commit.Parents) == 0 {
+		return []Commit{o.commit}, nil
+	}
+	log, err := o.base.MainlineCommitLog(ctx, from, o.commit.Parents[0], filters...)
+	if err != nil {
+		return nil, err
+	}
+	return append([]Commit{o.commit}, log...), nil
+}
+
+func (o *OverlayRepository) BranchCommits(ctx context.Context, branch Branch, filters ...PathFilter) ([]Commit, error) {
+	if branch.Tip == nil {
+		return nil, nil
+	}
+	return o.CommitLog(ctx, "", branch.Tip.Sha, filters...)
+}
+
+func (o *OverlayRepository) CommitsPriorTo(ctx context.Context, olderThan time.Time, branch Branch) ([]Commit, error) {
+	commits, err := o.BranchCommits(ctx, branch)
+	if err != nil {
+		return nil, err
+	}
+	var result []Commit
+	for _, c := range commits {
+		if c.When.Before(olderThan) {
+			result = append(result, c)
+		}
+	}
+	return result, nil
+}
+
+// FindMergeBase resolves merge bases with the virtual commit through its
+// parents: an ancestor of the virtual commit is its own merge base, and
+// otherwise the newest merge base of a parent wins.
+func (o *OverlayRepository) FindMergeBase(ctx context.Context, sha1, sha2 string) (string, error) {
+	if sha2 == o.commit.Sha {
+		sha1, sha2 = sha2, sha1
+	}
+	if sha1 != o.commit.Sha {
+		return o.base.FindMergeBase(ctx, sha1, sha2)
+	}
+	if sha2 == o.commit.Sha {
+		return sha2, nil
+	}
+
+	ancestor, err := o.isAncestor(ctx, sha2)
+	if err != nil {
+		return "", err
+	}
+	if ancestor {
+		return sha2, nil
+	}
+
+	var best Commit
+	for _, p := range o.commit.Parents {
+		mb, err := o.base.FindMergeBase(ctx, p, sha2)
+		if err != nil {
+			return "", err
+		}
+		if mb == "" {
+			continue
+		}
+		c, err := o.base.CommitFromSha(ctx, mb)
+		if err != nil {
+			return "", err
+		}
+		if best.IsEmpty() || c.When.After(best.When) {
+			best = c
+		}
+	}
+	return best.Sha, nil
+}
+
+func (o *OverlayRepository) BranchesContainingCommit(ctx context.Context, sha string) ([]Branch, error) {
+	branches, err := o.base.BranchesContainingCommit(ctx, sha)
+	if err != nil {
+		return nil, err
+	}
+	var result []Branch
+	hasBranch := false
+	for _, b := range branches {
+		if b.FriendlyName() == o.branch {
+			hasBranch = true
+		}
+		result = append(result, o.withTip(b))
+	}
+	if hasBranch {
+		return result, nil
+	}
+
+	// The branch contains everything its new tip does.
+	ancestor, err := o.isAncestor(ctx, sha)
+	if err != nil {
+		return nil, err
+	}
+	if sha == o.commit.Sha || ancestor {
+		all, err := o.Branches(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range all {
+			if b.FriendlyName() == o.branch {
+				result = append(result, b)
+			}
+		}
+	}
+	return result, nil
+}
+
+// NumberOfUncommittedChanges is always zero: the virtual commit stands in
+// for the working directory.
+func (o *OverlayRepository) NumberOfUncommittedChanges(_ context.Context) (int, error) {
+	return 0, nil
+}
+
+func (o *OverlayRepository) PeelTagToCommit(ctx context.Context, tag Tag) (string, error) {
+	return o.base.PeelTagToCommit(ctx, tag)
+}
+
+// withTip returns b with the virtual commit as its tip if it is the
+// overlaid branch.
+func (o *OverlayRepository) withTip(b Branch) Branch {
+	if b.FriendlyName() == o.branch {
+		tip := o.commit
+		b.Tip = &tip
+	}
+	return b
+}
+
+// isAncestor reports whether sha is reachable from the virtual commit.
+func (o *OverlayRepository) isAncestor(ctx context.Context, sha string) (bool, error) {
+	commits, err := o.CommitLog(ctx, "", o.commit.Sha)
+	if err != nil {
+		return false, err
+	}
+	for _, c := range commits {
+		if c.Sha == sha {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,172 @@
+package git
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// overlayTestRepo returns a virtual merge of feature into main on top of a
+// mock repository:
+//
+//	c1 ── m2 (main) ──┐
+//	  └── f2 (feature) ┴─ merge
+func overlayTestRepo(t *testing.T) (*OverlayRepository, Commit, map[string]Commit) {
+	t.Helper()
+	now := time.Now()
+	commits := map[string]Commit{
+		"c1": newTestCommit("c1", now.Add(-3*time.Hour), "initial"),
+		"m2": newTestCommit("m2", now.Add(-2*time.Hour), "fix: on main", "c1"),
+		"f2": newTestCommit("f2", now.Add(-time.Hour), "feat: on feature", "c1"),
+	}
+	main, feature := commits["m2"], commits["f2"]
+
+	log := func(from, to string, _ ...PathFilter) ([]Commit, error) {
+		var result []Commit
+		seen := map[string]bool{}
+		queue := []string{to}
+		for len(queue) > 0 {
+			sha := queue[0]
+			queue = queue[1:]
+			if sha == from || seen[sha] {
+				continue
+			}
+			seen[sha] = true
+			result = append(result, commits[sha])
+			queue = append(queue, commits[sha].Parents...)
+		}
+		sort.SliceStable(result, func(i, j int) bool { return result[i].When.After(result[j].When) })
+		return result, nil
+	}
+	mock := &MockRepository{
+		HeadFunc: func() (Branch, error) { return branchWithTip("main", &main), nil },
+		BranchesFunc: func(...PathFilter) ([]Branch, error) {
+			return []Branch{branchWithTip("main", &main), branchWithTip("feature", &feature)}, nil
+		},
+		CommitFromShaFunc: func(sha string) (Commit, error) { return commits[sha], nil },
+		CommitLogFunc:     log,
+		MainlineCommitLogFunc: func(from, to string, _ ...PathFilter) ([]Commit, error) {
+			var result []Commit
+			for sha := to; sha != "" && sha != from; {
+				c := commits[sha]
+				result = append(result, c)
+				sha = ""
+				if len(c.Parents) > 0 {
+					sha = c.Parents[0]
+				}
+			}
+			return result, nil
+		},
+		FindMergeBaseFunc: func(string, string) (string, error) { return "c1", nil },
+		BranchesContainingCommitFunc: func(sha string) ([]Branch, error) {
+			if sha == "f2" {
+				return []Branch{branchWithTip("feature", &feature)}, nil
+			}
+			return []Branch{branchWithTip("main", &main)}, nil
+		},
+		NumberOfUncommittedChangesFunc: func() (int, error) { return 3, nil },
+	}
+
+	merge := NewVirtualCommit("Merge branch 'feature' into main", now, "m2", "f2")
+	return NewOverlayRepository(mock, "main", merge), merge, commits
+}
+
+func TestNewVirtualCommit(t *testing.T) {
+	when := time.Now()
+	a := NewVirtualCommit("msg", when, "p1", "p2")
+	require.Len(t, a.Sha, 40)
+	require.Equal(t, []string{"p1", "p2"}, a.Parents)
+	require.True(t, a.IsMerge())
+	require.Equal(t, a.Sha, NewVirtualCommit("msg", when, "p1", "p2").Sha)
+	require.NotEqual(t, a.Sha, NewVirtualCommit("other", when, "p1", "p2").Sha)
+}
+
+func TestOverlayRepository_Tips(t *testing.T) {
+	overlay, merge, _ := overlayTestRepo(t)
+
+	head, err := overlay.Head(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, merge.Sha, head.Tip.Sha)
+
+	branches, err := overlay.Branches(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, merge.Sha, branches[0].Tip.Sha)
+	require.Equal(t, "f2", branches[1].Tip.Sha)
+
+	c, err := overlay.CommitFromSha(context.Background(), merge.Sha)
+	require.NoError(t, err)
+	require.Equal(t, merge, c)
+
+	changes, err := overlay.NumberOfUncommittedChanges(context.Background())
+	require.NoError(t, err)
+	require.Zero(t, changes)
+}
+
+func TestOverlayRepository_CommitLog(t *testing.T) {
+	overlay, merge, _ := overlayTestRepo(t)
+
+	log, err := overlay.CommitLog(context.Background(), "", merge.Sha)
+	require.NoError(t, err)
+	require.Equal(t, []string{merge.Sha, "f2", "m2", "c1"}, shas(log))
+
+	log, err = overlay.CommitLog(context.Background(), "c1", merge.Sha)
+	require.NoError(t, err)
+	require.Equal(t, []string{merge.Sha, "f2", "m2"}, shas(log))
+
+	log, err = overlay.CommitLog(context.Background(), merge.Sha, merge.Sha)
+	require.NoError(t, err)
+	require.Empty(t, log)
+
+	mainline, err := overlay.MainlineCommitLog(context.Background(), "", merge.Sha)
+	require.NoError(t, err)
+	require.Equal(t, []string{merge.Sha, "m2", "c1"}, shas(mainline))
+
+	head, err := overlay.Head(context.Background())
+	require.NoError(t, err)
+	prior, err := overlay.CommitsPriorTo(context.Background(), merge.When, head)
+	require.NoError(t, err)
+	require.Equal(t, []string{"f2", "m2", "c1"}, shas(prior))
+}
+
+func TestOverlayRepository_FindMergeBase(t *testing.T) {
+	overlay, merge, _ := overlayTestRepo(t)
+
+	base, err := overlay.FindMergeBase(context.Background(), merge.Sha, "f2")
+	require.NoError(t, err)
+	require.Equal(t, "f2", base)
+
+	base, err = overlay.FindMergeBase(context.Background(), "m2", merge.Sha)
+	require.NoError(t, err)
+	require.Equal(t, "m2", base)
+
+	base, err = overlay.FindMergeBase(context.Background(), "m2", "f2")
+	require.NoError(t, err)
+	require.Equal(t, "c1", base)
+}
+
+func TestOverlayRepository_BranchesContainingCommit(t *testing.T) {
+	overlay, merge, _ := overlayTestRepo(t)
+
+	branches, err := overlay.BranchesContainingCommit(context.Background(), "f2")
+	require.NoError(t, err)
+	require.Len(t, branches, 2)
+	require.Equal(t, "feature", branches[0].FriendlyName())
+	require.Equal(t, "main", branches[1].FriendlyName())
+	require.Equal(t, merge.Sha, branches[1].Tip.Sha)
+
+	branches, err = overlay.BranchesContainingCommit(context.Background(), merge.Sha)
+	require.NoError(t, err)
+	require.Len(t, branches, 1)
+	require.Equal(t, "main", branches[0].FriendlyName())
+}
+
+func shas(commits []Commit) []string {
+	result := make([]string, len(commits))
+	for i, c := range commits {
+		result[i] = c.Sha
+	}
+	return result
+}
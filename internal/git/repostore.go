@@ -1,6 +1,7 @@
 package git
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"sort"
@@ -15,11 +16,22 @@ import (
 // in the context of semantic versioning.
 type RepositoryStore struct {
 	repo Repository
+	ctx  context.Context // passed to every Repository call
+	memo *storeMemo      // nil unless created by NewMemoizedRepositoryStore
 }
 
 // NewRepositoryStore creates a new RepositoryStore wrapping the given Repository.
 func NewRepositoryStore(repo Repository) *RepositoryStore {
-	return &RepositoryStore{repo: repo}
+	return &RepositoryStore{repo: repo, ctx: context.Background()}
+}
+
+// WithContext returns a shallow copy of s whose Repository calls use ctx,
+// so a calculation stops with ctx's error once it is cancelled or its
+// deadline passes. The copy shares s's memoized results.
+func (s *RepositoryStore) WithContext(ctx context.Context) *RepositoryStore {
+	s2 := *s
+	s2.ctx = ctx
+	return &s2
 }
 
 // --- Tag queries ---
@@ -27,35 +39,47 @@ func NewRepositoryStore(repo Repository) *RepositoryStore {
 // GetValidVersionTags returns all tags that parse as semantic versions,
 // optionally filtered to tags on commits older than the given time.
 func (s *RepositoryStore) GetValidVersionTags(tagPrefix string, olderThan *time.Time, filters ...PathFilter) ([]VersionTag, error) {
-	tags, err := s.repo.Tags(filters...)
-	if err != nil {
-		return nil, fmt.Errorf("listing tags: %w", err)
-	}
-
-	var result []VersionTag
-	for _, tag := range tags {
-		ver, ok := semver.TryParse(tag.Name.Friendly, tagPrefix)
-		if !ok {
-			continue
-		}
-
-		commitSha, err := s.repo.PeelTagToCommit(tag)
+	all, ok := s.cachedVersionTags(tagPrefix, filters)
+	if !ok {
+		tags, err := s.repo.Tags(s.ctx, filters...)
 		if err != nil {
-			continue
+			return nil, fmt.Errorf("listing tags: %w", err)
 		}
 
-		commit, err := s.repo.CommitFromSha(commitSha)
-		if err != nil {
-			continue
-		}
+		for _, tag := range tags {
+			ver, ok := semver.TryParse(tag.Name.Friendly, tagPrefix)
+			if !ok {
+				continue
+			}
 
-		if olderThan != nil && commit.When.After(*olderThan) {
-			continue
-		}
+			commitSha, err := s.repo.PeelTagToCommit(s.ctx, tag)
+			if err != nil {
+				continue
+			}
+
+			commit, err := s.repo.CommitFromSha(s.ctx, commitSha)
+			if err != nil {
+				continue
+			}
 
-		result = append(result, VersionTag{Tag: tag, Version: ver, Commit: commit})
+			all = append(all, VersionTag{Tag: tag, Version: ver, Commit: commit})
+		}
+		// Lookup failures above skip the tag; don't mistake cancellation for one.
+		if err := s.ctx.Err(); err != nil {
+			return nil, err
+		}
+		s.cacheVersionTags(tagPrefix, filters, all)
 	}
 
+	if olderThan == nil {
+		return all, nil
+	}
+	var result []VersionTag
+	for _, vt := range all {
+		if !vt.Commit.When.After(*olderThan) {
+			result = append(result, vt)
+		}
+	}
 	return result, nil
 }
 
@@ -67,7 +91,7 @@ func (s *RepositoryStore) GetVersionTagsOnBranch(branch Branch, tagPrefix string
 		return nil, err
 	}
 
-	commits, err := s.repo.BranchCommits(branch, filters...)
+	commits, err := s.repo.BranchCommits(s.ctx, branch, filters...)
 	if err != nil {
 		return nil, fmt.Errorf("getting branch commits: %w", err)
 	}
@@ -116,8 +140,19 @@ func (s *RepositoryStore) GetCurrentCommitTaggedVersion(commit Commit, tagPrefix
 	return *best, true, nil
 }
 
+// GetTags returns every tag in the repository, whether or not it parses as
+// a version.
+func (s *RepositoryStore) GetTags(filters ...PathFilter) ([]Tag, error) {
+	return s.repo.Tags(s.ctx, filters...)
+}
+
 // --- Branch queries ---
 
+// GetBranches returns every local and remote-tracking branch.
+func (s *RepositoryStore) GetBranches(filters ...PathFilter) ([]Branch, error) {
+	return s.repo.Branches(s.ctx, filters...)
+}
+
 // FindMainBranch returns the branch matching the main branch regex from config.
 func (s *RepositoryStore) FindMainBranch(cfg *config.Config) (Branch, bool, error) {
 	mainBC, ok := cfg.Branches["main"]
@@ -130,7 +165,7 @@ func (s *RepositoryStore) FindMainBranch(cfg *config.Config) (Branch, bool, erro
 		return Branch{}, false, fmt.Errorf("invalid main branch regex %q: %w", *mainBC.Regex, err)
 	}
 
-	branches, err := s.repo.Branches()
+	branches, err := s.branches()
 	if err != nil {
 		return Branch{}, false, fmt.Errorf("listing branches: %w", err)
 	}
@@ -146,7 +181,7 @@ func (s *RepositoryStore) FindMainBranch(cfg *config.Config) (Branch, bool, erro
 
 // GetReleaseBranches returns all branches matching any release branch config regex.
 func (s *RepositoryStore) GetReleaseBranches(releaseBranchConfig map[string]*config.BranchConfig) ([]Branch, error) {
-	branches, err := s.repo.Branches()
+	branches, err := s.branches()
 	if err != nil {
 		return nil, fmt.Errorf("listing branches: %w", err)
 	}
@@ -184,12 +219,12 @@ func (s *RepositoryStore) GetBranchesContainingCommit(commit Commit) ([]Branch,
 	if commit.IsEmpty() {
 		return nil, nil
 	}
-	return s.repo.BranchesContainingCommit(commit.Sha)
+	return s.repo.BranchesContainingCommit(s.ctx, commit.Sha)
 }
 
 // GetBranchesForCommit returns non-remote branches whose tip is the given commit.
 func (s *RepositoryStore) GetBranchesForCommit(commit Commit) ([]Branch, error) {
-	branches, err := s.repo.Branches()
+	branches, err := s.branches()
 	if err != nil {
 		return nil, fmt.Errorf("listing branches: %w", err)
 	}
@@ -207,10 +242,10 @@ func (s *RepositoryStore) GetBranchesForCommit(commit Commit) ([]Branch, error)
 // GetTargetBranch resolves the target branch from a name or HEAD.
 func (s *RepositoryStore) GetTargetBranch(targetBranchName string) (Branch, error) {
 	if targetBranchName == "" {
-		return s.repo.Head()
+		return s.repo.Head(s.ctx)
 	}
 
-	branches, err := s.repo.Branches()
+	branches, err := s.branches()
 	if err != nil {
 		return Branch{}, fmt.Errorf("listing branches: %w", err)
 	}
@@ -229,7 +264,7 @@ func (s *RepositoryStore) GetTargetBranch(targetBranchName string) (Branch, erro
 // GetCurrentCommit returns the commit from a SHA or the branch tip.
 func (s *RepositoryStore) GetCurrentCommit(branch Branch, commitID string) (Commit, error) {
 	if commitID != "" {
-		return s.repo.CommitFromSha(commitID)
+		return s.repo.CommitFromSha(s.ctx, commitID)
 	}
 	if branch.Tip == nil {
 		return Commit{}, fmt.Errorf("branch %q has no tip commit", branch.FriendlyName())
@@ -239,7 +274,7 @@ func (s *RepositoryStore) GetCurrentCommit(branch Branch, commitID string) (Comm
 
 // GetBaseVersionSource returns the root commit (first commit) reachable from tip.
 func (s *RepositoryStore) GetBaseVersionSource(tip Commit) (Commit, error) {
-	commits, err := s.repo.CommitLog("", tip.Sha)
+	commits, err := s.commitLog("", tip.Sha)
 	if err != nil {
 		return Commit{}, fmt.Errorf("getting commit log: %w", err)
 	}
@@ -251,17 +286,17 @@ func (s *RepositoryStore) GetBaseVersionSource(tip Commit) (Commit, error) {
 
 // GetCommitLog returns commits between from and to.
 func (s *RepositoryStore) GetCommitLog(from, to Commit) ([]Commit, error) {
-	return s.repo.CommitLog(from.Sha, to.Sha)
+	return s.commitLog(from.Sha, to.Sha)
 }
 
 // GetMainlineCommitLog returns first-parent-only commits between from and to.
 func (s *RepositoryStore) GetMainlineCommitLog(from, to Commit) ([]Commit, error) {
-	return s.repo.MainlineCommitLog(from.Sha, to.Sha)
+	return s.repo.MainlineCommitLog(s.ctx, from.Sha, to.Sha)
 }
 
 // GetMergeBaseCommits returns commits reachable from mergedHead but not from mergeBase.
 func (s *RepositoryStore) GetMergeBaseCommits(mergedHead, mergeBase Commit) ([]Commit, error) {
-	return s.repo.CommitLog(mergeBase.Sha, mergedHead.Sha)
+	return s.commitLog(mergeBase.Sha, mergedHead.Sha)
 }
 
 // --- Merge base ---
@@ -276,7 +311,7 @@ func (s *RepositoryStore) FindMergeBase(branch1, branch2 Branch) (Commit, bool,
 
 // FindMergeBaseFromCommits returns the merge base of two commits.
 func (s *RepositoryStore) FindMergeBaseFromCommits(commit1, commit2 Commit) (Commit, bool, error) {
-	sha, err := s.repo.FindMergeBase(commit1.Sha, commit2.Sha)
+	sha, err := s.mergeBase(commit1.Sha, commit2.Sha)
 	if err != nil {
 		return Commit{}, false, fmt.Errorf("finding merge base: %w", err)
 	}
@@ -284,7 +319,7 @@ func (s *RepositoryStore) FindMergeBaseFromCommits(commit1, commit2 Commit) (Com
 		return Commit{}, false, nil
 	}
 
-	commit, err := s.repo.CommitFromSha(sha)
+	commit, err := s.repo.CommitFromSha(s.ctx, sha)
 	if err != nil {
 		return Commit{}, false, fmt.Errorf("loading merge base commit: %w", err)
 	}
@@ -294,7 +329,8 @@ func (s *RepositoryStore) FindMergeBaseFromCommits(commit1, commit2 Commit) (Com
 
 // FindCommitBranchWasBranchedFrom finds where a branch was forked from a source
 // branch. It examines source branches defined in config and returns the branch
-// and commit of the closest fork point.
+// and commit of the closest fork point. The config builder adds branches
+// declaring is-source-branch-for to the source branches of their targets.
 func (s *RepositoryStore) FindCommitBranchWasBranchedFrom(branch Branch, cfg *config.Config, excludedBranches ...Branch) (BranchCommit, error) {
 	if branch.Tip == nil {
 		return BranchCommit{}, nil
@@ -316,7 +352,7 @@ func (s *RepositoryStore) FindCommitBranchWasBranchedFrom(branch Branch, cfg *co
 		excludedSet[eb.FriendlyName()] = struct{}{}
 	}
 
-	allBranches, err := s.repo.Branches()
+	allBranches, err := s.branches()
 	if err != nil {
 		return BranchCommit{}, fmt.Errorf("listing branches: %w", err)
 	}
@@ -349,12 +385,12 @@ func (s *RepositoryStore) FindCommitBranchWasBranchedFrom(branch Branch, cfg *co
 				continue
 			}
 
-			mb, err := s.repo.FindMergeBase(branch.Tip.Sha, b.Tip.Sha)
+			mb, err := s.mergeBase(branch.Tip.Sha, b.Tip.Sha)
 			if err != nil || mb == "" {
 				continue
 			}
 
-			commit, err := s.repo.CommitFromSha(mb)
+			commit, err := s.repo.CommitFromSha(s.ctx, mb)
 			if err != nil {
 				continue
 			}
@@ -367,6 +403,9 @@ func (s *RepositoryStore) FindCommitBranchWasBranchedFrom(branch Branch, cfg *co
 		}
 	}
 
+	if err := s.ctx.Err(); err != nil {
+		return BranchCommit{}, err
+	}
 	if !found {
 		return BranchCommit{}, nil
 	}
@@ -374,6 +413,67 @@ func (s *RepositoryStore) FindCommitBranchWasBranchedFrom(branch Branch, cfg *co
 	return BranchCommit{Branch: bestBranch, Commit: bestCommit}, nil
 }
 
+// MergeBaseCandidates returns the branches whose merge base with branch may be
+// queried during calculation: branches matching the source-branches of
+// branch's config and, when it tracks release branches, all release branches.
+// Backends use it to prefetch merge bases; the order follows Branches().
+func (s *RepositoryStore) MergeBaseCandidates(branch Branch, cfg *config.Config) ([]Branch, error) {
+	if branch.Tip == nil {
+		return nil, nil
+	}
+
+	_, configName, err := cfg.GetBranchConfiguration(branch.FriendlyName())
+	if err != nil {
+		return nil, fmt.Errorf("getting branch configuration: %w", err)
+	}
+	bc := cfg.Branches[configName]
+	if bc == nil {
+		return nil, nil
+	}
+
+	var patterns []*regexp.Regexp
+	addPattern := func(sbc *config.BranchConfig) {
+		if sbc == nil || sbc.Regex == nil {
+			return
+		}
+		if re, err := regexp.Compile(*sbc.Regex); err == nil {
+			patterns = append(patterns, re)
+		}
+	}
+	if bc.SourceBranches != nil {
+		for _, name := range *bc.SourceBranches {
+			addPattern(cfg.Branches[name])
+		}
+	}
+	if bc.TracksReleaseBranches != nil && *bc.TracksReleaseBranches {
+		for _, rbc := range cfg.GetReleaseBranchConfig() {
+			addPattern(rbc)
+		}
+	}
+	if len(patterns) == 0 {
+		return nil, nil
+	}
+
+	branches, err := s.branches()
+	if err != nil {
+		return nil, fmt.Errorf("listing branches: %w", err)
+	}
+
+	var result []Branch
+	for _, b := range branches {
+		if b.IsRemote || b.Tip == nil || b.FriendlyName() == branch.FriendlyName() {
+			continue
+		}
+		for _, re := range patterns {
+			if re.MatchString(b.FriendlyName()) {
+				result = append(result, b)
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
 // --- Utility ---
 
 // IsCommitOnBranch checks if a commit is reachable from the branch tip.
@@ -382,7 +482,7 @@ func (s *RepositoryStore) IsCommitOnBranch(commit Commit, branch Branch) (bool,
 		return false, nil
 	}
 
-	commits, err := s.repo.CommitLog("", branch.Tip.Sha)
+	commits, err := s.commitLog("", branch.Tip.Sha)
 	if err != nil {
 		return false, fmt.Errorf("getting commit log: %w", err)
 	}
@@ -398,5 +498,5 @@ func (s *RepositoryStore) IsCommitOnBranch(commit Commit, branch Branch) (bool,
 
 // GetNumberOfUncommittedChanges returns the number of uncommitted changes.
 func (s *RepositoryStore) GetNumberOfUncommittedChanges() (int, error) {
-	return s.repo.NumberOfUncommittedChanges()
+	return s.repo.NumberOfUncommittedChanges(s.ctx)
 }
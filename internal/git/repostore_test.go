@@ -613,6 +613,54 @@ func TestFindCommitBranchWasBranchedFrom(t *testing.T) {
 	require.Equal(t, "main", bc.Branch.FriendlyName())
 }
 
+func TestMergeBaseCandidates(t *testing.T) {
+	tip := newTestCommit("tip", time.Now(), "tip")
+	mock := &MockRepository{
+		BranchesFunc: func(filters ...PathFilter) ([]Branch, error) {
+			return []Branch{
+				branchWithTip("main", &tip),
+				branchWithTip("develop", &tip),
+				branchWithTip("release/1.0", &tip),
+				branchWithTip("feature/auth", &tip),
+				branchWithTip("feature/other", &tip),
+				branchWithTip("pull/7/merge", &tip),
+				branchWithTip("orphan", nil),
+			}, nil
+		},
+	}
+
+	store := NewRepositoryStore(mock)
+	cfg, err := config.NewBuilder().Build()
+	require.NoError(t, err)
+
+	names := func(branches []Branch) []string {
+		var result []string
+		for _, b := range branches {
+			result = append(result, b.FriendlyName())
+		}
+		return result
+	}
+
+	// Feature branches consider all their source branches except themselves.
+	got, err := store.MergeBaseCandidates(branchWithTip("feature/auth", &tip), cfg)
+	require.NoError(t, err)
+	require.Equal(t, []string{"main", "develop", "release/1.0", "feature/other"}, names(got))
+
+	// Develop has no source branches but tracks release branches.
+	got, err = store.MergeBaseCandidates(branchWithTip("develop", &tip), cfg)
+	require.NoError(t, err)
+	require.Equal(t, []string{"release/1.0"}, names(got))
+
+	// Main's sources are develop and release.
+	got, err = store.MergeBaseCandidates(branchWithTip("main", &tip), cfg)
+	require.NoError(t, err)
+	require.Equal(t, []string{"develop", "release/1.0"}, names(got))
+
+	got, err = store.MergeBaseCandidates(branchWithTip("main", nil), cfg)
+	require.NoError(t, err)
+	require.Empty(t, got)
+}
+
 func TestFindCommitBranchWasBranchedFrom_NilTip(t *testing.T) {
 	store := NewRepositoryStore(&MockRepository{})
 	cfg, _ := config.NewBuilder().Build()
@@ -621,3 +669,41 @@ func TestFindCommitBranchWasBranchedFrom_NilTip(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, BranchCommit{}, bc)
 }
+
+func TestFindCommitBranchWasBranchedFrom_IsSourceBranchFor(t *testing.T) {
+	stagingTip := newTestCommit("staging-tip", time.Now(), "staging tip")
+	featureTip := newTestCommit("feat-tip", time.Now(), "feature tip")
+	forkPoint := newTestCommit("fork", time.Now().Add(-time.Hour), "fork")
+
+	mock := &MockRepository{
+		BranchesFunc: func(filters ...PathFilter) ([]Branch, error) {
+			return []Branch{
+				branchWithTip("staging", &stagingTip),
+				branchWithTip("feature/auth", &featureTip),
+			}, nil
+		},
+		FindMergeBaseFunc: func(sha1, sha2 string) (string, error) {
+			return "fork", nil
+		},
+		CommitFromShaFunc: func(sha string) (Commit, error) {
+			return forkPoint, nil
+		},
+	}
+
+	// The builder adds staging to feature's source-branches.
+	regex := `^staging$`
+	cfg, err := config.NewBuilder().Add(&config.Config{Branches: map[string]*config.BranchConfig{
+		"staging": {Regex: &regex, IsSourceBranchFor: &[]string{"feature"}},
+	}}).Build()
+	require.NoError(t, err)
+
+	store := NewRepositoryStore(mock)
+	bc, err := store.FindCommitBranchWasBranchedFrom(branchWithTip("feature/auth", &featureTip), cfg)
+	require.NoError(t, err)
+	require.Equal(t, "staging", bc.Branch.FriendlyName())
+
+	got, err := store.MergeBaseCandidates(branchWithTip("feature/auth", &featureTip), cfg)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	require.Equal(t, "staging", got[0].FriendlyName())
+}
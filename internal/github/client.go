@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/bradleyfalzon/ghinstallation/v2"
 	gh "github.com/google/go-github/v68/github"
@@ -37,17 +38,21 @@ type ClientConfig struct {
 
 	// Owner is the repository owner, used for auto-detecting the app installation.
 	Owner string
+
+	// Retry controls rate-limit handling and retries for transient failures.
+	Retry RetryConfig
 }
 
 // NewClient creates an authenticated GitHub API client.
 // Auth resolution order: Token → App key content → App key file → error.
-func NewClient(cfg ClientConfig) (*gh.Client, error) {
+// With GitHub App auth, ctx bounds the installation lookup.
+func NewClient(ctx context.Context, cfg ClientConfig) (*gh.Client, error) {
 	baseURL := resolveString(cfg.BaseURL, "GITHUB_API_URL")
 
 	// Try token auth first.
 	token := resolveString(cfg.Token, "GITHUB_TOKEN")
 	if token != "" {
-		return newTokenClient(token, baseURL)
+		return newTokenClient(token, baseURL, cfg.Retry)
 	}
 
 	// Try GitHub App auth.
@@ -63,21 +68,22 @@ func NewClient(cfg ClientConfig) (*gh.Client, error) {
 	// Try key content first (--github-app-key / GH_APP_PRIVATE_KEY).
 	appKey := resolveString(cfg.AppKey, "GH_APP_PRIVATE_KEY")
 	if appID != 0 && appKey != "" {
-		return newAppClientFromKey(appID, []byte(appKey), cfg.Owner, baseURL)
+		return newAppClientFromKey(ctx, appID, []byte(appKey), cfg.Owner, baseURL, cfg.Retry)
 	}
 
 	// Try key file path (--github-app-key-path / GH_APP_PRIVATE_KEY_PATH).
 	appKeyPath := resolveString(cfg.AppKeyPath, "GH_APP_PRIVATE_KEY_PATH")
 	if appID != 0 && appKeyPath != "" {
-		return newAppClientFromFile(appID, appKeyPath, cfg.Owner, baseURL)
+		return newAppClientFromFile(ctx, appID, appKeyPath, cfg.Owner, baseURL, cfg.Retry)
 	}
 
 	return nil, errors.New("no GitHub authentication provided: set GITHUB_TOKEN, use --token, or provide --github-app-id with --github-app-key or --github-app-key-path")
 }
 
-func newTokenClient(token, baseURL string) (*gh.Client, error) {
+func newTokenClient(token, baseURL string, retry RetryConfig) (*gh.Client, error) {
 	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
 	httpClient := oauth2.NewClient(context.Background(), ts)
+	httpClient.Transport = newRetryTransport(httpClient.Transport, retry)
 
 	if baseURL != "" {
 		return gh.NewClient(httpClient).WithEnterpriseURLs(baseURL, baseURL)
@@ -85,7 +91,7 @@ func newTokenClient(token, baseURL string) (*gh.Client, error) {
 	return gh.NewClient(httpClient), nil
 }
 
-func newAppClientFromFile(appID int64, keyPath, owner, baseURL string) (*gh.Client, error) {
+func newAppClientFromFile(ctx context.Context, appID int64, keyPath, owner, baseURL string, retry RetryConfig) (*gh.Client, error) {
 	// Create an app-level transport to discover the installation ID.
 	appTransport, err := ghinstallation.NewAppsTransportKeyFromFile(http.DefaultTransport, appID, keyPath)
 	if err != nil {
@@ -96,7 +102,7 @@ func newAppClientFromFile(appID int64, keyPath, owner, baseURL string) (*gh.Clie
 	}
 
 	// Find the installation for the target owner.
-	appClient := gh.NewClient(&http.Client{Transport: appTransport})
+	appClient := gh.NewClient(&http.Client{Transport: newRetryTransport(appTransport, retry)})
 	if baseURL != "" {
 		appClient, err = appClient.WithEnterpriseURLs(baseURL, baseURL)
 		if err != nil {
@@ -104,7 +110,7 @@ func newAppClientFromFile(appID int64, keyPath, owner, baseURL string) (*gh.Clie
 		}
 	}
 
-	installationID, err := findInstallation(appClient, owner)
+	installationID, err := findInstallation(ctx, appClient, owner)
 	if err != nil {
 		return nil, err
 	}
@@ -118,14 +124,14 @@ func newAppClientFromFile(appID int64, keyPath, owner, baseURL string) (*gh.Clie
 		installTransport.BaseURL = baseURL
 	}
 
-	client := gh.NewClient(&http.Client{Transport: installTransport})
+	client := gh.NewClient(&http.Client{Transport: newRetryTransport(installTransport, retry)})
 	if baseURL != "" {
 		return client.WithEnterpriseURLs(baseURL, baseURL)
 	}
 	return client, nil
 }
 
-func newAppClientFromKey(appID int64, key []byte, owner, baseURL string) (*gh.Client, error) {
+func newAppClientFromKey(ctx context.Context, appID int64, key []byte, owner, baseURL string, retry RetryConfig) (*gh.Client, error) {
 	// Create an app-level transport from key bytes.
 	appTransport, err := ghinstallation.NewAppsTransport(http.DefaultTransport, appID, key)
 	if err != nil {
@@ -136,7 +142,7 @@ func newAppClientFromKey(appID int64, key []byte, owner, baseURL string) (*gh.Cl
 	}
 
 	// Find the installation for the target owner.
-	appClient := gh.NewClient(&http.Client{Transport: appTransport})
+	appClient := gh.NewClient(&http.Client{Transport: newRetryTransport(appTransport, retry)})
 	if baseURL != "" {
 		appClient, err = appClient.WithEnterpriseURLs(baseURL, baseURL)
 		if err != nil {
@@ -144,7 +150,7 @@ func newAppClientFromKey(appID int64, key []byte, owner, baseURL string) (*gh.Cl
 		}
 	}
 
-	installationID, err := findInstallation(appClient, owner)
+	installationID, err := findInstallation(ctx, appClient, owner)
 	if err != nil {
 		return nil, err
 	}
@@ -158,7 +164,7 @@ func newAppClientFromKey(appID int64, key []byte, owner, baseURL string) (*gh.Cl
 		installTransport.BaseURL = baseURL
 	}
 
-	client := gh.NewClient(&http.Client{Transport: installTransport})
+	client := gh.NewClient(&http.Client{Transport: newRetryTransport(installTransport, retry)})
 	if baseURL != "" {
 		return client.WithEnterpriseURLs(baseURL, baseURL)
 	}
@@ -166,8 +172,7 @@ func newAppClientFromKey(appID int64, key []byte, owner, baseURL string) (*gh.Cl
 }
 
 // findInstallation finds the GitHub App installation for the given owner.
-func findInstallation(client *gh.Client, owner string) (int64, error) {
-	ctx := context.Background()
+func findInstallation(ctx context.Context, client *gh.Client, owner string) (int64, error) {
 	opts := &gh.ListOptions{PerPage: 100}
 
 	for {
@@ -218,3 +223,29 @@ func resolveString(flag, envKey string) string {
 func ResolveBaseURL(flagValue string) string {
 	return resolveString(flagValue, "GITHUB_API_URL")
 }
+
+// ParseRepositoryURL extracts owner and repository name from a git remote URL
+// in HTTPS ("https://github.com/owner/repo.git") or SSH
+// ("git@github.com:owner/repo.git", "ssh://git@host/owner/repo") form.
+func ParseRepositoryURL(url string) (string, string, error) {
+	path := url
+	switch {
+	case strings.Contains(path, "://"):
+		path = path[strings.Index(path, "://")+3:]
+		// Drop the host (and any credentials).
+		idx := strings.IndexByte(path, '/')
+		if idx < 0 {
+			return "", "", fmt.Errorf("cannot parse repository from URL %q", url)
+		}
+		path = path[idx+1:]
+	case strings.Contains(path, ":"):
+		path = path[strings.IndexByte(path, ':')+1:]
+	}
+
+	path = strings.TrimSuffix(strings.TrimSuffix(path, "/"), ".git")
+	parts := strings.Split(path, "/")
+	if len(parts) < 2 || parts[len(parts)-2] == "" || parts[len(parts)-1] == "" {
+		return "", "", fmt.Errorf("cannot parse repository from URL %q", url)
+	}
+	return parts[len(parts)-2], parts[len(parts)-1], nil
+}
@@ -2,6 +2,7 @@ package github
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -83,6 +84,33 @@ query($owner: String!, $name: String!, $cursor: String) {
 }
 `
 
+// GraphQL query to walk commit history from a revision in pages of 100,
+// including parent SHAs so no per-commit follow-up requests are needed.
+const historyQuery = `
+query($owner: String!, $name: String!, $expr: String!, $cursor: String, $path: String) {
+  repository(owner: $owner, name: $name) {
+    object(expression: $expr) {
+      ... on Commit {
+        history(first: 100, after: $cursor, path: $path) {
+          nodes {
+            oid
+            message
+            committedDate
+            parents(first: 10) {
+              nodes { oid }
+            }
+          }
+          pageInfo {
+            hasNextPage
+            endCursor
+          }
+        }
+      }
+    }
+  }
+}
+`
+
 // graphQL response types.
 
 type graphQLRequest struct {
@@ -96,6 +124,7 @@ type graphQLResponse struct {
 }
 
 type graphQLError struct {
+	Type    string `json:"type"`
 	Message string `json:"message"`
 }
 
@@ -131,13 +160,26 @@ type parentList struct {
 	} `json:"nodes"`
 }
 
+type historyResponse struct {
+	Repository struct {
+		Object *struct {
+			History *historyConnection `json:"history"`
+		} `json:"object"`
+	} `json:"repository"`
+}
+
+type historyConnection struct {
+	Nodes    []refTarget `json:"nodes"`
+	PageInfo pageInfo    `json:"pageInfo"`
+}
+
 type pageInfo struct {
 	HasNextPage bool   `json:"hasNextPage"`
 	EndCursor   string `json:"endCursor"`
 }
 
 // executeGraphQL sends a GraphQL query using the client's HTTP transport.
-func (r *GitHubRepository) executeGraphQL(query string, variables map[string]interface{}) (json.RawMessage, error) {
+func (r *GitHubRepository) executeGraphQL(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error) {
 	reqBody := graphQLRequest{
 		Query:     query,
 		Variables: variables,
@@ -152,7 +194,8 @@ func (r *GitHubRepository) executeGraphQL(query string, variables map[string]int
 		graphqlURL = deriveGraphQLURL(r.baseURL)
 	}
 
-	httpReq, err := http.NewRequestWithContext(r.ctx, http.MethodPost, graphqlURL, bytes.NewReader(bodyBytes))
+	// GraphQL queries are read-only, so the retry transport may replay them.
+	httpReq, err := http.NewRequestWithContext(withIdempotent(ctx), http.MethodPost, graphqlURL, bytes.NewReader(bodyBytes))
 	if err != nil {
 		return nil, fmt.Errorf("creating GraphQL request: %w", err)
 	}
@@ -179,6 +222,13 @@ func (r *GitHubRepository) executeGraphQL(query string, variables map[string]int
 	}
 
 	if len(resp.Errors) > 0 {
+		if resp.Errors[0].Type == "RATE_LIMITED" {
+			return nil, &RateLimitError{
+				StatusCode: httpResp.StatusCode,
+				Remaining:  headerInt(httpResp.Header, "X-RateLimit-Remaining", -1),
+				Reset:      resetTime(httpResp.Header),
+			}
+		}
 		return nil, fmt.Errorf("GraphQL error: %s", resp.Errors[0].Message)
 	}
 
@@ -186,7 +236,7 @@ func (r *GitHubRepository) executeGraphQL(query string, variables map[string]int
 }
 
 // fetchAllBranchesGraphQL fetches all branches with tip commit details via GraphQL.
-func (r *GitHubRepository) fetchAllBranchesGraphQL() ([]git.Branch, error) {
+func (r *GitHubRepository) fetchAllBranchesGraphQL(ctx context.Context) ([]git.Branch, error) {
 	var branches []git.Branch
 	var cursor *string
 
@@ -199,7 +249,7 @@ func (r *GitHubRepository) fetchAllBranchesGraphQL() ([]git.Branch, error) {
 			vars["cursor"] = *cursor
 		}
 
-		data, err := r.executeGraphQL(branchesQuery, vars)
+		data, err := r.executeGraphQL(ctx, branchesQuery, vars)
 		if err != nil {
 			return nil, fmt.Errorf("fetching branches via GraphQL: %w", err)
 		}
@@ -236,7 +286,7 @@ func (r *GitHubRepository) fetchAllBranchesGraphQL() ([]git.Branch, error) {
 
 // fetchAllTagsGraphQL fetches all tags with peel info via GraphQL.
 // Pre-populates the tagPeels cache for instant PeelTagToCommit lookups.
-func (r *GitHubRepository) fetchAllTagsGraphQL() ([]git.Tag, error) {
+func (r *GitHubRepository) fetchAllTagsGraphQL(ctx context.Context) ([]git.Tag, error) {
 	var tags []git.Tag
 	var cursor *string
 
@@ -249,7 +299,7 @@ func (r *GitHubRepository) fetchAllTagsGraphQL() ([]git.Tag, error) {
 			vars["cursor"] = *cursor
 		}
 
-		data, err := r.executeGraphQL(tagsQuery, vars)
+		data, err := r.executeGraphQL(ctx, tagsQuery, vars)
 		if err != nil {
 			return nil, fmt.Errorf("fetching tags via GraphQL: %w", err)
 		}
@@ -298,6 +348,86 @@ func (r *GitHubRepository) fetchAllTagsGraphQL() ([]git.Tag, error) {
 	return tags, nil
 }
 
+// fetchCommitHistoryGraphQL walks the history of to via GraphQL, stopping at
+// from (exclusive), after one buffer page past a version tag, or at maxCommits.
+// Mirrors commitLogPaginated but fetches parents in bulk.
+func (r *GitHubRepository) fetchCommitHistoryGraphQL(ctx context.Context, from, to string, filters ...git.PathFilter) ([]git.Commit, error) {
+	expr := to
+	if expr == "" {
+		expr = "HEAD"
+	}
+
+	vars := map[string]interface{}{
+		"owner": r.owner,
+		"name":  r.repo,
+		"expr":  expr,
+	}
+
+	// Apply path filter for monorepo support.
+	for _, f := range filters {
+		if f != "" {
+			vars["path"] = string(f)
+			break // history() only supports one path filter.
+		}
+	}
+
+	var commits []git.Commit
+	foundTag := false
+	bufferPages := 0
+
+	for {
+		data, err := r.executeGraphQL(ctx, historyQuery, vars)
+		if err != nil {
+			return nil, fmt.Errorf("fetching commit history via GraphQL: %w", err)
+		}
+
+		var resp historyResponse
+		if err := json.Unmarshal(data, &resp); err != nil {
+			return nil, fmt.Errorf("parsing commit history response: %w", err)
+		}
+		if resp.Repository.Object == nil || resp.Repository.Object.History == nil {
+			return nil, fmt.Errorf("revision %s not found or not a commit", expr)
+		}
+		history := resp.Repository.Object.History
+
+		for _, node := range history.Nodes {
+			// Stop if we've reached the 'from' boundary.
+			if from != "" && node.OID == from {
+				return commits, nil
+			}
+
+			commit := commitFromRefTarget(node)
+			r.cache.putCommit(commit)
+			commits = append(commits, commit)
+
+			// Check for early termination: is this commit tagged?
+			if r.versionTagSHAs[node.OID] {
+				foundTag = true
+			}
+		}
+
+		// Hard cap on total commits.
+		if len(commits) >= r.maxCommits {
+			break
+		}
+
+		// Smart early termination: if we found a tag, allow one more buffer page.
+		if foundTag {
+			bufferPages++
+			if bufferPages > 1 {
+				break
+			}
+		}
+
+		if !history.PageInfo.HasNextPage {
+			break
+		}
+		vars["cursor"] = history.PageInfo.EndCursor
+	}
+
+	return commits, nil
+}
+
 // deriveGraphQLURL converts a GitHub REST API base URL to the corresponding
 // GraphQL endpoint. For GitHub Enterprise, the REST base URL is typically
 // "https://ghe.example.com/api/v3" and the GraphQL endpoint is
@@ -0,0 +1,108 @@
+package github
+
+import (
+	"context"
+	"sync"
+
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/git"
+)
+
+const defaultConcurrency = 8
+
+// WithConcurrency sets the number of concurrent API requests used when
+// prefetching. Values of 1 or less disable prefetching.
+func WithConcurrency(n int) Option {
+	return func(r *GitHubRepository) { r.concurrency = n }
+}
+
+// Prefetch warms the cache with a bounded worker pool: tag peels (and the
+// peeled commits) for every tag, and merge bases between head and each of
+// branches. Later sequential lookups are then served from the cache, so
+// results are identical to an uncached run. Individual lookup failures are
+// ignored here and surface again when the value is actually requested.
+func (r *GitHubRepository) Prefetch(ctx context.Context, head git.Commit, branches []git.Branch) error {
+	if r.concurrency <= 1 {
+		return nil
+	}
+
+	tags, err := r.Tags(ctx)
+	if err != nil {
+		return err
+	}
+
+	// 1. Peel tags not already resolved by the GraphQL tags query.
+	var unpeeled []git.Tag
+	for _, tag := range tags {
+		if _, ok := r.cache.getTagPeel(tag.TargetSha); !ok {
+			unpeeled = append(unpeeled, tag)
+		}
+	}
+	r.forEach(ctx, len(unpeeled), func(i int) {
+		_, _ = r.PeelTagToCommit(ctx, unpeeled[i])
+	})
+
+	// 2. Load peeled commits missing from the cache.
+	var missing []string
+	seen := make(map[string]bool)
+	for _, tag := range tags {
+		sha, ok := r.cache.getTagPeel(tag.TargetSha)
+		if !ok || seen[sha] {
+			continue
+		}
+		seen[sha] = true
+		if _, ok := r.cache.getCommit(sha); !ok {
+			missing = append(missing, sha)
+		}
+	}
+	r.forEach(ctx, len(missing), func(i int) {
+		_, _ = r.CommitFromSha(ctx, missing[i])
+	})
+
+	// Tags peeled above now count for CommitLog early termination.
+	for _, tag := range unpeeled {
+		if !semverTagPattern.MatchString(tag.Name.Friendly) {
+			continue
+		}
+		if sha, ok := r.cache.getTagPeel(tag.TargetSha); ok {
+			r.versionTagSHAs[sha] = true
+		}
+	}
+
+	// 3. Merge bases between head and each candidate branch.
+	var tips []string
+	for _, b := range branches {
+		if b.Tip == nil || b.Tip.Sha == head.Sha {
+			continue
+		}
+		if _, ok := r.cache.getMergeBase(head.Sha, b.Tip.Sha); !ok {
+			tips = append(tips, b.Tip.Sha)
+		}
+	}
+	r.forEach(ctx, len(tips), func(i int) {
+		base, err := r.FindMergeBase(ctx, head.Sha, tips[i])
+		if err == nil && base != "" {
+			_, _ = r.CommitFromSha(ctx, base)
+		}
+	})
+
+	return ctx.Err()
+}
+
+// forEach calls fn for each index in [0, n) using at most r.concurrency
+// goroutines. Stops scheduling new work once the request context is done.
+func (r *GitHubRepository) forEach(ctx context.Context, n int, fn func(i int)) {
+	limit := max(r.concurrency, 1)
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	for i := range n {
+		if ctx.Err() != nil {
+			break
+		}
+		sem <- struct{}{}
+		wg.Go(func() {
+			defer func() { <-sem }()
+			fn(i)
+		})
+	}
+	wg.Wait()
+}
@@ -0,0 +1,192 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/git"
+
+	"github.com/stretchr/testify/require"
+)
+
+// inFlight tracks the peak number of concurrent requests seen by a handler.
+type inFlight struct {
+	cur, peak atomic.Int32
+}
+
+func (f *inFlight) enter() {
+	n := f.cur.Add(1)
+	for {
+		p := f.peak.Load()
+		if n <= p || f.peak.CompareAndSwap(p, n) {
+			break
+		}
+	}
+	// Hold the slot briefly so overlapping requests are observable.
+	time.Sleep(5 * time.Millisecond)
+}
+
+func (f *inFlight) leave() { f.cur.Add(-1) }
+
+func TestPrefetch_PeelsTagsAndMergeBases(t *testing.T) {
+	const numTags = 20
+	var flight inFlight
+	var peels, compares atomic.Int32
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v3/repos/testowner/testrepo/git/tags/", func(w http.ResponseWriter, r *http.Request) {
+		flight.enter()
+		defer flight.leave()
+		peels.Add(1)
+		tagSha := strings.TrimPrefix(r.URL.Path, "/api/v3/repos/testowner/testrepo/git/tags/")
+		writeJSON(w, map[string]interface{}{
+			"sha":    tagSha,
+			"object": map[string]interface{}{"sha": "commit_" + tagSha, "type": "commit"},
+		})
+	})
+	mux.HandleFunc("/api/v3/repos/testowner/testrepo/commits/", func(w http.ResponseWriter, r *http.Request) {
+		sha := strings.TrimPrefix(r.URL.Path, "/api/v3/repos/testowner/testrepo/commits/")
+		writeJSON(w, map[string]interface{}{
+			"sha":     sha,
+			"commit":  map[string]interface{}{"message": sha, "committer": map[string]interface{}{"date": "2025-01-01T00:00:00Z"}},
+			"parents": []map[string]interface{}{},
+		})
+	})
+	mux.HandleFunc("/api/v3/repos/testowner/testrepo/compare/", func(w http.ResponseWriter, r *http.Request) {
+		flight.enter()
+		defer flight.leave()
+		compares.Add(1)
+		writeJSON(w, map[string]interface{}{
+			"merge_base_commit": map[string]interface{}{"sha": "base"},
+		})
+	})
+
+	repo, cleanup := newTestRepo(t, mux, WithConcurrency(4))
+	defer cleanup()
+
+	var tags []git.Tag
+	for i := 0; i < numTags; i++ {
+		tags = append(tags, git.Tag{
+			Name:      git.NewReferenceName(fmt.Sprintf("refs/tags/v1.0.%d", i)),
+			TargetSha: fmt.Sprintf("tag%d", i),
+		})
+	}
+	repo.cache.putTags(tags)
+
+	head := git.Commit{Sha: "head"}
+	branches := []git.Branch{
+		{Name: git.NewBranchReferenceName("develop"), Tip: &git.Commit{Sha: "dev"}},
+		{Name: git.NewBranchReferenceName("release/1.0"), Tip: &git.Commit{Sha: "rel"}},
+		{Name: git.NewBranchReferenceName("main"), Tip: &head}, // same tip: skipped
+		{Name: git.NewBranchReferenceName("orphan")},           // nil tip: skipped
+	}
+
+	require.NoError(t, repo.Prefetch(context.Background(), head, branches))
+	require.Equal(t, int32(numTags), peels.Load())
+	require.Equal(t, int32(2), compares.Load())
+	require.LessOrEqual(t, flight.peak.Load(), int32(4))
+	require.Greater(t, flight.peak.Load(), int32(1))
+
+	for _, tag := range tags {
+		sha, ok := repo.cache.getTagPeel(tag.TargetSha)
+		require.True(t, ok)
+		require.Equal(t, "commit_"+tag.TargetSha, sha)
+		_, ok = repo.cache.getCommit(sha)
+		require.True(t, ok)
+		require.True(t, repo.versionTagSHAs[sha])
+	}
+
+	base, ok := repo.cache.getMergeBase("rel", "head")
+	require.True(t, ok)
+	require.Equal(t, "base", base)
+	_, ok = repo.cache.getCommit("base")
+	require.True(t, ok)
+
+	// A second prefetch is served entirely from cache.
+	require.NoError(t, repo.Prefetch(context.Background(), head, branches))
+	require.Equal(t, int32(numTags), peels.Load())
+	require.Equal(t, int32(2), compares.Load())
+}
+
+func TestPrefetch_ConcurrencyOneDisables(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s", r.URL.Path)
+	})
+
+	repo, cleanup := newTestRepo(t, mux, WithConcurrency(1))
+	defer cleanup()
+
+	head := git.Commit{Sha: "head"}
+	require.NoError(t, repo.Prefetch(context.Background(), head, []git.Branch{
+		{Name: git.NewBranchReferenceName("develop"), Tip: &git.Commit{Sha: "dev"}},
+	}))
+}
+
+func TestBranchesContainingCommit_ConcurrentKeepsOrder(t *testing.T) {
+	mux := http.NewServeMux()
+	var mu sync.Mutex
+	var seen []string
+
+	mux.HandleFunc("/api/v3/repos/testowner/testrepo/compare/", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		seen = append(seen, r.URL.Path)
+		mu.Unlock()
+		status := "behind"
+		if strings.HasSuffix(r.URL.Path, "even") {
+			status = "ahead"
+		}
+		writeJSON(w, map[string]interface{}{"status": status})
+	})
+
+	repo, cleanup := newTestRepo(t, mux, WithConcurrency(8))
+	defer cleanup()
+
+	var branches []git.Branch
+	var want []string
+	for i := 0; i < 16; i++ {
+		suffix := "odd"
+		if i%2 == 0 {
+			suffix = "even"
+		}
+		name := fmt.Sprintf("b%02d", i)
+		branches = append(branches, git.Branch{
+			Name: git.NewBranchReferenceName(name),
+			Tip:  &git.Commit{Sha: fmt.Sprintf("tip%02d%s", i, suffix)},
+		})
+		if suffix == "even" {
+			want = append(want, name)
+		}
+	}
+	repo.cache.putBranches(branches)
+
+	result, err := repo.BranchesContainingCommit(context.Background(), "target")
+	require.NoError(t, err)
+
+	var got []string
+	for _, b := range result {
+		got = append(got, b.FriendlyName())
+	}
+	require.Equal(t, want, got)
+	require.Len(t, seen, 16)
+}
+
+func TestBranchesContainingCommit_Cancelled(t *testing.T) {
+	repo, cleanup := newTestRepo(t, http.NewServeMux())
+	defer cleanup()
+	repo.cache.putBranches([]git.Branch{
+		{Name: git.NewBranchReferenceName("main"), Tip: &git.Commit{Sha: "tip"}},
+	})
+
+	// Unreachable branches are skipped, but cancellation is not hidden.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := repo.BranchesContainingCommit(ctx, "target")
+	require.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,55 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	gh "github.com/google/go-github/v68/github"
+)
+
+// PublishContext is the commit status context and check run name used when
+// publishing the calculated version.
+const PublishContext = "go-gitsemver"
+
+// maxStatusDescription is GitHub's limit on commit status descriptions, in
+// characters.
+const maxStatusDescription = 140
+
+// CreateCommitStatus publishes description as a successful commit status on sha.
+// Descriptions longer than GitHub's limit are truncated.
+func (r *GitHubRepository) CreateCommitStatus(ctx context.Context, sha, description string) error {
+	if runes := []rune(description); len(runes) > maxStatusDescription {
+		description = string(runes[:maxStatusDescription-3]) + "..."
+	}
+
+	status := &gh.RepoStatus{
+		State:       gh.Ptr("success"),
+		Description: gh.Ptr(description),
+		Context:     gh.Ptr(PublishContext),
+	}
+	if _, _, err := r.client.Repositories.CreateStatus(ctx, r.owner, r.repo, sha, status); err != nil {
+		return fmt.Errorf("creating commit status on %s: %w", sha, err)
+	}
+	return nil
+}
+
+// CreateCheckRun publishes a completed, neutral check run on sha with the given
+// title, summary, and details text (Markdown). Check runs require GitHub App
+// authentication.
+func (r *GitHubRepository) CreateCheckRun(ctx context.Context, sha, title, summary, text string) error {
+	opts := gh.CreateCheckRunOptions{
+		Name:       PublishContext,
+		HeadSHA:    sha,
+		Status:     gh.Ptr("completed"),
+		Conclusion: gh.Ptr("neutral"),
+		Output: &gh.CheckRunOutput{
+			Title:   gh.Ptr(title),
+			Summary: gh.Ptr(summary),
+			Text:    gh.Ptr(text),
+		},
+	}
+	if _, _, err := r.client.Checks.CreateCheckRun(ctx, r.owner, r.repo, opts); err != nil {
+		return fmt.Errorf("creating check run on %s: %w", sha, err)
+	}
+	return nil
+}
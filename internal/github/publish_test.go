@@ -0,0 +1,107 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateCommitStatus(t *testing.T) {
+	var got map[string]interface{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v3/repos/testowner/testrepo/statuses/abc123", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		w.WriteHeader(http.StatusCreated)
+		writeJSON(w, map[string]interface{}{"state": "success"})
+	})
+
+	repo, cleanup := newTestRepo(t, mux)
+	defer cleanup()
+
+	require.NoError(t, repo.CreateCommitStatus(context.Background(), "abc123", "Will release 2.0.0 (breaking change in abc1234)"))
+	require.Equal(t, "success", got["state"])
+	require.Equal(t, PublishContext, got["context"])
+	require.Equal(t, "Will release 2.0.0 (breaking change in abc1234)", got["description"])
+}
+
+func TestCreateCommitStatus_TruncatesDescription(t *testing.T) {
+	var got map[string]interface{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v3/repos/testowner/testrepo/statuses/abc123", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		w.WriteHeader(http.StatusCreated)
+		writeJSON(w, map[string]interface{}{})
+	})
+
+	repo, cleanup := newTestRepo(t, mux)
+	defer cleanup()
+
+	require.NoError(t, repo.CreateCommitStatus(context.Background(), "abc123", strings.Repeat("x", 200)))
+	desc := got["description"].(string)
+	require.Len(t, desc, maxStatusDescription)
+	require.True(t, strings.HasSuffix(desc, "..."))
+}
+
+func TestCreateCommitStatus_TruncatesByCharacter(t *testing.T) {
+	var got map[string]interface{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v3/repos/testowner/testrepo/statuses/abc123", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		w.WriteHeader(http.StatusCreated)
+		writeJSON(w, map[string]interface{}{})
+	})
+
+	repo, cleanup := newTestRepo(t, mux)
+	defer cleanup()
+
+	require.NoError(t, repo.CreateCommitStatus(context.Background(), "abc123", "Will release 2.0.0 ("+strings.Repeat("ü", 200)+")"))
+	desc := got["description"].(string)
+	require.True(t, utf8.ValidString(desc))
+	require.Equal(t, maxStatusDescription, utf8.RuneCountInString(desc))
+	require.True(t, strings.HasSuffix(desc, "ü..."))
+}
+
+func TestCreateCommitStatus_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v3/repos/testowner/testrepo/statuses/abc123", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		writeJSON(w, map[string]interface{}{"message": "Not Found"})
+	})
+
+	repo, cleanup := newTestRepo(t, mux)
+	defer cleanup()
+
+	err := repo.CreateCommitStatus(context.Background(), "abc123", "Will release 1.0.0")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "creating commit status on abc123")
+}
+
+func TestCreateCheckRun(t *testing.T) {
+	var got map[string]interface{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v3/repos/testowner/testrepo/check-runs", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		w.WriteHeader(http.StatusCreated)
+		writeJSON(w, map[string]interface{}{"id": 1})
+	})
+
+	repo, cleanup := newTestRepo(t, mux)
+	defer cleanup()
+
+	require.NoError(t, repo.CreateCheckRun(context.Background(), "abc123", "2.0.0", "Will release 2.0.0", "details"))
+	require.Equal(t, PublishContext, got["name"])
+	require.Equal(t, "abc123", got["head_sha"])
+	require.Equal(t, "completed", got["status"])
+	require.Equal(t, "neutral", got["conclusion"])
+	out := got["output"].(map[string]interface{})
+	require.Equal(t, "2.0.0", out["title"])
+	require.Equal(t, "Will release 2.0.0", out["summary"])
+	require.Equal(t, "details", out["text"])
+}
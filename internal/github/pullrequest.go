@@ -0,0 +1,156 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/git"
+)
+
+// WithPullRequest versions pull request n instead of a ref. HEAD becomes the
+// branch "pull/<n>/merge" at the PR head commit, and that commit is treated
+// as if it were merged into the PR base.
+func WithPullRequest(n int) Option {
+	return func(r *GitHubRepository) { r.pullRequest = n }
+}
+
+// pullRequestInfo holds the resolved refs of the pull request being versioned.
+type pullRequestInfo struct {
+	number  int
+	baseRef string
+	baseSha string
+	realSha string     // head commit as it exists in the repository
+	head    git.Commit // head commit with the base tip prepended to its parents
+}
+
+// PullRequestBranchName returns the branch name used for pull request n.
+func PullRequestBranchName(n int) string {
+	return fmt.Sprintf("pull/%d/merge", n)
+}
+
+// PullRequestHead resolves the pull request and returns its head commit SHA.
+// Returns an empty string when no pull request is configured.
+func (r *GitHubRepository) PullRequestHead(ctx context.Context) (string, error) {
+	if r.pullRequest <= 0 {
+		return "", nil
+	}
+	pr, err := r.resolvePullRequest(ctx)
+	if err != nil {
+		return "", err
+	}
+	return pr.realSha, nil
+}
+
+// resolvePullRequest fetches the pull request once and builds the virtual
+// merged head commit.
+func (r *GitHubRepository) resolvePullRequest(ctx context.Context) (*pullRequestInfo, error) {
+	if r.pr != nil {
+		return r.pr, nil
+	}
+
+	ghPR, _, err := r.client.PullRequests.Get(ctx, r.owner, r.repo, r.pullRequest)
+	if err != nil {
+		return nil, fmt.Errorf("getting pull request #%d: %w", r.pullRequest, err)
+	}
+	headSha := ghPR.GetHead().GetSHA()
+	baseRef := ghPR.GetBase().GetRef()
+	if headSha == "" || baseRef == "" {
+		return nil, fmt.Errorf("pull request #%d has no head or base", r.pullRequest)
+	}
+
+	// Prefer the current base branch tip over the PR's recorded base SHA,
+	// which can lag behind the branch.
+	baseSha := ghPR.GetBase().GetSHA()
+	branches, err := r.Branches(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range branches {
+		if b.FriendlyName() == baseRef && b.Tip != nil {
+			baseSha = b.Tip.Sha
+			break
+		}
+	}
+
+	head, err := r.CommitFromSha(ctx, headSha)
+	if err != nil {
+		return nil, fmt.Errorf("getting pull request head: %w", err)
+	}
+
+	// Pretend the head commit is the merge of itself into base: the base tip
+	// becomes its first parent so history and mainline walks follow the base.
+	parents := []string{baseSha}
+	for _, p := range head.Parents {
+		if p != baseSha {
+			parents = append(parents, p)
+		}
+	}
+	head.Parents = parents
+
+	r.pr = &pullRequestInfo{
+		number:  r.pullRequest,
+		baseRef: baseRef,
+		baseSha: baseSha,
+		realSha: headSha,
+		head:    head,
+	}
+	return r.pr, nil
+}
+
+// pullRequestHeadBranch returns the virtual "pull/<n>/merge" branch.
+func (r *GitHubRepository) pullRequestHeadBranch(ctx context.Context) (git.Branch, error) {
+	pr, err := r.resolvePullRequest(ctx)
+	if err != nil {
+		return git.Branch{}, err
+	}
+	tip := pr.head
+	return git.Branch{
+		Name:     git.NewBranchReferenceName(PullRequestBranchName(pr.number)),
+		Tip:      &tip,
+		IsRemote: false,
+	}, nil
+}
+
+// pullRequestCommitLog returns the history of the virtual merged head: the
+// union of the real head history and the base history, newest first, with
+// the merged head pinned at the front.
+func (r *GitHubRepository) pullRequestCommitLog(ctx context.Context, pr *pullRequestInfo, from string, filters ...git.PathFilter) ([]git.Commit, error) {
+	headLog, err := r.commitLogUncached(ctx, from, pr.realSha, filters...)
+	if err != nil {
+		return nil, err
+	}
+	baseLog, err := r.commitLogUncached(ctx, from, pr.baseSha, filters...)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{pr.realSha: true}
+	var rest []git.Commit
+	includeHead := false
+	for _, c := range headLog {
+		if c.Sha == pr.realSha {
+			includeHead = true
+			continue
+		}
+		if !seen[c.Sha] {
+			seen[c.Sha] = true
+			rest = append(rest, c)
+		}
+	}
+	for _, c := range baseLog {
+		if !seen[c.Sha] {
+			seen[c.Sha] = true
+			rest = append(rest, c)
+		}
+	}
+	sort.SliceStable(rest, func(i, j int) bool {
+		return rest[i].When.After(rest[j].When)
+	})
+
+	if !includeHead {
+		// The head commit was excluded by a path filter.
+		return rest, nil
+	}
+	return append([]git.Commit{pr.head}, rest...), nil
+}
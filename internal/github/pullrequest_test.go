@@ -0,0 +1,156 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newPullRequestMux mocks a PR #123 whose head "feat2" forked from "base1"
+// on main, while main has since advanced to "main2".
+//
+//	main:  base0 ← base1 ← main2
+//	PR:             base1 ← feat1 ← feat2
+func newPullRequestMux(t *testing.T) (*http.ServeMux, *[]string) {
+	t.Helper()
+	var contentRefs []string
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/api/v3/repos/testowner/testrepo/pulls/123", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]interface{}{
+			"number": 123,
+			"head":   map[string]interface{}{"ref": "feature/login", "sha": "feat2"},
+			"base":   map[string]interface{}{"ref": "main", "sha": "base1"},
+		})
+	})
+	mux.HandleFunc("/api/v3/repos/testowner/testrepo/commits/feat2", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]interface{}{
+			"sha":     "feat2",
+			"commit":  map[string]interface{}{"message": "feat: login form", "committer": map[string]interface{}{"date": "2025-01-04T00:00:00Z"}},
+			"parents": []map[string]interface{}{{"sha": "feat1"}},
+		})
+	})
+	mux.HandleFunc("/api/v3/repos/testowner/testrepo/contents/", func(w http.ResponseWriter, r *http.Request) {
+		contentRefs = append(contentRefs, r.URL.Query().Get("ref"))
+		writeJSON(w, map[string]interface{}{
+			"type":     "file",
+			"encoding": "base64",
+			"content":  "bW9kZTogTWFpbmxpbmUK", // "mode: Mainline\n"
+		})
+	})
+
+	node := func(sha, date string, parents ...string) map[string]interface{} {
+		n := historyNode(sha, parents...)
+		n["committedDate"] = date
+		return n
+	}
+	mux.HandleFunc("/graphql", func(w http.ResponseWriter, r *http.Request) {
+		var body graphQLRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		switch {
+		case strings.Contains(body.Query, "refs/heads/"):
+			writeJSON(w, graphQLBranchesResponse([]map[string]interface{}{
+				{"name": "main", "target": node("main2", "2025-01-05T00:00:00Z", "base1")},
+			}, false, ""))
+		case body.Variables["expr"] == "feat2":
+			writeJSON(w, graphQLHistoryResponse([]map[string]interface{}{
+				node("feat2", "2025-01-04T00:00:00Z", "feat1"),
+				node("feat1", "2025-01-03T00:00:00Z", "base1"),
+				node("base1", "2025-01-02T00:00:00Z", "base0"),
+				node("base0", "2025-01-01T00:00:00Z"),
+			}, false, ""))
+		case body.Variables["expr"] == "main2":
+			writeJSON(w, graphQLHistoryResponse([]map[string]interface{}{
+				node("main2", "2025-01-05T00:00:00Z", "base1"),
+				node("base1", "2025-01-02T00:00:00Z", "base0"),
+				node("base0", "2025-01-01T00:00:00Z"),
+			}, false, ""))
+		default:
+			t.Errorf("unexpected GraphQL request: %v", body.Variables)
+		}
+	})
+
+	return mux, &contentRefs
+}
+
+func TestPullRequest_Head(t *testing.T) {
+	mux, _ := newPullRequestMux(t)
+	repo, _, cleanup := newTestRepoWithGraphQL(t, mux, WithPullRequest(123))
+	defer cleanup()
+
+	head, err := repo.Head(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "pull/123/merge", head.FriendlyName())
+	require.False(t, head.IsDetachedHead)
+	require.Equal(t, "feat2", head.Tip.Sha)
+	// Base tip is the first parent, as in a merge into main.
+	require.Equal(t, []string{"main2", "feat1"}, head.Tip.Parents)
+
+	sha, err := repo.PullRequestHead(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "feat2", sha)
+
+	// CommitFromSha returns the merged form of the head.
+	c, err := repo.CommitFromSha(context.Background(), "feat2")
+	require.NoError(t, err)
+	require.Equal(t, head.Tip.Parents, c.Parents)
+}
+
+func TestPullRequest_CommitLogIncludesBase(t *testing.T) {
+	mux, _ := newPullRequestMux(t)
+	repo, _, cleanup := newTestRepoWithGraphQL(t, mux, WithPullRequest(123))
+	defer cleanup()
+
+	_, err := repo.Head(context.Background())
+	require.NoError(t, err)
+
+	commits, err := repo.CommitLog(context.Background(), "", "feat2")
+	require.NoError(t, err)
+	var shas []string
+	for _, c := range commits {
+		shas = append(shas, c.Sha)
+	}
+	require.Equal(t, []string{"feat2", "main2", "feat1", "base1", "base0"}, shas)
+
+	mainline, err := repo.MainlineCommitLog(context.Background(), "", "feat2")
+	require.NoError(t, err)
+	shas = nil
+	for _, c := range mainline {
+		shas = append(shas, c.Sha)
+	}
+	require.Equal(t, []string{"feat2", "main2", "base1", "base0"}, shas)
+}
+
+func TestPullRequest_FetchFileContentUsesHead(t *testing.T) {
+	mux, refs := newPullRequestMux(t)
+	repo, _, cleanup := newTestRepoWithGraphQL(t, mux, WithPullRequest(123))
+	defer cleanup()
+
+	content, err := repo.FetchFileContent(context.Background(), "go-gitsemver.yml")
+	require.NoError(t, err)
+	require.Equal(t, "mode: Mainline\n", content)
+	require.Equal(t, []string{"feat2"}, *refs)
+}
+
+func TestPullRequest_NotFound(t *testing.T) {
+	mux := http.NewServeMux()
+	repo, _, cleanup := newTestRepoWithGraphQL(t, mux, WithPullRequest(999))
+	defer cleanup()
+
+	_, err := repo.Head(context.Background())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "pull request #999")
+}
+
+func TestPullRequestHead_NoPullRequest(t *testing.T) {
+	repo := NewGitHubRepository(nil, "o", "r")
+	sha, err := repo.PullRequestHead(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, sha)
+	require.Equal(t, "pull/7/merge", PullRequestBranchName(7))
+}
@@ -0,0 +1,319 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultMaxRetries   = 5
+	defaultMaxRetryWait = 2 * time.Minute
+	defaultBaseBackoff  = time.Second
+	maxBackoff          = 30 * time.Second
+
+	// secondaryRateLimitWait is the minimum wait GitHub recommends after a
+	// secondary rate limit response that carries no Retry-After header.
+	secondaryRateLimitWait = time.Minute
+)
+
+// RetryConfig controls how the GitHub transport retries rate-limited and
+// transiently failing requests.
+type RetryConfig struct {
+	// MaxRetries is the maximum number of retries per request.
+	// Defaults to 5 when zero. Negative disables retries.
+	MaxRetries int
+
+	// MaxWait is the total time the transport may spend waiting across all
+	// requests in a run before giving up with a RateLimitError.
+	// Defaults to 2 minutes when zero.
+	MaxWait time.Duration
+}
+
+// RateLimitError is returned when a request is still rate limited after the
+// retry budget is exhausted.
+type RateLimitError struct {
+	StatusCode int
+	Remaining  int       // -1 when the response carried no quota header
+	Reset      time.Time // zero when the response carried no reset header
+	Waited     time.Duration
+	Secondary  bool
+}
+
+func (e *RateLimitError) Error() string {
+	var b strings.Builder
+	if e.Secondary {
+		b.WriteString("GitHub secondary rate limit exceeded")
+	} else {
+		fmt.Fprintf(&b, "GitHub API request failed with status %d", e.StatusCode)
+	}
+	if e.Remaining >= 0 {
+		fmt.Fprintf(&b, ", remaining quota %d", e.Remaining)
+	}
+	if !e.Reset.IsZero() {
+		fmt.Fprintf(&b, ", resets at %s", e.Reset.UTC().Format(time.RFC3339))
+	}
+	fmt.Fprintf(&b, " (waited %s of retry budget)", e.Waited.Round(time.Second))
+	return b.String()
+}
+
+// idempotentKey marks a non-GET request as safe to retry.
+type idempotentKey struct{}
+
+// withIdempotent marks requests made with ctx as safe to retry even when the
+// HTTP method is not idempotent (e.g. read-only GraphQL queries sent via POST).
+func withIdempotent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, idempotentKey{}, true)
+}
+
+// retryTransport is an http.RoundTripper that honors GitHub rate-limit headers
+// and retries idempotent requests with jittered exponential backoff.
+type retryTransport struct {
+	base       http.RoundTripper
+	maxRetries int
+	maxWait    time.Duration
+
+	// now and sleep are replaceable for tests.
+	now   func() time.Time
+	sleep func(ctx context.Context, d time.Duration) error
+
+	mu     sync.Mutex
+	waited time.Duration
+}
+
+// newRetryTransport wraps base with rate-limit aware retries.
+func newRetryTransport(base http.RoundTripper, cfg RetryConfig) *retryTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	maxRetries := cfg.MaxRetries
+	if maxRetries == 0 {
+		maxRetries = defaultMaxRetries
+	}
+	maxWait := cfg.MaxWait
+	if maxWait == 0 {
+		maxWait = defaultMaxRetryWait
+	}
+	return &retryTransport{
+		base:       base,
+		maxRetries: maxRetries,
+		maxWait:    maxWait,
+		now:        time.Now,
+		sleep:      sleepContext,
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !isIdempotent(req) {
+		return t.base.RoundTrip(req)
+	}
+
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("rewinding request body: %w", err)
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+
+		resp, err := t.base.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+
+		wait, rl, retryable := t.classify(resp, attempt)
+		if !retryable {
+			return resp, nil
+		}
+		if attempt >= t.maxRetries || !t.reserve(wait) {
+			if rl == nil {
+				return resp, nil
+			}
+			drain(resp)
+			rl.Waited = t.totalWaited()
+			return nil, rl
+		}
+
+		drain(resp)
+		if err := t.sleep(req.Context(), wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// classify decides whether resp should be retried and for how long to wait.
+// The returned RateLimitError is non-nil for rate-limit responses and is
+// surfaced to the caller when the budget runs out.
+func (t *retryTransport) classify(resp *http.Response, attempt int) (time.Duration, *RateLimitError, bool) {
+	status := resp.StatusCode
+	remaining := headerInt(resp.Header, "X-RateLimit-Remaining", -1)
+	reset := resetTime(resp.Header)
+	retryAfter, hasRetryAfter := parseRetryAfter(resp.Header.Get("Retry-After"), t.now())
+
+	switch {
+	case status == http.StatusForbidden || status == http.StatusTooManyRequests:
+		rl := &RateLimitError{StatusCode: status, Remaining: remaining, Reset: reset}
+		switch {
+		case hasRetryAfter:
+			rl.Secondary = remaining != 0
+			return retryAfter, rl, true
+		case remaining == 0 && !reset.IsZero():
+			wait := reset.Sub(t.now()) + time.Second
+			if wait < 0 {
+				wait = 0
+			}
+			return wait, rl, true
+		case status == http.StatusTooManyRequests || isSecondaryRateLimit(resp):
+			rl.Secondary = true
+			wait := secondaryRateLimitWait
+			if b := t.backoff(attempt); b > wait {
+				wait = b
+			}
+			return wait, rl, true
+		}
+		// A plain 403 is a permission error, not a rate limit.
+		return 0, nil, false
+
+	case status >= http.StatusInternalServerError && status != http.StatusNotImplemented:
+		if hasRetryAfter {
+			return retryAfter, nil, true
+		}
+		return t.backoff(attempt), nil, true
+	}
+
+	return 0, nil, false
+}
+
+// backoff returns a full-jitter exponential backoff for the given attempt.
+func (t *retryTransport) backoff(attempt int) time.Duration {
+	ceiling := defaultBaseBackoff << attempt
+	if ceiling <= 0 || ceiling > maxBackoff {
+		ceiling = maxBackoff
+	}
+	return ceiling/2 + rand.N(ceiling/2+1)
+}
+
+// reserve records d against the wait budget. Returns false if the budget
+// would be exceeded.
+func (t *retryTransport) reserve(d time.Duration) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.waited+d > t.maxWait {
+		return false
+	}
+	t.waited += d
+	return true
+}
+
+func (t *retryTransport) totalWaited() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.waited
+}
+
+// isIdempotent reports whether req can be safely replayed.
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	marked, _ := req.Context().Value(idempotentKey{}).(bool)
+	return marked && (req.Body == nil || req.GetBody != nil)
+}
+
+// isSecondaryRateLimit peeks at the response body for GitHub's secondary
+// rate limit message. The body is restored so callers can still read it.
+func isSecondaryRateLimit(resp *http.Response) bool {
+	if resp.Body == nil {
+		return false
+	}
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(strings.NewReader(string(data)))
+	if err != nil {
+		return false
+	}
+	msg := strings.ToLower(string(data))
+	return strings.Contains(msg, "secondary rate limit") || strings.Contains(msg, "abuse detection")
+}
+
+// parseRetryAfter parses a Retry-After header in either delay-seconds or
+// HTTP-date form.
+func parseRetryAfter(v string, now time.Time) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			secs = 0
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if at, err := http.ParseTime(v); err == nil {
+		d := at.Sub(now)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+func headerInt(h http.Header, key string, fallback int) int {
+	v := h.Get(key)
+	if v == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return fallback
+	}
+	return n
+}
+
+// resetTime returns the quota reset time from X-RateLimit-Reset, or the zero
+// time if the header is absent.
+func resetTime(h http.Header) time.Time {
+	if s := headerInt(h, "X-RateLimit-Reset", 0); s > 0 {
+		return time.Unix(int64(s), 0)
+	}
+	return time.Time{}
+}
+
+// drain discards and closes the response body so the connection can be reused.
+func drain(resp *http.Response) {
+	if resp.Body == nil {
+		return
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// IsRateLimitError reports whether err is a RateLimitError.
+func IsRateLimitError(err error) bool {
+	var rl *RateLimitError
+	return errors.As(err, &rl)
+}
@@ -0,0 +1,296 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRetryTransport creates a retryTransport that records sleeps instead of blocking.
+func newTestRetryTransport(cfg RetryConfig, now time.Time) (*retryTransport, *[]time.Duration) {
+	var slept []time.Duration
+	rt := newRetryTransport(http.DefaultTransport, cfg)
+	rt.now = func() time.Time { return now }
+	rt.sleep = func(_ context.Context, d time.Duration) error {
+		slept = append(slept, d)
+		return nil
+	}
+	return rt, &slept
+}
+
+func TestRetryTransport_RetriesServerErrors(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			http.Error(w, "unavailable", http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	rt, slept := newTestRetryTransport(RetryConfig{}, time.Now())
+	client := &http.Client{Transport: rt}
+
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, int32(3), calls.Load())
+	require.Len(t, *slept, 2)
+	for _, d := range *slept {
+		require.LessOrEqual(t, d, maxBackoff)
+	}
+}
+
+func TestRetryTransport_HonorsRetryAfter(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "7")
+			http.Error(w, `{"message":"You have exceeded a secondary rate limit"}`, http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	rt, slept := newTestRetryTransport(RetryConfig{}, time.Now())
+	resp, err := (&http.Client{Transport: rt}).Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, []time.Duration{7 * time.Second}, *slept)
+}
+
+func TestRetryTransport_WaitsForRateLimitReset(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(now.Add(20*time.Second).Unix(), 10))
+			http.Error(w, `{"message":"API rate limit exceeded"}`, http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	rt, slept := newTestRetryTransport(RetryConfig{}, now)
+	resp, err := (&http.Client{Transport: rt}).Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, []time.Duration{21 * time.Second}, *slept)
+}
+
+func TestRetryTransport_BudgetExceeded(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	reset := now.Add(time.Hour)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+		http.Error(w, `{"message":"API rate limit exceeded"}`, http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	rt, slept := newTestRetryTransport(RetryConfig{MaxWait: time.Minute}, now)
+	_, err := (&http.Client{Transport: rt}).Get(server.URL)
+	require.Error(t, err)
+	require.Empty(t, *slept)
+
+	var rl *RateLimitError
+	require.True(t, errors.As(err, &rl))
+	require.Equal(t, 0, rl.Remaining)
+	require.Equal(t, reset.Unix(), rl.Reset.Unix())
+	require.Contains(t, err.Error(), "remaining quota 0")
+	require.Contains(t, err.Error(), "resets at")
+	require.True(t, IsRateLimitError(err))
+}
+
+func TestRetryTransport_SecondaryRateLimitWithoutRetryAfter(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			http.Error(w, `{"message":"You have exceeded a secondary rate limit."}`, http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	rt, slept := newTestRetryTransport(RetryConfig{}, time.Now())
+	resp, err := (&http.Client{Transport: rt}).Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, []time.Duration{secondaryRateLimitWait}, *slept)
+}
+
+func TestRetryTransport_PlainForbiddenNotRetried(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		http.Error(w, `{"message":"Resource not accessible by integration"}`, http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	rt, slept := newTestRetryTransport(RetryConfig{}, time.Now())
+	resp, err := (&http.Client{Transport: rt}).Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusForbidden, resp.StatusCode)
+	require.Equal(t, int32(1), calls.Load())
+	require.Empty(t, *slept)
+
+	// Body must still be readable after classification peeked at it.
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Contains(t, string(body), "Resource not accessible")
+}
+
+func TestRetryTransport_NonIdempotentNotRetried(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	rt, _ := newTestRetryTransport(RetryConfig{}, time.Now())
+	resp, err := (&http.Client{Transport: rt}).Post(server.URL, "application/json", bytes.NewReader([]byte("{}")))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, int32(1), calls.Load())
+}
+
+func TestRetryTransport_IdempotentPostReplaysBody(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		require.Equal(t, `{"query":"q"}`, string(body))
+		if calls.Add(1) == 1 {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	rt, _ := newTestRetryTransport(RetryConfig{}, time.Now())
+	req, err := http.NewRequestWithContext(withIdempotent(context.Background()), http.MethodPost, server.URL, bytes.NewReader([]byte(`{"query":"q"}`)))
+	require.NoError(t, err)
+
+	resp, err := (&http.Client{Transport: rt}).Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, int32(2), calls.Load())
+}
+
+func TestRetryTransport_MaxRetriesReturnsLastResponse(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		http.Error(w, "boom", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	rt, _ := newTestRetryTransport(RetryConfig{MaxRetries: 2}, time.Now())
+	resp, err := (&http.Client{Transport: rt}).Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	require.Equal(t, int32(3), calls.Load())
+}
+
+func TestRetryTransport_ContextCancelledDuringWait(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "30")
+		http.Error(w, "slow down", http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	rt := newRetryTransport(http.DefaultTransport, RetryConfig{})
+	ctx, cancel := context.WithCancel(context.Background())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+
+	_, err = (&http.Client{Transport: rt}).Do(req)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	d, ok := parseRetryAfter("", now)
+	require.False(t, ok)
+	require.Zero(t, d)
+
+	d, ok = parseRetryAfter("5", now)
+	require.True(t, ok)
+	require.Equal(t, 5*time.Second, d)
+
+	d, ok = parseRetryAfter(now.Add(90*time.Second).Format(http.TimeFormat), now)
+	require.True(t, ok)
+	require.Equal(t, 90*time.Second, d)
+
+	_, ok = parseRetryAfter("soon", now)
+	require.False(t, ok)
+}
+
+func TestRateLimitError_Message(t *testing.T) {
+	err := &RateLimitError{StatusCode: http.StatusTooManyRequests, Remaining: -1, Secondary: true, Waited: 90 * time.Second}
+	require.Equal(t, "GitHub secondary rate limit exceeded (waited 1m30s of retry budget)", err.Error())
+}
+
+func TestGraphQL_RateLimitedError(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/graphql", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		writeJSON(w, map[string]interface{}{
+			"errors": []map[string]interface{}{
+				{"type": "RATE_LIMITED", "message": "API rate limit exceeded"},
+			},
+		})
+	})
+
+	repo, _, cleanup := newTestRepoWithGraphQL(t, mux)
+	defer cleanup()
+
+	_, err := repo.Branches(context.Background())
+	require.Error(t, err)
+	require.True(t, IsRateLimitError(err))
+}
+
+func TestCommitLog_GraphQLRateLimitedSkipsRESTFallback(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/graphql", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]interface{}{
+			"errors": []map[string]interface{}{
+				{"type": "RATE_LIMITED", "message": "API rate limit exceeded"},
+			},
+		})
+	})
+	mux.HandleFunc("/api/v3/repos/testowner/testrepo/commits", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected REST fallback %s", r.URL.Path)
+	})
+
+	repo, _, cleanup := newTestRepoWithGraphQL(t, mux)
+	defer cleanup()
+
+	_, err := repo.CommitLog(context.Background(), "", "main")
+	require.True(t, IsRateLimitError(err))
+}
@@ -25,8 +25,12 @@ type GitHubRepository struct {
 	ref        string // target ref (branch name, tag, or SHA)
 	baseURL    string // custom API base URL for GHE
 	maxCommits int    // hard cap on commit walk depth
-	cache      *apiCache
-	ctx        context.Context // request context
+	// concurrency bounds parallel API requests in Prefetch and BranchesContainingCommit.
+	concurrency int
+	// pullRequest is the PR number to version (0 = none); pr is resolved lazily.
+	pullRequest int
+	pr          *pullRequestInfo
+	cache       *apiCache
 	// versionTagSHAs is populated by Tags() and used by CommitLog for early termination.
 	versionTagSHAs map[string]bool
 }
@@ -56,9 +60,9 @@ func NewGitHubRepository(client *gh.Client, owner, repo string, opts ...Option)
 		owner:          owner,
 		repo:           repo,
 		maxCommits:     defaultMaxCommits,
+		concurrency:    defaultConcurrency,
 		cache:          newCache(),
 		versionTagSHAs: make(map[string]bool),
-		ctx:            context.Background(),
 	}
 	for _, opt := range opts {
 		opt(r)
@@ -85,16 +89,25 @@ func (r *GitHubRepository) IsHeadDetached() bool {
 	return hexPattern.MatchString(r.ref)
 }
 
-func (r *GitHubRepository) Head() (git.Branch, error) {
+func (r *GitHubRepository) Head(ctx context.Context) (git.Branch, error) {
 	if branch, ok := r.cache.getHead(); ok {
 		return *branch, nil
 	}
 
+	if r.pullRequest > 0 {
+		branch, err := r.pullRequestHeadBranch(ctx)
+		if err != nil {
+			return git.Branch{}, err
+		}
+		r.cache.putHead(branch)
+		return branch, nil
+	}
+
 	ref := r.ref
 
 	if ref == "" {
 		// Fetch the repository's default branch.
-		repoInfo, _, err := r.client.Repositories.Get(r.ctx, r.owner, r.repo)
+		repoInfo, _, err := r.client.Repositories.Get(ctx, r.owner, r.repo)
 		if err != nil {
 			return git.Branch{}, fmt.Errorf("getting repository info: %w", err)
 		}
@@ -103,7 +116,7 @@ func (r *GitHubRepository) Head() (git.Branch, error) {
 
 	// If ref is a SHA, build a detached head.
 	if hexPattern.MatchString(ref) {
-		commit, err := r.CommitFromSha(ref)
+		commit, err := r.CommitFromSha(ctx, ref)
 		if err != nil {
 			return git.Branch{}, fmt.Errorf("getting HEAD commit: %w", err)
 		}
@@ -118,7 +131,7 @@ func (r *GitHubRepository) Head() (git.Branch, error) {
 	}
 
 	// Try resolving as a branch first.
-	ghBranch, resp, err := r.client.Repositories.GetBranch(r.ctx, r.owner, r.repo, ref, 0)
+	ghBranch, resp, err := r.client.Repositories.GetBranch(ctx, r.owner, r.repo, ref, 0)
 	if err == nil {
 		tipCommit := convertGitHubCommit(ghBranch.GetCommit())
 		r.cache.putCommit(tipCommit)
@@ -134,17 +147,17 @@ func (r *GitHubRepository) Head() (git.Branch, error) {
 
 	// If branch lookup returned 404, try resolving as a tag.
 	if resp != nil && resp.StatusCode == http.StatusNotFound {
-		ghRef, _, tagErr := r.client.Git.GetRef(r.ctx, r.owner, r.repo, "tags/"+ref)
+		ghRef, _, tagErr := r.client.Git.GetRef(ctx, r.owner, r.repo, "tags/"+ref)
 		if tagErr == nil && ghRef.GetObject() != nil {
 			commitSha := ghRef.GetObject().GetSHA()
 			// If the ref points to a tag object, peel to the commit.
 			if ghRef.GetObject().GetType() == "tag" {
-				tagObj, _, peelErr := r.client.Git.GetTag(r.ctx, r.owner, r.repo, commitSha)
+				tagObj, _, peelErr := r.client.Git.GetTag(ctx, r.owner, r.repo, commitSha)
 				if peelErr == nil && tagObj.GetObject() != nil {
 					commitSha = tagObj.GetObject().GetSHA()
 				}
 			}
-			commit, commitErr := r.CommitFromSha(commitSha)
+			commit, commitErr := r.CommitFromSha(ctx, commitSha)
 			if commitErr != nil {
 				return git.Branch{}, fmt.Errorf("getting tag commit for %s: %w", ref, commitErr)
 			}
@@ -162,12 +175,12 @@ func (r *GitHubRepository) Head() (git.Branch, error) {
 	return git.Branch{}, fmt.Errorf("getting ref %s: %w", ref, err)
 }
 
-func (r *GitHubRepository) Branches(_ ...git.PathFilter) ([]git.Branch, error) {
+func (r *GitHubRepository) Branches(ctx context.Context, _ ...git.PathFilter) ([]git.Branch, error) {
 	if branches, ok := r.cache.getBranches(); ok {
 		return branches, nil
 	}
 
-	branches, err := r.fetchAllBranchesGraphQL()
+	branches, err := r.fetchAllBranchesGraphQL(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -176,12 +189,12 @@ func (r *GitHubRepository) Branches(_ ...git.PathFilter) ([]git.Branch, error) {
 	return branches, nil
 }
 
-func (r *GitHubRepository) Tags(_ ...git.PathFilter) ([]git.Tag, error) {
+func (r *GitHubRepository) Tags(ctx context.Context, _ ...git.PathFilter) ([]git.Tag, error) {
 	if tags, ok := r.cache.getTags(); ok {
 		return tags, nil
 	}
 
-	tags, err := r.fetchAllTagsGraphQL()
+	tags, err := r.fetchAllTagsGraphQL(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -202,12 +215,16 @@ func (r *GitHubRepository) Tags(_ ...git.PathFilter) ([]git.Tag, error) {
 	return tags, nil
 }
 
-func (r *GitHubRepository) CommitFromSha(sha string) (git.Commit, error) {
+func (r *GitHubRepository) CommitFromSha(ctx context.Context, sha string) (git.Commit, error) {
+	// The pull request head is served as its virtual merged form.
+	if r.pr != nil && sha == r.pr.realSha {
+		return r.pr.head, nil
+	}
 	if commit, ok := r.cache.getCommit(sha); ok {
 		return commit, nil
 	}
 
-	ghCommit, _, err := r.client.Repositories.GetCommit(r.ctx, r.owner, r.repo, sha, nil)
+	ghCommit, _, err := r.client.Repositories.GetCommit(ctx, r.owner, r.repo, sha, nil)
 	if err != nil {
 		return git.Commit{}, fmt.Errorf("getting commit %s: %w", sha, err)
 	}
@@ -217,37 +234,67 @@ func (r *GitHubRepository) CommitFromSha(sha string) (git.Commit, error) {
 	return commit, nil
 }
 
-func (r *GitHubRepository) CommitLog(from, to string, filters ...git.PathFilter) ([]git.Commit, error) {
+func (r *GitHubRepository) CommitLog(ctx context.Context, from, to string, filters ...git.PathFilter) ([]git.Commit, error) {
 	key := commitLogKey(from, to, filters...)
 	if log, ok := r.cache.getCommitLog(key); ok {
 		return log, nil
 	}
 
+	var commits []git.Commit
+	var err error
+	if r.pr != nil && to == r.pr.realSha {
+		commits, err = r.pullRequestCommitLog(ctx, r.pr, from, filters...)
+	} else {
+		commits, err = r.commitLogUncached(ctx, from, to, filters...)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	r.cache.putCommitLog(key, commits)
+	return commits, nil
+}
+
+// commitLogUncached fetches the real commit history of to, stopping at from.
+func (r *GitHubRepository) commitLogUncached(ctx context.Context, from, to string, filters ...git.PathFilter) ([]git.Commit, error) {
 	var commits []git.Commit
 	var err error
 
 	if from != "" {
 		// Bounded range: try compare API first.
-		commits, err = r.commitLogCompare(from, to)
+		commits, err = r.commitLogCompare(ctx, from, to)
 		if err != nil {
-			// Fall back to paginated walk if compare fails (e.g., > 250 commits).
-			commits, err = r.commitLogPaginated(from, to, filters...)
+			// Fall back to a history walk if compare fails (e.g., > 250 commits).
+			commits, err = r.commitLogWalk(ctx, from, to, filters...)
 		}
 	} else {
 		// Full history walk with smart early termination.
-		commits, err = r.commitLogPaginated(from, to, filters...)
+		commits, err = r.commitLogWalk(ctx, from, to, filters...)
 	}
-	if err != nil {
+	return commits, err
+}
+
+// commitLogWalk walks history via GraphQL, which returns parents in bulk,
+// falling back to the paginated REST walk if the GraphQL query fails.
+// Cancellation and rate limiting are returned as is, since the REST walk
+// would fail the same way.
+func (r *GitHubRepository) commitLogWalk(ctx context.Context, from, to string, filters ...git.PathFilter) ([]git.Commit, error) {
+	commits, err := r.fetchCommitHistoryGraphQL(ctx, from, to, filters...)
+	if err == nil {
+		return commits, nil
+	}
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, ctxErr
+	}
+	if IsRateLimitError(err) {
 		return nil, err
 	}
-
-	r.cache.putCommitLog(key, commits)
-	return commits, nil
+	return r.commitLogPaginated(ctx, from, to, filters...)
 }
 
 // commitLogCompare uses the compare API for bounded commit ranges.
-func (r *GitHubRepository) commitLogCompare(from, to string) ([]git.Commit, error) {
-	comparison, _, err := r.client.Repositories.CompareCommits(r.ctx, r.owner, r.repo, from, to, nil)
+func (r *GitHubRepository) commitLogCompare(ctx context.Context, from, to string) ([]git.Commit, error) {
+	comparison, _, err := r.client.Repositories.CompareCommits(ctx, r.owner, r.repo, from, to, nil)
 	if err != nil {
 		return nil, fmt.Errorf("comparing commits: %w", err)
 	}
@@ -269,7 +316,7 @@ func (r *GitHubRepository) commitLogCompare(from, to string) ([]git.Commit, erro
 }
 
 // commitLogPaginated walks commits page-by-page with smart early termination.
-func (r *GitHubRepository) commitLogPaginated(from, to string, filters ...git.PathFilter) ([]git.Commit, error) {
+func (r *GitHubRepository) commitLogPaginated(ctx context.Context, from, to string, filters ...git.PathFilter) ([]git.Commit, error) {
 	opts := &gh.CommitsListOptions{
 		SHA: to,
 		ListOptions: gh.ListOptions{
@@ -290,7 +337,7 @@ func (r *GitHubRepository) commitLogPaginated(from, to string, filters ...git.Pa
 	bufferPages := 0
 
 	for {
-		ghCommits, resp, err := r.client.Repositories.ListCommits(r.ctx, r.owner, r.repo, opts)
+		ghCommits, resp, err := r.client.Repositories.ListCommits(ctx, r.owner, r.repo, opts)
 		if err != nil {
 			return nil, fmt.Errorf("listing commits: %w", err)
 		}
@@ -336,9 +383,9 @@ done:
 	return commits, nil
 }
 
-func (r *GitHubRepository) MainlineCommitLog(from, to string, filters ...git.PathFilter) ([]git.Commit, error) {
+func (r *GitHubRepository) MainlineCommitLog(ctx context.Context, from, to string, filters ...git.PathFilter) ([]git.Commit, error) {
 	// Get full commit log, then filter to first-parent only.
-	allCommits, err := r.CommitLog(from, to, filters...)
+	allCommits, err := r.CommitLog(ctx, from, to, filters...)
 	if err != nil {
 		return nil, err
 	}
@@ -374,14 +421,14 @@ func (r *GitHubRepository) MainlineCommitLog(from, to string, filters ...git.Pat
 	return mainline, nil
 }
 
-func (r *GitHubRepository) BranchCommits(branch git.Branch, filters ...git.PathFilter) ([]git.Commit, error) {
+func (r *GitHubRepository) BranchCommits(ctx context.Context, branch git.Branch, filters ...git.PathFilter) ([]git.Commit, error) {
 	if branch.Tip == nil {
 		return nil, nil
 	}
-	return r.CommitLog("", branch.Tip.Sha, filters...)
+	return r.CommitLog(ctx, "", branch.Tip.Sha, filters...)
 }
 
-func (r *GitHubRepository) CommitsPriorTo(olderThan time.Time, branch git.Branch) ([]git.Commit, error) {
+func (r *GitHubRepository) CommitsPriorTo(ctx context.Context, olderThan time.Time, branch git.Branch) ([]git.Commit, error) {
 	if branch.Tip == nil {
 		return nil, nil
 	}
@@ -396,7 +443,7 @@ func (r *GitHubRepository) CommitsPriorTo(olderThan time.Time, branch git.Branch
 
 	var commits []git.Commit
 	for {
-		ghCommits, resp, err := r.client.Repositories.ListCommits(r.ctx, r.owner, r.repo, opts)
+		ghCommits, resp, err := r.client.Repositories.ListCommits(ctx, r.owner, r.repo, opts)
 		if err != nil {
 			return nil, fmt.Errorf("listing commits prior to %s: %w", olderThan, err)
 		}
@@ -416,12 +463,12 @@ func (r *GitHubRepository) CommitsPriorTo(olderThan time.Time, branch git.Branch
 	return commits, nil
 }
 
-func (r *GitHubRepository) FindMergeBase(sha1, sha2 string) (string, error) {
+func (r *GitHubRepository) FindMergeBase(ctx context.Context, sha1, sha2 string) (string, error) {
 	if base, ok := r.cache.getMergeBase(sha1, sha2); ok {
 		return base, nil
 	}
 
-	comparison, _, err := r.client.Repositories.CompareCommits(r.ctx, r.owner, r.repo, sha1, sha2, nil)
+	comparison, _, err := r.client.Repositories.CompareCommits(ctx, r.owner, r.repo, sha1, sha2, nil)
 	if err != nil {
 		return "", fmt.Errorf("comparing commits for merge base: %w", err)
 	}
@@ -435,34 +482,44 @@ func (r *GitHubRepository) FindMergeBase(sha1, sha2 string) (string, error) {
 	return base, nil
 }
 
-func (r *GitHubRepository) BranchesContainingCommit(sha string) ([]git.Branch, error) {
-	branches, err := r.Branches()
+func (r *GitHubRepository) BranchesContainingCommit(ctx context.Context, sha string) ([]git.Branch, error) {
+	branches, err := r.Branches(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	var result []git.Branch
-
-	for _, b := range branches {
+	// Compare concurrently, recording matches by index so the result keeps
+	// the order of Branches().
+	contains := make([]bool, len(branches))
+	r.forEach(ctx, len(branches), func(i int) {
+		b := branches[i]
 		if b.Tip == nil {
-			continue
+			return
 		}
 
 		// Direct match: commit is the branch tip.
 		if b.Tip.Sha == sha {
-			result = append(result, b)
-			continue
+			contains[i] = true
+			return
 		}
 
 		// Check ancestry via compare API.
-		comparison, _, err := r.client.Repositories.CompareCommits(r.ctx, r.owner, r.repo, sha, b.Tip.Sha, nil)
+		comparison, _, err := r.client.Repositories.CompareCommits(ctx, r.owner, r.repo, sha, b.Tip.Sha, nil)
 		if err != nil {
-			continue // skip branches we can't compare
+			return // skip branches we can't compare
 		}
 
 		// If status is "ahead" or "identical", the branch contains the commit.
 		status := comparison.GetStatus()
-		if status == "ahead" || status == "identical" {
+		contains[i] = status == "ahead" || status == "identical"
+	})
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var result []git.Branch
+	for i, b := range branches {
+		if contains[i] {
 			result = append(result, b)
 		}
 	}
@@ -470,11 +527,11 @@ func (r *GitHubRepository) BranchesContainingCommit(sha string) ([]git.Branch, e
 	return result, nil
 }
 
-func (r *GitHubRepository) NumberOfUncommittedChanges() (int, error) {
+func (r *GitHubRepository) NumberOfUncommittedChanges(_ context.Context) (int, error) {
 	return 0, nil
 }
 
-func (r *GitHubRepository) PeelTagToCommit(tag git.Tag) (string, error) {
+func (r *GitHubRepository) PeelTagToCommit(ctx context.Context, tag git.Tag) (string, error) {
 	// Check the pre-populated cache from Tags() GraphQL query.
 	if commitSha, ok := r.cache.getTagPeel(tag.TargetSha); ok {
 		return commitSha, nil
@@ -482,13 +539,17 @@ func (r *GitHubRepository) PeelTagToCommit(tag git.Tag) (string, error) {
 
 	// Fallback: try to resolve via the git tags API.
 
-	tagObj, _, err := r.client.Git.GetTag(r.ctx, r.owner, r.repo, tag.TargetSha)
+	tagObj, _, err := r.client.Git.GetTag(ctx, r.owner, r.repo, tag.TargetSha)
 	if err == nil && tagObj.GetObject() != nil {
 		commitSha := tagObj.GetObject().GetSHA()
 		r.cache.putTagPeel(tag.TargetSha, commitSha)
 		return commitSha, nil
 	}
 
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
 	// If it's not an annotated tag object, it's a lightweight tag pointing directly to a commit.
 	r.cache.putTagPeel(tag.TargetSha, tag.TargetSha)
 	return tag.TargetSha, nil
@@ -496,13 +557,21 @@ func (r *GitHubRepository) PeelTagToCommit(tag git.Tag) (string, error) {
 
 // FetchFileContent fetches a file's content from the repository.
 // Used to load configuration files from the remote repository.
-func (r *GitHubRepository) FetchFileContent(path string) (string, error) {
+func (r *GitHubRepository) FetchFileContent(ctx context.Context, path string) (string, error) {
 	opts := &gh.RepositoryContentGetOptions{}
 	if r.ref != "" {
 		opts.Ref = r.ref
 	}
+	if r.pullRequest > 0 {
+		// Read configuration as of the PR head so config changes in the PR apply.
+		headSha, err := r.PullRequestHead(ctx)
+		if err != nil {
+			return "", err
+		}
+		opts.Ref = headSha
+	}
 
-	content, _, _, err := r.client.Repositories.GetContents(r.ctx, r.owner, r.repo, path, opts)
+	content, _, _, err := r.client.Repositories.GetContents(ctx, r.owner, r.repo, path, opts)
 	if err != nil {
 		return "", fmt.Errorf("fetching file %s: %w", path, err)
 	}
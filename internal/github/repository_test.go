@@ -1,6 +1,7 @@
 package github
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -35,7 +36,9 @@ func newTestRepo(t *testing.T, mux *http.ServeMux, opts ...Option) (*GitHubRepos
 	t.Helper()
 	client, cleanup := newTestServer(t, mux)
 	repo := NewGitHubRepository(client, "testowner", "testrepo", opts...)
-	repo.baseURL = "" // graphql won't be used in REST-only tests
+	// Route GraphQL to the test server; unregistered GraphQL paths return 404,
+	// so REST-only tests exercise the REST fallback.
+	repo.baseURL = client.BaseURL.String()
 	return repo, cleanup
 }
 
@@ -70,7 +73,7 @@ func TestIsHeadDetached(t *testing.T) {
 
 func TestNumberOfUncommittedChanges(t *testing.T) {
 	repo := NewGitHubRepository(nil, "o", "r")
-	n, err := repo.NumberOfUncommittedChanges()
+	n, err := repo.NumberOfUncommittedChanges(context.Background())
 	require.NoError(t, err)
 	require.Equal(t, 0, n)
 }
@@ -109,14 +112,14 @@ func TestHead_DefaultBranch(t *testing.T) {
 	repo, cleanup := newTestRepo(t, mux)
 	defer cleanup()
 
-	head, err := repo.Head()
+	head, err := repo.Head(context.Background())
 	require.NoError(t, err)
 	require.Equal(t, "main", head.FriendlyName())
 	require.Equal(t, "abcdef1234567890abcdef1234567890abcdef12", head.Tip.Sha)
 	require.False(t, head.IsDetachedHead)
 
 	// Second call should hit cache.
-	head2, err := repo.Head()
+	head2, err := repo.Head(context.Background())
 	require.NoError(t, err)
 	require.Equal(t, head.Tip.Sha, head2.Tip.Sha)
 }
@@ -143,7 +146,7 @@ func TestHead_ExplicitRef(t *testing.T) {
 	repo, cleanup := newTestRepo(t, mux, WithRef("develop"))
 	defer cleanup()
 
-	head, err := repo.Head()
+	head, err := repo.Head(context.Background())
 	require.NoError(t, err)
 	require.Equal(t, "develop", head.FriendlyName())
 }
@@ -170,7 +173,7 @@ func TestCommitFromSha(t *testing.T) {
 	repo, cleanup := newTestRepo(t, mux)
 	defer cleanup()
 
-	commit, err := repo.CommitFromSha(sha)
+	commit, err := repo.CommitFromSha(context.Background(), sha)
 	require.NoError(t, err)
 	require.Equal(t, sha, commit.Sha)
 	require.Equal(t, "test commit", commit.Message)
@@ -179,11 +182,26 @@ func TestCommitFromSha(t *testing.T) {
 	require.Equal(t, 2025, commit.When.Year())
 
 	// Second call should hit cache.
-	commit2, err := repo.CommitFromSha(sha)
+	commit2, err := repo.CommitFromSha(context.Background(), sha)
 	require.NoError(t, err)
 	require.Equal(t, commit.Sha, commit2.Sha)
 }
 
+func TestCommitFromSha_Cancelled(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v3/repos/testowner/testrepo/commits/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s", r.URL.Path)
+	})
+
+	repo, cleanup := newTestRepo(t, mux)
+	defer cleanup()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := repo.CommitFromSha(ctx, "abcdef1234567890abcdef1234567890abcdef12")
+	require.ErrorIs(t, err, context.Canceled)
+}
+
 func TestFindMergeBase(t *testing.T) {
 	sha1 := "aaa1111111111111111111111111111111111111"
 	sha2 := "bbb2222222222222222222222222222222222222"
@@ -202,12 +220,12 @@ func TestFindMergeBase(t *testing.T) {
 	repo, cleanup := newTestRepo(t, mux)
 	defer cleanup()
 
-	base, err := repo.FindMergeBase(sha1, sha2)
+	base, err := repo.FindMergeBase(context.Background(), sha1, sha2)
 	require.NoError(t, err)
 	require.Equal(t, baseSha, base)
 
 	// Cached (key is sorted, so reversing args still hits cache).
-	base2, err := repo.FindMergeBase(sha2, sha1)
+	base2, err := repo.FindMergeBase(context.Background(), sha2, sha1)
 	require.NoError(t, err)
 	require.Equal(t, baseSha, base2)
 }
@@ -218,7 +236,7 @@ func TestPeelTagToCommit_Cached(t *testing.T) {
 	commitSha := "commit2222222222222222222222222222222222"
 	repo.cache.putTagPeel(tagSha, commitSha)
 
-	result, err := repo.PeelTagToCommit(git.Tag{
+	result, err := repo.PeelTagToCommit(context.Background(), git.Tag{
 		Name:      git.NewReferenceName("refs/tags/v1.0.0"),
 		TargetSha: tagSha,
 	})
@@ -259,7 +277,7 @@ func TestCommitLog_Compare(t *testing.T) {
 	repo, cleanup := newTestRepo(t, mux)
 	defer cleanup()
 
-	commits, err := repo.CommitLog(fromSha, toSha)
+	commits, err := repo.CommitLog(context.Background(), fromSha, toSha)
 	require.NoError(t, err)
 	require.Len(t, commits, 3)
 	// Should be in reverse chronological order.
@@ -298,7 +316,7 @@ func TestCommitLog_Paginated_EarlyTermination(t *testing.T) {
 	// Pre-populate versionTagSHAs so early termination kicks in.
 	repo.versionTagSHAs["commit_1_2"] = true
 
-	commits, err := repo.CommitLog("", "HEAD")
+	commits, err := repo.CommitLog(context.Background(), "", "HEAD")
 	require.NoError(t, err)
 	// Should have fetched page 1 (found tag at commit_1_2) + 1 buffer page = 6 commits.
 	require.Equal(t, 6, len(commits))
@@ -327,7 +345,7 @@ func TestCommitLog_Paginated_MaxCommitsCap(t *testing.T) {
 	repo, cleanup := newTestRepo(t, mux, WithMaxCommits(50))
 	defer cleanup()
 
-	commits, err := repo.CommitLog("", "HEAD")
+	commits, err := repo.CommitLog(context.Background(), "", "HEAD")
 	require.NoError(t, err)
 	// maxCommits=50 but we get 100 per page; should stop after first page (100 >= 50).
 	require.LessOrEqual(t, 50, len(commits))
@@ -354,12 +372,12 @@ func TestMainlineCommitLog(t *testing.T) {
 	defer cleanup()
 
 	// Full log returns all 4 commits (reversed).
-	commits, err := repo.CommitLog("base", "tip")
+	commits, err := repo.CommitLog(context.Background(), "base", "tip")
 	require.NoError(t, err)
 	require.Len(t, commits, 4)
 
 	// Mainline should follow first-parent: ccc -> bbb -> aaa (skip ddd).
-	mainline, err := repo.MainlineCommitLog("base", "tip")
+	mainline, err := repo.MainlineCommitLog(context.Background(), "base", "tip")
 	require.NoError(t, err)
 	require.Len(t, mainline, 3)
 	require.Equal(t, "ccc", mainline[0].Sha)
@@ -389,7 +407,7 @@ func TestCommitsPriorTo(t *testing.T) {
 		Tip:  &tip,
 	}
 
-	commits, err := repo.CommitsPriorTo(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), branch)
+	commits, err := repo.CommitsPriorTo(context.Background(), time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), branch)
 	require.NoError(t, err)
 	require.Len(t, commits, 1)
 	require.Equal(t, "old_commit", commits[0].Sha)
@@ -410,7 +428,7 @@ func TestFetchFileContent(t *testing.T) {
 	repo, cleanup := newTestRepo(t, mux)
 	defer cleanup()
 
-	content, err := repo.FetchFileContent("go-gitsemver.yml")
+	content, err := repo.FetchFileContent(context.Background(), "go-gitsemver.yml")
 	require.NoError(t, err)
 	require.Equal(t, "mode: Mainline", content)
 }
@@ -425,7 +443,7 @@ func TestFetchFileContent_NotFound(t *testing.T) {
 	repo, cleanup := newTestRepo(t, mux)
 	defer cleanup()
 
-	_, err := repo.FetchFileContent("nonexistent.yml")
+	_, err := repo.FetchFileContent(context.Background(), "nonexistent.yml")
 	require.Error(t, err)
 }
 
@@ -449,19 +467,19 @@ func TestCacheHits(t *testing.T) {
 	defer cleanup()
 
 	// First call hits API.
-	_, err := repo.CommitFromSha(sha)
+	_, err := repo.CommitFromSha(context.Background(), sha)
 	require.NoError(t, err)
 	require.Equal(t, 1, callCount)
 
 	// Second call should be cached.
-	_, err = repo.CommitFromSha(sha)
+	_, err = repo.CommitFromSha(context.Background(), sha)
 	require.NoError(t, err)
 	require.Equal(t, 1, callCount) // still 1
 }
 
 func TestBranchCommits_NilTip(t *testing.T) {
 	repo := NewGitHubRepository(nil, "o", "r")
-	commits, err := repo.BranchCommits(git.Branch{Name: git.NewBranchReferenceName("main")})
+	commits, err := repo.BranchCommits(context.Background(), git.Branch{Name: git.NewBranchReferenceName("main")})
 	require.NoError(t, err)
 	require.Nil(t, commits)
 }
@@ -542,7 +560,7 @@ func TestBranches_GraphQL(t *testing.T) {
 	repo, _, cleanup := newTestRepoWithGraphQL(t, mux)
 	defer cleanup()
 
-	result, err := repo.Branches()
+	result, err := repo.Branches(context.Background())
 	require.NoError(t, err)
 	require.Len(t, result, 2)
 	require.Equal(t, "main", result[0].FriendlyName())
@@ -552,7 +570,7 @@ func TestBranches_GraphQL(t *testing.T) {
 	require.False(t, result[0].IsRemote)
 
 	// Second call should use cache.
-	result2, err := repo.Branches()
+	result2, err := repo.Branches(context.Background())
 	require.NoError(t, err)
 	require.Len(t, result2, 2)
 }
@@ -595,7 +613,7 @@ func TestTags_GraphQL_LightweightAndAnnotated(t *testing.T) {
 	repo, _, cleanup := newTestRepoWithGraphQL(t, mux)
 	defer cleanup()
 
-	result, err := repo.Tags()
+	result, err := repo.Tags(context.Background())
 	require.NoError(t, err)
 	require.Len(t, result, 2)
 
@@ -619,7 +637,7 @@ func TestTags_GraphQL_LightweightAndAnnotated(t *testing.T) {
 	require.True(t, repo.versionTagSHAs["commit_bbb"])
 
 	// Second call should use cache.
-	result2, err := repo.Tags()
+	result2, err := repo.Tags(context.Background())
 	require.NoError(t, err)
 	require.Len(t, result2, 2)
 }
@@ -639,7 +657,7 @@ func TestHead_DetachedSHA(t *testing.T) {
 	repo, cleanup := newTestRepo(t, mux, WithRef(sha))
 	defer cleanup()
 
-	head, err := repo.Head()
+	head, err := repo.Head(context.Background())
 	require.NoError(t, err)
 	require.True(t, head.IsDetachedHead)
 	require.Equal(t, "HEAD", head.FriendlyName())
@@ -687,7 +705,7 @@ func TestBranchesContainingCommit(t *testing.T) {
 	repo, _, cleanup := newTestRepoWithGraphQL(t, mux)
 	defer cleanup()
 
-	result, err := repo.BranchesContainingCommit(targetSha)
+	result, err := repo.BranchesContainingCommit(context.Background(), targetSha)
 	require.NoError(t, err)
 	// main: direct match (tip == sha), develop: ahead → contains
 	require.Len(t, result, 2)
@@ -724,7 +742,7 @@ func TestBranchesContainingCommit_Behind(t *testing.T) {
 	repo, _, cleanup := newTestRepoWithGraphQL(t, mux)
 	defer cleanup()
 
-	result, err := repo.BranchesContainingCommit(targetSha)
+	result, err := repo.BranchesContainingCommit(context.Background(), targetSha)
 	require.NoError(t, err)
 	require.Empty(t, result)
 }
@@ -750,7 +768,7 @@ func TestPeelTagToCommit_FallbackAPI(t *testing.T) {
 	defer cleanup()
 
 	// Don't pre-populate cache — forces the API fallback path.
-	result, err := repo.PeelTagToCommit(git.Tag{
+	result, err := repo.PeelTagToCommit(context.Background(), git.Tag{
 		Name:      git.NewReferenceName("refs/tags/v3.0.0"),
 		TargetSha: tagSha,
 	})
@@ -777,7 +795,7 @@ func TestPeelTagToCommit_LightweightFallback(t *testing.T) {
 	repo, cleanup := newTestRepo(t, mux)
 	defer cleanup()
 
-	result, err := repo.PeelTagToCommit(git.Tag{
+	result, err := repo.PeelTagToCommit(context.Background(), git.Tag{
 		Name:      git.NewReferenceName("refs/tags/v1.0.0-light"),
 		TargetSha: tagSha,
 	})
@@ -794,7 +812,7 @@ func TestCommitLog_CacheHit(t *testing.T) {
 	key := commitLogKey("from", "to")
 	repo.cache.putCommitLog(key, expected)
 
-	result, err := repo.CommitLog("from", "to")
+	result, err := repo.CommitLog(context.Background(), "from", "to")
 	require.NoError(t, err)
 	require.Equal(t, expected, result)
 }
@@ -823,7 +841,7 @@ func TestWithBaseURL(t *testing.T) {
 func TestCommitsPriorTo_NilTip(t *testing.T) {
 	repo := NewGitHubRepository(nil, "o", "r")
 	branch := git.Branch{Name: git.NewBranchReferenceName("main")}
-	result, err := repo.CommitsPriorTo(time.Now(), branch)
+	result, err := repo.CommitsPriorTo(context.Background(), time.Now(), branch)
 	require.NoError(t, err)
 	require.Nil(t, result)
 }
@@ -845,7 +863,7 @@ func TestBranchCommits_WithTip(t *testing.T) {
 	repo, cleanup := newTestRepo(t, mux)
 	defer cleanup()
 
-	commits, err := repo.BranchCommits(git.Branch{
+	commits, err := repo.BranchCommits(context.Background(), git.Branch{
 		Name: git.NewBranchReferenceName("main"),
 		Tip:  &tip,
 	})
@@ -859,7 +877,7 @@ func TestMainlineCommitLog_Empty(t *testing.T) {
 	key := commitLogKey("base", "tip")
 	repo.cache.putCommitLog(key, []git.Commit{})
 
-	mainline, err := repo.MainlineCommitLog("base", "tip")
+	mainline, err := repo.MainlineCommitLog(context.Background(), "base", "tip")
 	require.NoError(t, err)
 	require.Nil(t, mainline)
 }
@@ -879,7 +897,7 @@ func TestFetchFileContent_WithRef(t *testing.T) {
 	repo, cleanup := newTestRepo(t, mux, WithRef("develop"))
 	defer cleanup()
 
-	content, err := repo.FetchFileContent("config.yml")
+	content, err := repo.FetchFileContent(context.Background(), "config.yml")
 	require.NoError(t, err)
 	require.Equal(t, "test", content)
 }
@@ -917,7 +935,7 @@ func TestCommitLog_Paginated_WithFromBoundary(t *testing.T) {
 		http.Error(w, "error", http.StatusInternalServerError)
 	})
 
-	commits, err := repo.CommitLog(fromSha, "HEAD")
+	commits, err := repo.CommitLog(context.Background(), fromSha, "HEAD")
 	require.NoError(t, err)
 	// Should stop at fromSha boundary, so only "newer_commit" is returned.
 	require.Len(t, commits, 1)
@@ -941,7 +959,7 @@ func TestCommitLog_Paginated_PathFilter(t *testing.T) {
 	repo, cleanup := newTestRepo(t, mux)
 	defer cleanup()
 
-	commits, err := repo.CommitLog("", "HEAD", git.PathFilter("src/"))
+	commits, err := repo.CommitLog(context.Background(), "", "HEAD", git.PathFilter("src/"))
 	require.NoError(t, err)
 	require.Len(t, commits, 1)
 	require.Equal(t, "filtered_commit", commits[0].Sha)
@@ -961,7 +979,7 @@ func TestGraphQL_ErrorResponse(t *testing.T) {
 	repo, _, cleanup := newTestRepoWithGraphQL(t, mux)
 	defer cleanup()
 
-	_, err := repo.Branches()
+	_, err := repo.Branches(context.Background())
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "something went wrong")
 }
@@ -976,7 +994,7 @@ func TestGraphQL_HTTPError(t *testing.T) {
 	repo, _, cleanup := newTestRepoWithGraphQL(t, mux)
 	defer cleanup()
 
-	_, err := repo.Tags()
+	_, err := repo.Tags(context.Background())
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "401")
 }
@@ -1022,7 +1040,7 @@ func TestHead_APIError(t *testing.T) {
 	repo, cleanup := newTestRepo(t, mux)
 	defer cleanup()
 
-	_, err := repo.Head()
+	_, err := repo.Head(context.Background())
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "getting repository info")
 }
@@ -1036,7 +1054,7 @@ func TestCommitFromSha_APIError(t *testing.T) {
 	repo, cleanup := newTestRepo(t, mux)
 	defer cleanup()
 
-	_, err := repo.CommitFromSha("deadbeef12345678901234567890123456789012")
+	_, err := repo.CommitFromSha(context.Background(), "deadbeef12345678901234567890123456789012")
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "getting commit")
 }
@@ -1052,7 +1070,7 @@ func TestFindMergeBase_NilMergeBaseCommit(t *testing.T) {
 	repo, cleanup := newTestRepo(t, mux)
 	defer cleanup()
 
-	base, err := repo.FindMergeBase("sha1", "sha2")
+	base, err := repo.FindMergeBase(context.Background(), "sha1", "sha2")
 	require.NoError(t, err)
 	require.Equal(t, "", base)
 }
@@ -1087,7 +1105,7 @@ func TestCommitLogCompare_PartialResults(t *testing.T) {
 	repo, cleanup := newTestRepo(t, mux)
 	defer cleanup()
 
-	commits, err := repo.CommitLog("from_sha", "to_sha")
+	commits, err := repo.CommitLog(context.Background(), "from_sha", "to_sha")
 	require.NoError(t, err)
 	require.Len(t, commits, 1)
 	// Should have fallen back to paginated.
@@ -1132,7 +1150,7 @@ func TestBranches_GraphQL_Pagination(t *testing.T) {
 	repo, _, cleanup := newTestRepoWithGraphQL(t, mux)
 	defer cleanup()
 
-	result, err := repo.Branches()
+	result, err := repo.Branches(context.Background())
 	require.NoError(t, err)
 	require.Len(t, result, 2)
 	require.Equal(t, 2, page)
@@ -1178,7 +1196,7 @@ func TestTags_GraphQL_Pagination(t *testing.T) {
 	repo, _, cleanup := newTestRepoWithGraphQL(t, mux)
 	defer cleanup()
 
-	result, err := repo.Tags()
+	result, err := repo.Tags(context.Background())
 	require.NoError(t, err)
 	require.Len(t, result, 2)
 	require.Equal(t, 2, page)
@@ -1194,7 +1212,7 @@ func TestFetchFileContent_NilResponse(t *testing.T) {
 	repo, cleanup := newTestRepo(t, mux)
 	defer cleanup()
 
-	_, err := repo.FetchFileContent("empty.yml")
+	_, err := repo.FetchFileContent(context.Background(), "empty.yml")
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "not found")
 }
@@ -1209,7 +1227,7 @@ func TestHead_BranchAPIError(t *testing.T) {
 	defer cleanup()
 
 	// Branch returns 404, tag resolution also fails → final error from "getting ref"
-	_, err := repo.Head()
+	_, err := repo.Head(context.Background())
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "getting ref")
 }
@@ -1246,7 +1264,7 @@ func TestHead_TagRef(t *testing.T) {
 	repo, cleanup := newTestRepo(t, mux, WithRef("v1.0.0"))
 	defer cleanup()
 
-	head, err := repo.Head()
+	head, err := repo.Head(context.Background())
 	require.NoError(t, err)
 	require.True(t, head.IsDetachedHead)
 	require.Equal(t, commitSha, head.Tip.Sha)
@@ -1263,7 +1281,7 @@ func TestHead_DetachedSHA_CommitError(t *testing.T) {
 	repo, cleanup := newTestRepo(t, mux, WithRef(sha))
 	defer cleanup()
 
-	_, err := repo.Head()
+	_, err := repo.Head(context.Background())
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "getting HEAD commit")
 }
@@ -1277,7 +1295,7 @@ func TestFindMergeBase_APIError(t *testing.T) {
 	repo, cleanup := newTestRepo(t, mux)
 	defer cleanup()
 
-	_, err := repo.FindMergeBase("sha1", "sha2")
+	_, err := repo.FindMergeBase(context.Background(), "sha1", "sha2")
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "comparing commits")
 }
@@ -1295,7 +1313,7 @@ func TestMainlineCommitLog_WithFromBoundary(t *testing.T) {
 	repo.cache.putCommitLog(key, commits)
 
 	// MainlineCommitLog should stop at "base" boundary.
-	mainline, err := repo.MainlineCommitLog("base", "ccc")
+	mainline, err := repo.MainlineCommitLog(context.Background(), "base", "ccc")
 	require.NoError(t, err)
 	// Should follow ccc -> bbb -> aaa, stopping because aaa's first parent is "base".
 	require.Len(t, mainline, 3)
@@ -1319,7 +1337,7 @@ func TestCommitsPriorTo_APIError(t *testing.T) {
 		Tip:  &tip,
 	}
 
-	_, err := repo.CommitsPriorTo(time.Now(), branch)
+	_, err := repo.CommitsPriorTo(context.Background(), time.Now(), branch)
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "listing commits prior to")
 }
@@ -1341,7 +1359,7 @@ func TestBranchesContainingCommit_NilTipSkipped(t *testing.T) {
 	repo, _, cleanup := newTestRepoWithGraphQL(t, mux)
 	defer cleanup()
 
-	result, err := repo.BranchesContainingCommit("some_sha")
+	result, err := repo.BranchesContainingCommit(context.Background(), "some_sha")
 	require.NoError(t, err)
 	require.Empty(t, result)
 }
@@ -1405,7 +1423,7 @@ func TestVersionTagSHAs_OnlySemverTags(t *testing.T) {
 	repo, _, cleanup := newTestRepoWithGraphQL(t, mux)
 	defer cleanup()
 
-	_, err := repo.Tags()
+	_, err := repo.Tags(context.Background())
 	require.NoError(t, err)
 
 	// Only the semver tag commit should be in versionTagSHAs.
@@ -1445,7 +1463,7 @@ func TestBranches_GraphQL_SkipsEmptyOID(t *testing.T) {
 	repo, _, cleanup := newTestRepoWithGraphQL(t, mux)
 	defer cleanup()
 
-	result, err := repo.Branches()
+	result, err := repo.Branches(context.Background())
 	require.NoError(t, err)
 	// Only "main" should be included; empty/missing OID branches are skipped.
 	require.Len(t, result, 1)
@@ -1472,3 +1490,171 @@ func TestSemverTagPattern(t *testing.T) {
 		})
 	}
 }
+
+// graphQLHistoryResponse returns a JSON GraphQL response containing commit history.
+func graphQLHistoryResponse(commits []map[string]interface{}, hasNextPage bool, endCursor string) map[string]interface{} {
+	return map[string]interface{}{
+		"data": map[string]interface{}{
+			"repository": map[string]interface{}{
+				"object": map[string]interface{}{
+					"history": map[string]interface{}{
+						"nodes": commits,
+						"pageInfo": map[string]interface{}{
+							"hasNextPage": hasNextPage,
+							"endCursor":   endCursor,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// historyNode builds a GraphQL commit node with the given parents.
+func historyNode(sha string, parents ...string) map[string]interface{} {
+	nodes := make([]map[string]interface{}, 0, len(parents))
+	for _, p := range parents {
+		nodes = append(nodes, map[string]interface{}{"oid": p})
+	}
+	return map[string]interface{}{
+		"oid":           sha,
+		"message":       sha,
+		"committedDate": "2025-01-01T00:00:00Z",
+		"parents":       map[string]interface{}{"nodes": nodes},
+	}
+}
+
+// decodeGraphQLVars decodes the variables of a GraphQL request body.
+func decodeGraphQLVars(t *testing.T, r *http.Request) map[string]interface{} {
+	t.Helper()
+	var body graphQLRequest
+	require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+	return body.Variables
+}
+
+func TestCommitLog_GraphQLHistory(t *testing.T) {
+	mux := http.NewServeMux()
+	var cursors []interface{}
+
+	mux.HandleFunc("/graphql", func(w http.ResponseWriter, r *http.Request) {
+		vars := decodeGraphQLVars(t, r)
+		require.Equal(t, "main", vars["expr"])
+		cursors = append(cursors, vars["cursor"])
+
+		if vars["cursor"] == nil {
+			writeJSON(w, graphQLHistoryResponse([]map[string]interface{}{
+				historyNode("ccc", "bbb", "feat"),
+				historyNode("feat", "aaa"),
+			}, true, "cursor1"))
+			return
+		}
+		writeJSON(w, graphQLHistoryResponse([]map[string]interface{}{
+			historyNode("bbb", "aaa"),
+			historyNode("aaa"),
+		}, false, ""))
+	})
+	mux.HandleFunc("/api/v3/repos/testowner/testrepo/commits", func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("REST commit listing should not be used when GraphQL succeeds")
+	})
+
+	repo, _, cleanup := newTestRepoWithGraphQL(t, mux)
+	defer cleanup()
+
+	commits, err := repo.CommitLog(context.Background(), "", "main")
+	require.NoError(t, err)
+	require.Len(t, commits, 4)
+	require.Equal(t, []interface{}{nil, "cursor1"}, cursors)
+	require.Equal(t, []string{"bbb", "feat"}, commits[0].Parents)
+
+	// Commits are cached, so CommitFromSha needs no further requests.
+	c, err := repo.CommitFromSha(context.Background(), "feat")
+	require.NoError(t, err)
+	require.Equal(t, []string{"aaa"}, c.Parents)
+
+	// MainlineCommitLog is served from the same history.
+	mainline, err := repo.MainlineCommitLog(context.Background(), "", "main")
+	require.NoError(t, err)
+	require.Len(t, mainline, 3)
+	require.Equal(t, "ccc", mainline[0].Sha)
+	require.Equal(t, "bbb", mainline[1].Sha)
+	require.Equal(t, "aaa", mainline[2].Sha)
+	require.Len(t, cursors, 2)
+}
+
+func TestCommitLog_GraphQLHistory_EarlyTermination(t *testing.T) {
+	mux := http.NewServeMux()
+	page := 0
+
+	mux.HandleFunc("/graphql", func(w http.ResponseWriter, r *http.Request) {
+		page++
+		var nodes []map[string]interface{}
+		for i := 0; i < 3; i++ {
+			nodes = append(nodes, historyNode(fmt.Sprintf("commit_%d_%d", page, i)))
+		}
+		writeJSON(w, graphQLHistoryResponse(nodes, page < 3, fmt.Sprintf("cursor%d", page)))
+	})
+
+	repo, _, cleanup := newTestRepoWithGraphQL(t, mux, WithMaxCommits(100))
+	defer cleanup()
+
+	repo.versionTagSHAs["commit_1_2"] = true
+
+	commits, err := repo.CommitLog(context.Background(), "", "HEAD")
+	require.NoError(t, err)
+	// Page with the tag plus one buffer page.
+	require.Len(t, commits, 6)
+	require.Equal(t, 2, page)
+}
+
+func TestCommitLog_GraphQLHistory_FromBoundaryAndPath(t *testing.T) {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/graphql", func(w http.ResponseWriter, r *http.Request) {
+		vars := decodeGraphQLVars(t, r)
+		require.Equal(t, "HEAD", vars["expr"])
+		require.Equal(t, "services/api", vars["path"])
+		writeJSON(w, graphQLHistoryResponse([]map[string]interface{}{
+			historyNode("ccc", "bbb"),
+			historyNode("bbb", "aaa"),
+			historyNode("aaa"),
+		}, false, ""))
+	})
+
+	repo, _, cleanup := newTestRepoWithGraphQL(t, mux)
+	defer cleanup()
+
+	commits, err := repo.fetchCommitHistoryGraphQL(context.Background(), "aaa", "", git.PathFilter("services/api"))
+	require.NoError(t, err)
+	require.Len(t, commits, 2)
+	require.Equal(t, "ccc", commits[0].Sha)
+	require.Equal(t, "bbb", commits[1].Sha)
+}
+
+func TestCommitLog_GraphQLHistory_FallsBackToREST(t *testing.T) {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/graphql", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]interface{}{
+			"data": map[string]interface{}{
+				"repository": map[string]interface{}{"object": nil},
+			},
+		})
+	})
+	mux.HandleFunc("/api/v3/repos/testowner/testrepo/commits", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, []map[string]interface{}{
+			{
+				"sha":     "rest_commit",
+				"commit":  map[string]interface{}{"message": "from REST", "committer": map[string]interface{}{"date": "2025-01-01T00:00:00Z"}},
+				"parents": []map[string]interface{}{},
+			},
+		})
+	})
+
+	repo, _, cleanup := newTestRepoWithGraphQL(t, mux)
+	defer cleanup()
+
+	commits, err := repo.CommitLog(context.Background(), "", "unknown")
+	require.NoError(t, err)
+	require.Len(t, commits, 1)
+	require.Equal(t, "rest_commit", commits[0].Sha)
+}
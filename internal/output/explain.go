@@ -6,7 +6,6 @@ import (
 	"strings"
 
 	"github.com/MyCarrier-DevOps/go-gitsemver/internal/calculator"
-	"github.com/MyCarrier-DevOps/go-gitsemver/internal/strategy"
 )
 
 // strategyOrder defines the display order for strategies.
@@ -23,14 +22,27 @@ var strategyOrder = []string{
 // calculation to w. It shows all strategy candidates, the selected winner,
 // increment reasoning, pre-release tag resolution, and the final result.
 func WriteExplanation(w io.Writer, result calculator.VersionResult) error {
-	// Group candidates by strategy name.
-	byStrategy := make(map[string][]strategy.BaseVersion)
-	for _, c := range result.AllCandidates {
+	// Group candidate indices by strategy name.
+	byStrategy := make(map[string][]int)
+	for i, c := range result.AllCandidates {
 		name := ""
 		if c.Explanation != nil {
 			name = c.Explanation.Strategy
 		}
-		byStrategy[name] = append(byStrategy[name], c)
+		byStrategy[name] = append(byStrategy[name], i)
+	}
+
+	// --- Branch config ---
+	if result.BranchConfig != nil {
+		if _, err := fmt.Fprintln(w, "Branch config:"); err != nil {
+			return err
+		}
+		if err := WriteBranchMatches(w, "  ", result.BranchConfig.Branch, result.BranchConfig.Matches); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintln(w); err != nil {
+			return err
+		}
 	}
 
 	// --- Strategies evaluated ---
@@ -46,7 +58,8 @@ func WriteExplanation(w io.Writer, result calculator.VersionResult) error {
 			}
 			continue
 		}
-		for i, c := range candidates {
+		for i, idx := range candidates {
+			c := result.AllCandidates[idx]
 			label := name + ":"
 			if i > 0 {
 				label = ""
@@ -55,8 +68,8 @@ func WriteExplanation(w io.Writer, result calculator.VersionResult) error {
 			if c.BaseVersionSource != nil {
 				source = c.BaseVersionSource.ShortSha()
 			}
-			if _, err := fmt.Fprintf(w, "  %-22s %s (source: %s, increment: %t)\n",
-				label, c.SemanticVersion.SemVer(), source, c.ShouldIncrement); err != nil {
+			if _, err := fmt.Fprintf(w, "  %-22s %s (source: %s, increment: %t)%s\n",
+				label, c.SemanticVersion.SemVer(), source, c.ShouldIncrement, evaluationSuffix(result, idx)); err != nil {
 				return err
 			}
 
@@ -130,6 +143,20 @@ func WriteExplanation(w io.Writer, result calculator.VersionResult) error {
 
 const arrowPrefix = "\u2192"
 
+// evaluationSuffix describes how candidate i fared in selection: its
+// effective version and rank, or why it was ignored. Empty when the
+// calculation did not rank candidates.
+func evaluationSuffix(result calculator.VersionResult, i int) string {
+	if i >= len(result.Evaluations) {
+		return ""
+	}
+	ev := result.Evaluations[i]
+	if ev.IgnoredReason != "" {
+		return fmt.Sprintf(" %s ignored: %s", arrowPrefix, ev.IgnoredReason)
+	}
+	return fmt.Sprintf(" %s effective %s, rank %d", arrowPrefix, ev.EffectiveVersion.SemVer(), ev.Rank)
+}
+
 // FormatExplanation returns the explain output as a string.
 func FormatExplanation(result calculator.VersionResult) string {
 	var sb strings.Builder
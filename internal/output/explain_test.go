@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/MyCarrier-DevOps/go-gitsemver/internal/calculator"
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/config"
 	"github.com/MyCarrier-DevOps/go-gitsemver/internal/git"
 	"github.com/MyCarrier-DevOps/go-gitsemver/internal/semver"
 	"github.com/MyCarrier-DevOps/go-gitsemver/internal/strategy"
@@ -181,3 +182,47 @@ func TestFormatExplanation_ReturnsString(t *testing.T) {
 	out := FormatExplanation(result)
 	require.Contains(t, out, "Result: 1.0.0")
 }
+
+func TestWriteExplanation_BranchConfig(t *testing.T) {
+	result := calculator.VersionResult{
+		Version: semver.SemanticVersion{Major: 1},
+		BranchConfig: &calculator.BranchConfigExplanation{
+			Branch: "main",
+			Matches: []config.BranchMatch{
+				{Name: "main", Regex: `^master$|^main$`, Priority: 100},
+				{Name: "unknown", Regex: `.*`, Priority: 0},
+			},
+		},
+	}
+
+	out := FormatExplanation(result)
+	require.Contains(t, out, "Branch config:\n  \"main\" matched 2 branch configs:\n")
+	require.Contains(t, out, "main    priority 100  regex ^master$|^main$  (selected)")
+}
+
+func TestWriteExplanation_Evaluations(t *testing.T) {
+	kept := makeCommit("abc1234567890abcdef1234567890abcdef123456", "v1.0.0")
+	dropped := makeCommit("def1234567890abcdef1234567890abcdef123456", "v3.0.0")
+	candidate := func(v semver.SemanticVersion, source *git.Commit) strategy.BaseVersion {
+		return strategy.BaseVersion{
+			Source:            "Git tag",
+			ShouldIncrement:   true,
+			SemanticVersion:   v,
+			BaseVersionSource: source,
+			Explanation:       &strategy.Explanation{Strategy: "TaggedCommit"},
+		}
+	}
+	result := calculator.VersionResult{
+		Version:       semver.SemanticVersion{Major: 1, Patch: 1},
+		BaseVersion:   candidate(semver.SemanticVersion{Major: 1}, kept),
+		AllCandidates: []strategy.BaseVersion{candidate(semver.SemanticVersion{Major: 1}, kept), candidate(semver.SemanticVersion{Major: 3}, dropped)},
+		Evaluations: []calculator.CandidateEvaluation{
+			{EffectiveVersion: semver.SemanticVersion{Major: 1, Patch: 1}, Rank: 1},
+			{IgnoredReason: "source commit def1234 is listed in ignore.sha"},
+		},
+	}
+
+	out := FormatExplanation(result)
+	require.Contains(t, out, "1.0.0 (source: abc1234, increment: true) → effective 1.0.1, rank 1\n")
+	require.Contains(t, out, "3.0.0 (source: def1234, increment: true) → ignored: source commit def1234 is listed in ignore.sha\n")
+}
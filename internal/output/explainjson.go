@@ -0,0 +1,161 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/calculator"
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/strategy"
+)
+
+// Explanation is the machine-readable form of the explain output.
+type Explanation struct {
+	Branch            string               `json:"branch"`
+	BranchConfig      *ExplainBranchConfig `json:"branchConfig,omitempty"`
+	Candidates        []ExplainCandidate   `json:"candidates"`        // in strategy order
+	IgnoredCandidates []ExplainCandidate   `json:"ignoredCandidates"` // removed by the ignore config
+	Selected          *ExplainCandidate    `json:"selected"`          // nil when the current commit is tagged
+	Increment         *ExplainIncrement    `json:"increment,omitempty"`
+	PreReleaseSteps   []string             `json:"preReleaseSteps"`
+	Version           string               `json:"version"`
+}
+
+// ExplainBranchConfig lists the branch configurations matching the branch.
+type ExplainBranchConfig struct {
+	Selected string               `json:"selected,omitempty"`
+	Matches  []ExplainBranchMatch `json:"matches"`
+}
+
+// ExplainBranchMatch is one branch configuration whose regex matched.
+type ExplainBranchMatch struct {
+	Name     string `json:"name"`
+	Regex    string `json:"regex"`
+	Priority int    `json:"priority"`
+}
+
+// ExplainCandidate is a base version produced by a strategy.
+type ExplainCandidate struct {
+	Strategy        string   `json:"strategy"`
+	Source          string   `json:"source"`
+	Version         string   `json:"version"`
+	Commit          string   `json:"commit,omitempty"` // empty for external sources
+	ShouldIncrement bool     `json:"shouldIncrement"`
+	Steps           []string `json:"steps"`
+
+	// EffectiveVersion and Rank are set for ranked candidates; rank 1 won.
+	EffectiveVersion string `json:"effectiveVersion,omitempty"`
+	Rank             int    `json:"rank,omitempty"`
+
+	// IgnoredReason is set for candidates removed by the ignore config.
+	IgnoredReason string `json:"ignoredReason,omitempty"`
+}
+
+// ExplainIncrement records how the increment was decided.
+type ExplainIncrement struct {
+	// DecidingField is the highest increment found in commit messages.
+	DecidingField  string   `json:"decidingField"`
+	DecidingCommit string   `json:"decidingCommit,omitempty"`
+	Steps          []string `json:"steps"`
+}
+
+// NewExplanation builds the machine-readable explanation of result.
+func NewExplanation(result calculator.VersionResult) Explanation {
+	e := Explanation{
+		Branch:            result.BranchName,
+		Candidates:        []ExplainCandidate{},
+		IgnoredCandidates: []ExplainCandidate{},
+		PreReleaseSteps:   nonNil(result.PreReleaseSteps),
+		Version:           result.Version.FullSemVer(),
+	}
+
+	for i, c := range result.AllCandidates {
+		candidate := explainCandidate(c)
+		if i < len(result.Evaluations) {
+			ev := result.Evaluations[i]
+			candidate.IgnoredReason = ev.IgnoredReason
+			if ev.Rank > 0 {
+				candidate.EffectiveVersion = ev.EffectiveVersion.SemVer()
+				candidate.Rank = ev.Rank
+			}
+		}
+		e.Candidates = append(e.Candidates, candidate)
+		if candidate.IgnoredReason != "" {
+			e.IgnoredCandidates = append(e.IgnoredCandidates, candidate)
+		}
+	}
+
+	if bc := result.BranchConfig; bc != nil {
+		e.Branch = bc.Branch
+		e.BranchConfig = &ExplainBranchConfig{Matches: []ExplainBranchMatch{}}
+		for i, m := range bc.Matches {
+			if i == 0 {
+				e.BranchConfig.Selected = m.Name
+			}
+			e.BranchConfig.Matches = append(e.BranchConfig.Matches, ExplainBranchMatch{
+				Name:     m.Name,
+				Regex:    m.Regex,
+				Priority: m.Priority,
+			})
+		}
+	}
+
+	for i := range e.Candidates {
+		if e.Candidates[i].Rank == 1 {
+			selected := e.Candidates[i]
+			e.Selected = &selected
+		}
+	}
+	if e.Selected == nil && result.BaseVersion.Source != "" {
+		selected := explainCandidate(result.BaseVersion)
+		e.Selected = &selected
+	}
+
+	if exp := result.IncrementExplanation; exp != nil {
+		e.Increment = &ExplainIncrement{
+			DecidingField: exp.DecidingField.String(),
+			Steps:         nonNil(exp.Steps),
+		}
+		if exp.DecidingCommit != nil {
+			e.Increment.DecidingCommit = exp.DecidingCommit.Sha
+		}
+	}
+
+	return e
+}
+
+// WriteExplanationJSON writes the explanation of result to w as indented
+// JSON.
+func WriteExplanationJSON(w io.Writer, result calculator.VersionResult) error {
+	data, err := json.MarshalIndent(NewExplanation(result), "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling explanation: %w", err)
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+func explainCandidate(c strategy.BaseVersion) ExplainCandidate {
+	ec := ExplainCandidate{
+		Source:          c.Source,
+		Version:         c.SemanticVersion.SemVer(),
+		ShouldIncrement: c.ShouldIncrement,
+		Steps:           []string{},
+	}
+	if c.BaseVersionSource != nil {
+		ec.Commit = c.BaseVersionSource.Sha
+	}
+	if c.Explanation != nil {
+		ec.Strategy = c.Explanation.Strategy
+		ec.Steps = nonNil(c.Explanation.Steps)
+	}
+	return ec
+}
+
+// nonNil returns s, or an empty slice so that JSON shows [] rather than null.
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
@@ -0,0 +1,85 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/calculator"
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/config"
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/semver"
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/strategy"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteExplanationJSON(t *testing.T) {
+	source := makeCommit("abc1234567890abcdef1234567890abcdef123456", "v1.0.0")
+	old := makeCommit("def1234567890abcdef1234567890abcdef123456", "v0.9.0")
+	tagged := strategy.BaseVersion{
+		Source:            "Git tag 'v1.0.0'",
+		ShouldIncrement:   true,
+		SemanticVersion:   semver.SemanticVersion{Major: 1},
+		BaseVersionSource: source,
+		Explanation:       &strategy.Explanation{Strategy: "TaggedCommit", Steps: []string{"found tag v1.0.0"}},
+	}
+	ignored := strategy.BaseVersion{
+		Source:            "Git tag 'v0.9.0'",
+		SemanticVersion:   semver.SemanticVersion{Minor: 9},
+		BaseVersionSource: old,
+		Explanation:       &strategy.Explanation{Strategy: "TaggedCommit"},
+	}
+	result := calculator.VersionResult{
+		Version:       semver.SemanticVersion{Major: 1, Minor: 1},
+		BaseVersion:   tagged,
+		BranchName:    "main",
+		AllCandidates: []strategy.BaseVersion{tagged, ignored},
+		Evaluations: []calculator.CandidateEvaluation{
+			{EffectiveVersion: semver.SemanticVersion{Major: 1, Patch: 1}, Rank: 1},
+			{IgnoredReason: "source commit def1234 is listed in ignore.sha"},
+		},
+		IncrementExplanation: &calculator.IncrementExplanation{
+			Steps:          []string{"highest increment from commits: Minor"},
+			DecidingCommit: source,
+			DecidingField:  semver.VersionFieldMinor,
+		},
+		BranchConfig: &calculator.BranchConfigExplanation{
+			Branch:  "main",
+			Matches: []config.BranchMatch{{Name: "main", Regex: "^main$", Priority: 100}},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteExplanationJSON(&buf, result))
+
+	var got Explanation
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	require.Equal(t, "main", got.Branch)
+	require.Equal(t, "main", got.BranchConfig.Selected)
+	require.Len(t, got.Candidates, 2)
+	require.Equal(t, "TaggedCommit", got.Selected.Strategy)
+	require.Equal(t, source.Sha, got.Selected.Commit)
+	require.Equal(t, []string{"found tag v1.0.0"}, got.Selected.Steps)
+	require.Equal(t, "1.0.1", got.Selected.EffectiveVersion)
+	require.Equal(t, "1.0.1", got.Candidates[0].EffectiveVersion)
+	require.Equal(t, 1, got.Candidates[0].Rank)
+	require.Len(t, got.IgnoredCandidates, 1)
+	require.Equal(t, "0.9.0", got.IgnoredCandidates[0].Version)
+	require.Equal(t, "source commit def1234 is listed in ignore.sha", got.IgnoredCandidates[0].IgnoredReason)
+	require.Zero(t, got.IgnoredCandidates[0].Rank)
+	require.Equal(t, "Minor", got.Increment.DecidingField)
+	require.Equal(t, source.Sha, got.Increment.DecidingCommit)
+	require.Equal(t, "1.1.0", got.Version)
+	require.Contains(t, buf.String(), `"preReleaseSteps": []`)
+}
+
+func TestWriteExplanationJSON_TaggedCommit(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteExplanationJSON(&buf, calculator.VersionResult{
+		Version:    semver.SemanticVersion{Major: 2},
+		BranchName: "main",
+	}))
+	require.Contains(t, buf.String(), `"selected": null`)
+	require.Contains(t, buf.String(), `"candidates": []`)
+	require.NotContains(t, buf.String(), `"increment"`)
+}
@@ -0,0 +1,166 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/calculator"
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/semver"
+)
+
+// History graph formats.
+const (
+	GraphFormatDOT     = "dot"
+	GraphFormatMermaid = "mermaid"
+)
+
+// maxGraphSubject bounds the commit subject shown in a graph node.
+const maxGraphSubject = 50
+
+// WriteGraph renders g to w as a Graphviz DOT or Mermaid flowchart. Edges
+// point from each commit to its parents, so the current commit is on top.
+func WriteGraph(w io.Writer, g calculator.HistoryGraph, format string) error {
+	switch format {
+	case GraphFormatDOT:
+		return writeDOT(w, g)
+	case GraphFormatMermaid:
+		return writeMermaid(w, g)
+	default:
+		return fmt.Errorf("unknown graph format %q", format)
+	}
+}
+
+func writeDOT(w io.Writer, g calculator.HistoryGraph) error {
+	var sb strings.Builder
+	sb.WriteString("digraph history {\n")
+	sb.WriteString("  node [shape=box, style=rounded, fontname=\"monospace\"];\n")
+	for _, n := range g.Nodes {
+		styles := []string{"rounded"}
+		attrs := []string{}
+		if len(n.Tags) > 0 {
+			styles = append(styles, "filled")
+			attrs = append(attrs, `fillcolor="#fff2cc"`)
+		}
+		if n.IsBranchPoint {
+			styles = append(styles, "dashed")
+		}
+		switch {
+		case n.Selected != nil:
+			attrs = append(attrs, `color="#d62728"`, "penwidth=3")
+		case !n.Merge.IsEmpty():
+			attrs = append(attrs, `color="#1f77b4"`)
+		}
+		if n.IsHead && n.Selected == nil {
+			attrs = append(attrs, "penwidth=2")
+		}
+		label := strings.ReplaceAll(dotEscape(strings.Join(graphLabel(n, g), "\n")), "\n", `\n`)
+		fmt.Fprintf(&sb, "  %q [label=\"%s\", style=%q", n.Commit.ShortSha(), label, strings.Join(styles, ","))
+		for _, a := range attrs {
+			sb.WriteString(", " + a)
+		}
+		sb.WriteString("];\n")
+	}
+	for _, n := range g.Nodes {
+		for _, p := range n.Parents {
+			fmt.Fprintf(&sb, "  %q -> %q;\n", n.Commit.ShortSha(), shortSha(p))
+		}
+	}
+	sb.WriteString("}\n")
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func writeMermaid(w io.Writer, g calculator.HistoryGraph) error {
+	var sb strings.Builder
+	sb.WriteString("flowchart TB\n")
+	classes := make(map[string][]string)
+	for _, n := range g.Nodes {
+		id := "c" + n.Commit.ShortSha()
+		fmt.Fprintf(&sb, "  %s[\"%s\"]\n", id, mermaidEscape(strings.Join(graphLabel(n, g), "<br/>")))
+		if len(n.Tags) > 0 {
+			classes["tag"] = append(classes["tag"], id)
+		}
+		if !n.Merge.IsEmpty() {
+			classes["merge"] = append(classes["merge"], id)
+		}
+		if n.IsBranchPoint {
+			classes["branchpoint"] = append(classes["branchpoint"], id)
+		}
+		if n.Selected != nil {
+			classes["selected"] = append(classes["selected"], id)
+		}
+	}
+	for _, n := range g.Nodes {
+		for _, p := range n.Parents {
+			fmt.Fprintf(&sb, "  c%s --> c%s\n", n.Commit.ShortSha(), shortSha(p))
+		}
+	}
+	sb.WriteString("  classDef tag fill:#fff2cc\n")
+	sb.WriteString("  classDef merge stroke:#1f77b4\n")
+	sb.WriteString("  classDef branchpoint stroke-dasharray:5 5\n")
+	sb.WriteString("  classDef selected stroke:#d62728,stroke-width:3px\n")
+	for _, class := range []string{"tag", "merge", "branchpoint", "selected"} {
+		if ids := classes[class]; len(ids) > 0 {
+			fmt.Fprintf(&sb, "  class %s %s\n", strings.Join(ids, ","), class)
+		}
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// graphLabel returns the lines shown in the node for n: the commit, then
+// whatever it contributed to the calculation.
+func graphLabel(n calculator.GraphNode, g calculator.HistoryGraph) []string {
+	subject := n.Commit.Message
+	if idx := strings.IndexByte(subject, '\n'); idx >= 0 {
+		subject = subject[:idx]
+	}
+	if r := []rune(subject); len(r) > maxGraphSubject {
+		subject = string(r[:maxGraphSubject-3]) + "..."
+	}
+
+	head := n.Commit.ShortSha()
+	if n.IsHead {
+		head += " (HEAD)"
+	}
+	lines := []string{head + " " + subject}
+	if len(n.Tags) > 0 {
+		lines = append(lines, "tag: "+strings.Join(n.Tags, ", "))
+	}
+	if !n.Merge.IsEmpty() {
+		merge := "merge (" + n.Merge.FormatName + ")"
+		if n.Merge.PullRequestNumber > 0 {
+			merge += fmt.Sprintf(" #%d", n.Merge.PullRequestNumber)
+		}
+		if n.Merge.MergedBranch != "" {
+			merge += " from " + n.Merge.MergedBranch
+		}
+		lines = append(lines, merge)
+	}
+	if n.IsBranchPoint && g.BranchPoint != nil {
+		lines = append(lines, "branched from "+g.BranchPoint.Branch.FriendlyName())
+	}
+	if n.Bump != semver.VersionFieldNone {
+		lines = append(lines, "bump: "+n.Bump.String())
+	}
+	if n.Selected != nil {
+		lines = append(lines, fmt.Sprintf("selected: %s (%s)", n.Selected.Source, n.Selected.Version.SemVer()))
+	}
+	return lines
+}
+
+func shortSha(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
+
+func dotEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s)
+}
+
+func mermaidEscape(s string) string {
+	return strings.ReplaceAll(s, `"`, "#quot;")
+}
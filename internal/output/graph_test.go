@@ -0,0 +1,70 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/calculator"
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/git"
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/semver"
+
+	"github.com/stretchr/testify/require"
+)
+
+func sampleGraph() calculator.HistoryGraph {
+	base := git.Commit{Sha: "aaa1111000000000000000000000000000000000", Message: "initial"}
+	head := git.Commit{
+		Sha:     "ccc3333000000000000000000000000000000000",
+		Message: "Merge pull request #12 from org/fix \"quoted\"",
+		Parents: []string{base.Sha, "bbb2222000000000000000000000000000000000"},
+	}
+	return calculator.HistoryGraph{
+		BranchPoint: &git.BranchCommit{Branch: git.Branch{Name: git.NewBranchReferenceName("develop")}, Commit: base},
+		Nodes: []calculator.GraphNode{
+			{
+				Commit:  head,
+				Parents: []string{base.Sha},
+				Merge:   git.MergeMessage{FormatName: "GitHubPull", MergedBranch: "org/fix", PullRequestNumber: 12},
+				Bump:    semver.VersionFieldMinor,
+				IsHead:  true,
+			},
+			{
+				Commit:        base,
+				Tags:          []string{"v1.0.0"},
+				IsBranchPoint: true,
+				Selected:      &calculator.SelectedCandidate{Source: "Git tag 'v1.0.0'", Version: semver.SemanticVersion{Major: 1}},
+			},
+		},
+	}
+}
+
+func TestWriteGraph_DOT(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteGraph(&buf, sampleGraph(), GraphFormatDOT))
+
+	out := buf.String()
+	require.Contains(t, out, "digraph history {\n")
+	require.Contains(t, out, `"ccc3333" [label="ccc3333 (HEAD) Merge pull request #12 from org/fix \"quoted\"\nmerge (GitHubPull) #12 from org/fix\nbump: Minor", style="rounded", color="#1f77b4", penwidth=2];`)
+	require.Contains(t, out, `"aaa1111" [label="aaa1111 initial\ntag: v1.0.0\nbranched from develop\nselected: Git tag 'v1.0.0' (1.0.0)", style="rounded,filled,dashed", fillcolor="#fff2cc", color="#d62728", penwidth=3];`)
+	require.Contains(t, out, `"ccc3333" -> "aaa1111";`)
+	require.NotContains(t, out, "bbb2222")
+}
+
+func TestWriteGraph_Mermaid(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteGraph(&buf, sampleGraph(), GraphFormatMermaid))
+
+	out := buf.String()
+	require.Contains(t, out, "flowchart TB\n")
+	require.Contains(t, out, `  cccc3333["ccc3333 (HEAD) Merge pull request #12 from org/fix #quot;quoted#quot;<br/>merge (GitHubPull) #12 from org/fix<br/>bump: Minor"]`)
+	require.Contains(t, out, "  cccc3333 --> caaa1111\n")
+	require.Contains(t, out, "  class caaa1111 tag\n")
+	require.Contains(t, out, "  class cccc3333 merge\n")
+	require.Contains(t, out, "  class caaa1111 branchpoint\n")
+	require.Contains(t, out, "  class caaa1111 selected\n")
+}
+
+func TestWriteGraph_UnknownFormat(t *testing.T) {
+	err := WriteGraph(&bytes.Buffer{}, sampleGraph(), "svg")
+	require.ErrorContains(t, err, `unknown graph format "svg"`)
+}
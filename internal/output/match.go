@@ -0,0 +1,42 @@
+package output
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/config"
+)
+
+// WriteBranchMatches writes the branch configurations that matched branch,
+// one per line in selection order, marking the selected one. indent
+// prefixes every line.
+func WriteBranchMatches(w io.Writer, indent, branch string, matches []config.BranchMatch) error {
+	if len(matches) == 0 {
+		_, err := fmt.Fprintf(w, "%sno branch configuration matches %q\n", indent, branch)
+		return err
+	}
+
+	plural := "s"
+	if len(matches) == 1 {
+		plural = ""
+	}
+	if _, err := fmt.Fprintf(w, "%s%q matched %d branch config%s:\n", indent, branch, len(matches), plural); err != nil {
+		return err
+	}
+
+	width := 0
+	for _, m := range matches {
+		width = max(width, len(m.Name))
+	}
+	for i, m := range matches {
+		marker := ""
+		if i == 0 {
+			marker = "  (selected)"
+		}
+		if _, err := fmt.Fprintf(w, "%s  %s %-*s priority %-4d regex %s%s\n",
+			indent, arrowPrefix, width, m.Name, m.Priority, m.Regex, marker); err != nil {
+			return err
+		}
+	}
+	return nil
+}
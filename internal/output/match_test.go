@@ -0,0 +1,29 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/config"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteBranchMatches(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteBranchMatches(&buf, "", "feature/login", []config.BranchMatch{
+		{Name: "feature", Regex: `^features?[/-]`, Priority: 50},
+		{Name: "unknown", Regex: `.*`, Priority: 0},
+	})
+	require.NoError(t, err)
+	require.Equal(t, `"feature/login" matched 2 branch configs:
+  → feature priority 50   regex ^features?[/-]  (selected)
+  → unknown priority 0    regex .*
+`, buf.String())
+}
+
+func TestWriteBranchMatches_None(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteBranchMatches(&buf, "  ", "develop", nil))
+	require.Equal(t, "  no branch configuration matches \"develop\"\n", buf.String())
+}
@@ -0,0 +1,34 @@
+package output
+
+import (
+	"fmt"
+
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/calculator"
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/semver"
+)
+
+// FormatSummary returns a one-line summary of the calculated version suitable
+// for a commit status or check run, e.g. "2.0.0 (breaking change in abc1234)".
+// The reason is only included when the result was calculated with explain.
+func FormatSummary(result calculator.VersionResult) string {
+	summary := result.Version.SemVer()
+
+	exp := result.IncrementExplanation
+	if exp == nil || exp.DecidingCommit == nil {
+		return summary
+	}
+
+	return fmt.Sprintf("%s (%s in %s)", summary, incrementReason(exp.DecidingField), exp.DecidingCommit.ShortSha())
+}
+
+// incrementReason describes why a commit bumped the given field.
+func incrementReason(field semver.VersionField) string {
+	switch field {
+	case semver.VersionFieldMajor:
+		return "breaking change"
+	case semver.VersionFieldMinor:
+		return "new feature"
+	default:
+		return "fix"
+	}
+}
@@ -0,0 +1,38 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/calculator"
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/semver"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatSummary_WithDecidingCommit(t *testing.T) {
+	result := calculator.VersionResult{
+		Version: semver.SemanticVersion{Major: 2},
+		IncrementExplanation: &calculator.IncrementExplanation{
+			DecidingCommit: makeCommit("abc1234567890abcdef1234567890abcdef123456", "feat!: drop v1"),
+			DecidingField:  semver.VersionFieldMajor,
+		},
+	}
+	require.Equal(t, "2.0.0 (breaking change in abc1234)", FormatSummary(result))
+
+	result.IncrementExplanation.DecidingField = semver.VersionFieldMinor
+	require.Equal(t, "2.0.0 (new feature in abc1234)", FormatSummary(result))
+
+	result.IncrementExplanation.DecidingField = semver.VersionFieldPatch
+	require.Equal(t, "2.0.0 (fix in abc1234)", FormatSummary(result))
+}
+
+func TestFormatSummary_VersionOnly(t *testing.T) {
+	n := int64(4)
+	result := calculator.VersionResult{
+		Version: semver.SemanticVersion{Major: 1, Minor: 2, Patch: 3, PreReleaseTag: semver.PreReleaseTag{Name: "beta", Number: &n}},
+	}
+	require.Equal(t, "1.2.3-beta.4", FormatSummary(result))
+
+	result.IncrementExplanation = &calculator.IncrementExplanation{}
+	require.Equal(t, "1.2.3-beta.4", FormatSummary(result))
+}
@@ -11,8 +11,7 @@ func GetVariables(
 	ver semver.SemanticVersion,
 	ec config.EffectiveConfiguration,
 ) map[string]string {
-	// Apply ContinuousDeployment promotion if needed.
-	promoted := PromoteCommitsToPreRelease(ver, ec.BranchMode, ec.ContinuousDeploymentFallbackTag)
+	promoted := PromotedVersion(ver, ec)
 
 	cfg := semver.FormatConfig{
 		Padding:             ec.LegacySemVerPadding,
@@ -20,5 +19,13 @@ func GetVariables(
 		TagPreReleaseWeight: ec.TagPreReleaseWeight,
 	}
 
-	return semver.ComputeFormatValues(promoted, cfg)
+	vars := semver.ComputeFormatValues(promoted, cfg)
+	vars["BranchConfigName"] = ec.BranchConfigName
+	return vars
+}
+
+// PromotedVersion returns the version the output variables describe: ver
+// with ContinuousDeployment promotion applied if the branch mode needs it.
+func PromotedVersion(ver semver.SemanticVersion, ec config.EffectiveConfiguration) semver.SemanticVersion {
+	return PromoteCommitsToPreRelease(ver, ec.BranchMode, ec.ContinuousDeploymentFallbackTag)
 }
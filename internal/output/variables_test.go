@@ -24,9 +24,11 @@ func TestGetVariables_Basic(t *testing.T) {
 		LegacySemVerPadding: 4,
 		CommitDateFormat:    "2006-01-02",
 		TagPreReleaseWeight: 60000,
+		BranchConfigName:    "main",
 	}
 
 	vars := GetVariables(ver, ec)
+	require.Equal(t, "main", vars["BranchConfigName"])
 	require.Equal(t, "1", vars["Major"])
 	require.Equal(t, "2", vars["Minor"])
 	require.Equal(t, "3", vars["Patch"])
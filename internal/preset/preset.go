@@ -0,0 +1,324 @@
+// Package preset detects how a repository is versioned — its branching
+// workflow, tag prefix and commit message convention — and renders a starter
+// configuration from the matching example in docs/examples.
+package preset
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/MyCarrier-DevOps/go-gitsemver/docs/examples"
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/calculator"
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/config"
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/git"
+)
+
+// Workflow presets chosen by Detect.
+const (
+	GitFlow    = "gitflow"
+	GitHubFlow = "github-flow"
+	TrunkBased = "trunk-based"
+	Minimal    = "minimal"
+)
+
+const (
+	// historyLimit is the number of mainline commits inspected.
+	historyLimit = 200
+	// minConventionalSample is the number of non-merge commits needed before
+	// Conventional Commits are considered prevalent.
+	minConventionalSample = 5
+)
+
+// conventionalTypes are the commit types of the Conventional Commits
+// specification and its common Angular extension.
+var conventionalTypes = map[string]bool{
+	"feat": true, "fix": true, "docs": true, "style": true, "refactor": true,
+	"perf": true, "test": true, "build": true, "ci": true, "chore": true,
+	"revert": true,
+}
+
+var (
+	// versionTagRe splits a tag into its prefix and a version.
+	versionTagRe = regexp.MustCompile(`^(.*?)\d+\.\d+(?:\.\d+)?(?:[-+][0-9A-Za-z.+-]*)?$`)
+	// squashMergeRe matches the "(#123)" suffix GitHub adds to squash merges.
+	squashMergeRe = regexp.MustCompile(`\(#\d+\)\s*$`)
+)
+
+// Detection describes what Detect found out about a repository.
+type Detection struct {
+	// Preset is the name of the matching workflow preset.
+	Preset string
+
+	// TagPrefix is the tag-prefix regex matching the version tags in use.
+	// Nil when the repository has no version tags.
+	TagPrefix *string
+
+	// ConventionalCommits is true when most recent mainline commits follow
+	// Conventional Commits.
+	ConventionalCommits bool
+
+	// Reasons explains each finding, one sentence per entry.
+	Reasons []string
+}
+
+func (d *Detection) addf(format string, args ...any) {
+	d.Reasons = append(d.Reasons, fmt.Sprintf(format, args...))
+}
+
+// Names returns the available preset names in alphabetical order.
+func Names() []string {
+	files, _ := fs.Glob(examples.FS, "*.yml")
+	names := make([]string, 0, len(files))
+	for _, f := range files {
+		names = append(names, strings.TrimSuffix(f, path.Ext(f)))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Load returns the example configuration of the named preset.
+func Load(name string) ([]byte, error) {
+	data, err := fs.ReadFile(examples.FS, name+".yml")
+	if err != nil {
+		return nil, fmt.Errorf("unknown preset %q (available: %s)", name, strings.Join(Names(), ", "))
+	}
+	return data, nil
+}
+
+// Detect inspects the branches, tags and mainline history of the repository
+// behind store. cfg supplies the branch regexes used to classify branches,
+// normally the built-in defaults.
+func Detect(store *git.RepositoryStore, cfg *config.Config) (Detection, error) {
+	var d Detection
+
+	history, mainName, err := mainlineHistory(store, cfg)
+	if err != nil {
+		return d, err
+	}
+	if err := detectWorkflow(&d, store, cfg, history, mainName); err != nil {
+		return d, err
+	}
+	if err := detectTagPrefix(&d, store); err != nil {
+		return d, err
+	}
+	detectConvention(&d, history, mainName)
+	return d, nil
+}
+
+// mainlineHistory returns up to historyLimit first-parent commits of the
+// main branch, or of HEAD when there is no main branch.
+func mainlineHistory(store *git.RepositoryStore, cfg *config.Config) ([]git.Commit, string, error) {
+	branch, found, err := store.FindMainBranch(cfg)
+	if err != nil {
+		return nil, "", err
+	}
+	if !found {
+		if branch, err = store.GetTargetBranch(""); err != nil {
+			return nil, "", fmt.Errorf("resolving HEAD: %w", err)
+		}
+	}
+	if branch.Tip == nil {
+		return nil, branch.FriendlyName(), nil
+	}
+
+	history, err := store.GetMainlineCommitLog(git.Commit{}, *branch.Tip)
+	if err != nil {
+		return nil, "", fmt.Errorf("reading history of %s: %w", branch.FriendlyName(), err)
+	}
+	if len(history) > historyLimit {
+		history = history[:historyLimit]
+	}
+	return history, branch.FriendlyName(), nil
+}
+
+// detectWorkflow picks GitFlow when a develop branch exists, GitHub Flow when
+// the mainline is mostly built from merged pull requests, and trunk-based
+// development otherwise.
+func detectWorkflow(d *Detection, store *git.RepositoryStore, cfg *config.Config, history []git.Commit, mainName string) error {
+	branches, err := store.GetBranches()
+	if err != nil {
+		return fmt.Errorf("listing branches: %w", err)
+	}
+	for _, b := range branches {
+		name := b.Name.WithoutRemote
+		if b.IsDetachedHead || name == "HEAD" {
+			continue
+		}
+		matches, err := cfg.MatchBranchConfigurations(name)
+		if err != nil {
+			return err
+		}
+		if len(matches) > 0 && matches[0].Name == "develop" {
+			d.Preset = GitFlow
+			d.addf("branch %s matches the develop branch configuration", b.FriendlyName())
+			return nil
+		}
+	}
+
+	if len(history) == 0 {
+		d.Preset = Minimal
+		d.addf("%s has no commits to inspect", mainName)
+		return nil
+	}
+
+	merged := 0
+	for _, c := range history {
+		subject, _, _ := strings.Cut(c.Message, "\n")
+		if c.IsMerge() || squashMergeRe.MatchString(subject) {
+			merged++
+		}
+	}
+	// Trunk-based teams merge the odd branch too; require one commit in ten
+	// to come from a pull request before calling it GitHub Flow.
+	if merged > 0 && merged*10 >= len(history) {
+		d.Preset = GitHubFlow
+		d.addf("%d of the last %d commits on %s merge a branch or pull request", merged, len(history), mainName)
+		return nil
+	}
+	d.Preset = TrunkBased
+	d.addf("%d of the last %d commits on %s were committed directly", len(history)-merged, len(history), mainName)
+	return nil
+}
+
+// detectTagPrefix finds the prefix shared by the version tags. v and V
+// prefixes, with or without unprefixed tags, keep the default '[vV]' style;
+// any other prefix is matched literally, taking the most common one.
+func detectTagPrefix(d *Detection, store *git.RepositoryStore) error {
+	tags, err := store.GetTags()
+	if err != nil {
+		return fmt.Errorf("listing tags: %w", err)
+	}
+
+	counts := make(map[string]int)
+	total := 0
+	for _, t := range tags {
+		if m := versionTagRe.FindStringSubmatch(t.Name.Friendly); m != nil {
+			counts[m[1]]++
+			total++
+		}
+	}
+	if total == 0 {
+		return nil
+	}
+
+	plain, versioned := counts[""], counts["v"]+counts["V"]
+	var prefix string
+	switch {
+	case plain+versioned == total && plain == 0:
+		prefix = "[vV]"
+		d.addf("%d version tags start with v", total)
+	case plain+versioned == total && versioned == 0:
+		prefix = ""
+		d.addf("%d version tags have no prefix", total)
+	case plain+versioned == total:
+		prefix = "[vV]?"
+		d.addf("%d of %d version tags start with v, the rest have no prefix", versioned, total)
+	default:
+		best := ""
+		for p, n := range counts {
+			if n > counts[best] || (n == counts[best] && p < best) {
+				best = p
+			}
+		}
+		prefix = regexp.QuoteMeta(best)
+		d.addf("%d of %d version tags start with %q", counts[best], total, best)
+	}
+	d.TagPrefix = &prefix
+	return nil
+}
+
+// detectConvention reports Conventional Commits as prevalent when at least
+// half of the non-merge commits in history use a known type.
+func detectConvention(d *Detection, history []git.Commit, mainName string) {
+	commits, conventional := 0, 0
+	for _, c := range history {
+		if c.IsMerge() {
+			continue
+		}
+		commits++
+		if conventionalTypes[calculator.ConventionalCommitType(c.Message)] {
+			conventional++
+		}
+	}
+	if commits < minConventionalSample {
+		return
+	}
+	d.ConventionalCommits = conventional*2 >= commits
+	d.addf("%d of the last %d commits on %s follow Conventional Commits", conventional, commits, mainName)
+}
+
+// Render returns the named preset with a header describing d, tag-prefix set
+// to the detected prefix and, when Conventional Commits are prevalent and the
+// preset accepts both conventions, commit-message-convention narrowed to
+// conventional-commits. The preset's comments are kept.
+func Render(name string, d Detection) ([]byte, error) {
+	data, err := Load(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []string
+	for _, line := range strings.Split(strings.TrimRight(string(data), "\n"), "\n") {
+		// The schema path is relative to docs/examples.
+		if strings.HasPrefix(line, "# yaml-language-server:") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	if d.TagPrefix != nil {
+		lines = setKey(lines, "tag-prefix", quote(*d.TagPrefix), nil)
+	}
+	if d.ConventionalCommits {
+		lines = setKey(lines, "commit-message-convention", "conventional-commits", func(old string) bool {
+			return old == "both"
+		})
+	}
+
+	header := []string{"# Generated by go-gitsemver init from the " + name + " preset."}
+	if len(d.Reasons) > 0 {
+		header = append(header, "# Detected:")
+		for _, r := range d.Reasons {
+			header = append(header, "#   - "+r)
+		}
+	}
+	header = append(header, "#")
+
+	return []byte(strings.Join(append(header, lines...), "\n") + "\n"), nil
+}
+
+// setKey sets the top-level scalar key to value. An existing key is
+// replaced when replace is nil or accepts its current value; a missing key
+// is inserted before the first top-level key, or appended.
+func setKey(lines []string, key, value string, replace func(old string) bool) []string {
+	first := -1
+	for i, line := range lines {
+		if line == "" || line[0] == '#' || line[0] == ' ' {
+			continue
+		}
+		if first < 0 {
+			first = i
+		}
+		if rest, ok := strings.CutPrefix(line, key+":"); ok {
+			if replace == nil || replace(strings.TrimSpace(rest)) {
+				lines[i] = key + ": " + value
+			}
+			return lines
+		}
+	}
+
+	entry := key + ": " + value
+	if first < 0 {
+		return append(lines, "", entry)
+	}
+	return append(lines[:first], append([]string{entry}, lines[first:]...)...)
+}
+
+// quote returns s as a single-quoted YAML scalar.
+func quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
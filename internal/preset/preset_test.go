@@ -0,0 +1,138 @@
+package preset
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/config"
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/git"
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/testutil"
+
+	"github.com/stretchr/testify/require"
+)
+
+func detect(t *testing.T, r *testutil.TestRepo) Detection {
+	t.Helper()
+	repo, err := git.Open(r.Path())
+	require.NoError(t, err)
+	cfg, err := config.NewBuilder().Build()
+	require.NoError(t, err)
+	d, err := Detect(git.NewRepositoryStore(repo), cfg)
+	require.NoError(t, err)
+	return d
+}
+
+func TestDetect_GitFlow(t *testing.T) {
+	r := testutil.NewTestRepo(t)
+	sha := r.AddCommit("initial")
+	r.CreateTag("v1.0.0", sha)
+	r.CreateBranch("develop", sha)
+
+	d := detect(t, r)
+	require.Equal(t, GitFlow, d.Preset)
+	require.Contains(t, d.Reasons, "branch develop matches the develop branch configuration")
+	require.NotNil(t, d.TagPrefix)
+	require.Equal(t, "[vV]", *d.TagPrefix)
+}
+
+func TestDetect_GitHubFlowWithConventionalCommits(t *testing.T) {
+	r := testutil.NewTestRepo(t)
+	r.AddCommit("chore: initial")
+	r.AddCommit("feat: add search (#12)")
+	main := r.AddCommit("fix: typo")
+	r.CreateBranch("feature/login", main)
+	r.Checkout("feature/login")
+	login := r.AddCommit("feat: add login")
+	r.Checkout("master")
+	r.MergeCommit("Merge pull request #13 from feature/login", login)
+	r.AddCommit("docs: readme")
+	r.AddCommit("update changelog")
+
+	d := detect(t, r)
+	require.Equal(t, GitHubFlow, d.Preset)
+	require.Contains(t, d.Reasons, "2 of the last 6 commits on master merge a branch or pull request")
+	require.True(t, d.ConventionalCommits)
+	require.Contains(t, d.Reasons, "4 of the last 5 commits on master follow Conventional Commits")
+	require.Nil(t, d.TagPrefix)
+}
+
+func TestDetect_TrunkBasedWithCustomPrefix(t *testing.T) {
+	r := testutil.NewTestRepo(t)
+	var sha string
+	for _, msg := range []string{"one", "two", "three", "four", "five"} {
+		sha = r.AddCommit(msg)
+	}
+	r.CreateTag("release-1.0.0", sha)
+	r.CreateTag("release-1.1.0-rc.1", sha)
+	r.CreateTag("v0.9.0", sha)
+	r.CreateTag("nightly", sha)
+
+	d := detect(t, r)
+	require.Equal(t, TrunkBased, d.Preset)
+	require.False(t, d.ConventionalCommits)
+	require.NotNil(t, d.TagPrefix)
+	require.Equal(t, `release-`, *d.TagPrefix)
+	require.Contains(t, d.Reasons, `2 of 3 version tags start with "release-"`)
+}
+
+func TestDetect_MixedPrefix(t *testing.T) {
+	r := testutil.NewTestRepo(t)
+	sha := r.AddCommit("initial")
+	r.CreateTag("1.0.0", sha)
+	r.CreateTag("v1.1.0", sha)
+
+	d := detect(t, r)
+	require.NotNil(t, d.TagPrefix)
+	require.Equal(t, "[vV]?", *d.TagPrefix)
+}
+
+func TestRender(t *testing.T) {
+	prefix := `release\.`
+	d := Detection{
+		Preset:              GitHubFlow,
+		TagPrefix:           &prefix,
+		ConventionalCommits: true,
+		Reasons:             []string{"found it"},
+	}
+
+	out, err := Render(GitHubFlow, d)
+	require.NoError(t, err)
+	text := string(out)
+	require.True(t, strings.HasPrefix(text, "# Generated by go-gitsemver init from the github-flow preset.\n# Detected:\n#   - found it\n#\n# GitHub Flow\n"))
+	require.NotContains(t, text, "yaml-language-server")
+	require.Contains(t, text, "\ntag-prefix: 'release\\.'\n")
+	require.Contains(t, text, "\ncommit-message-convention: conventional-commits\n")
+	require.Empty(t, config.Validate(out))
+}
+
+func TestRender_KeepsNarrowerConvention(t *testing.T) {
+	out, err := Render("bump-directives", Detection{ConventionalCommits: true})
+	require.NoError(t, err)
+	require.Contains(t, string(out), "\ncommit-message-convention: bump-directive\n")
+}
+
+func TestRender_MinimalAppendsKeys(t *testing.T) {
+	prefix := ""
+	out, err := Render(Minimal, Detection{TagPrefix: &prefix})
+	require.NoError(t, err)
+	require.True(t, strings.HasSuffix(string(out), "\n\ntag-prefix: ''\n"))
+
+	cfg, err := config.LoadFromBytes(out)
+	require.NoError(t, err)
+	require.NotNil(t, cfg.TagPrefix)
+	require.Empty(t, *cfg.TagPrefix)
+}
+
+func TestRender_AllPresetsValidate(t *testing.T) {
+	prefix := "[vV]?"
+	for _, name := range Names() {
+		out, err := Render(name, Detection{TagPrefix: &prefix, ConventionalCommits: true})
+		require.NoError(t, err, name)
+		require.False(t, config.HasErrors(config.Validate(out)), name)
+	}
+}
+
+func TestRender_UnknownPreset(t *testing.T) {
+	_, err := Render("nope", Detection{})
+	require.ErrorContains(t, err, `unknown preset "nope" (available: bump-directives,`)
+}
@@ -18,6 +18,16 @@ func (t PreReleaseTag) HasTag() bool {
 	return t.Name != "" || t.Number != nil
 }
 
+// IntNumber returns a copy of Number as an int, the type the public semver
+// package uses, or nil when the tag has no number.
+func (t PreReleaseTag) IntNumber() *int {
+	if t.Number == nil {
+		return nil
+	}
+	n := int(*t.Number)
+	return &n
+}
+
 // WithName returns a new PreReleaseTag with the given name.
 func (t PreReleaseTag) WithName(name string) PreReleaseTag {
 	return PreReleaseTag{Name: name, Number: t.Number}
@@ -29,6 +29,16 @@ func TestPreReleaseTag_HasTag(t *testing.T) {
 	}
 }
 
+func TestPreReleaseTag_IntNumber(t *testing.T) {
+	require.Nil(t, PreReleaseTag{Name: "beta"}.IntNumber())
+
+	tag := PreReleaseTag{Name: "beta", Number: int64Ptr(4)}
+	n := tag.IntNumber()
+	require.Equal(t, 4, *n)
+	*n = 5
+	require.Equal(t, int64(4), *tag.Number)
+}
+
 func TestPreReleaseTag_WithName(t *testing.T) {
 	original := PreReleaseTag{Name: "alpha", Number: int64Ptr(1)}
 	result := original.WithName("beta")
@@ -0,0 +1,145 @@
+package server
+
+import (
+	"sync"
+	"time"
+)
+
+// cacheKey identifies one version response.
+type cacheKey struct {
+	repo    string // lowercased owner/name
+	ref     string
+	explain bool
+}
+
+// cacheEntry is a rendered version response.
+type cacheEntry struct {
+	body    []byte
+	etag    string
+	expires time.Time
+	used    time.Time
+}
+
+// flight is a calculation in progress that concurrent requests for the same
+// key wait on instead of starting their own.
+type flight struct {
+	done  chan struct{}
+	entry cacheEntry
+	err   error
+}
+
+// resultCache holds rendered responses shared by all requests. Entries
+// expire after ttl (zero keeps them until invalidated) and are dropped per
+// repository when a push webhook arrives. At most size entries are kept:
+// storing one more sweeps expired entries and, when none have expired,
+// evicts the least recently used. Each repository has a generation
+// that invalidation bumps, so a calculation that started before a push
+// does not store its now stale result.
+type resultCache struct {
+	mu          sync.Mutex
+	ttl         time.Duration
+	size        int
+	now         func() time.Time
+	entries     map[cacheKey]cacheEntry
+	inflight    map[cacheKey]*flight
+	generations map[string]uint64
+}
+
+func newResultCache(ttl time.Duration, size int) *resultCache {
+	return &resultCache{
+		ttl:         ttl,
+		size:        size,
+		now:         time.Now,
+		entries:     make(map[cacheKey]cacheEntry),
+		inflight:    make(map[cacheKey]*flight),
+		generations: make(map[string]uint64),
+	}
+}
+
+// get returns the entry for key, calling compute to fill it on a miss. hit
+// reports whether the entry came from the cache, including from a
+// calculation another request started.
+func (c *resultCache) get(key cacheKey, compute func() (cacheEntry, error)) (entry cacheEntry, hit bool, err error) {
+	c.mu.Lock()
+	if e, ok := c.entries[key]; ok {
+		if c.ttl <= 0 || c.now().Before(e.expires) {
+			e.used = c.now()
+			c.entries[key] = e
+			c.mu.Unlock()
+			return e, true, nil
+		}
+		delete(c.entries, key)
+	}
+	if f, ok := c.inflight[key]; ok {
+		c.mu.Unlock()
+		<-f.done
+		return f.entry, f.err == nil, f.err
+	}
+	f := &flight{done: make(chan struct{})}
+	c.inflight[key] = f
+	gen := c.generations[key.repo]
+	c.mu.Unlock()
+
+	f.entry, f.err = compute()
+
+	c.mu.Lock()
+	delete(c.inflight, key)
+	if f.err == nil && c.generations[key.repo] == gen {
+		now := c.now()
+		f.entry.expires = now.Add(c.ttl)
+		f.entry.used = now
+		c.makeRoom(now)
+		c.entries[key] = f.entry
+	}
+	c.mu.Unlock()
+	close(f.done)
+	return f.entry, false, f.err
+}
+
+// makeRoom drops entries until there is room for one more, expired ones
+// first. c.mu must be held.
+func (c *resultCache) makeRoom(now time.Time) {
+	if len(c.entries) < c.size {
+		return
+	}
+	if c.ttl > 0 {
+		for key, e := range c.entries {
+			if !now.Before(e.expires) {
+				delete(c.entries, key)
+			}
+		}
+	}
+	for len(c.entries) >= c.size {
+		var oldest cacheKey
+		var oldestUsed time.Time
+		first := true
+		for key, e := range c.entries {
+			if first || e.used.Before(oldestUsed) {
+				oldest, oldestUsed, first = key, e.used, false
+			}
+		}
+		delete(c.entries, oldest)
+	}
+}
+
+// invalidate drops every entry for repo and returns how many there were.
+func (c *resultCache) invalidate(repo string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generations[repo]++
+	n := 0
+	for key := range c.entries {
+		if key.repo == repo {
+			delete(c.entries, key)
+			n++
+		}
+	}
+	return n
+}
+
+// len returns the number of cached entries, expired ones included.
+func (c *resultCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
@@ -0,0 +1,143 @@
+package server
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResultCache_TTL(t *testing.T) {
+	now := time.Now()
+	c := newResultCache(time.Minute, DefaultCacheSize)
+	c.now = func() time.Time { return now }
+	key := cacheKey{repo: "myorg/api"}
+
+	calls := 0
+	compute := func() (cacheEntry, error) {
+		calls++
+		return cacheEntry{etag: "e"}, nil
+	}
+
+	_, hit, err := c.get(key, compute)
+	require.NoError(t, err)
+	require.False(t, hit)
+	_, hit, _ = c.get(key, compute)
+	require.True(t, hit)
+
+	now = now.Add(2 * time.Minute)
+	_, hit, _ = c.get(key, compute)
+	require.False(t, hit)
+	require.Equal(t, 2, calls)
+}
+
+func TestResultCache_Errors(t *testing.T) {
+	c := newResultCache(0, DefaultCacheSize)
+	_, _, err := c.get(cacheKey{repo: "myorg/api"}, func() (cacheEntry, error) {
+		return cacheEntry{}, errors.New("boom")
+	})
+	require.EqualError(t, err, "boom")
+	require.Zero(t, c.len())
+}
+
+func TestResultCache_ConcurrentMissesCalculateOnce(t *testing.T) {
+	c := newResultCache(0, DefaultCacheSize)
+	key := cacheKey{repo: "myorg/api"}
+	release := make(chan struct{})
+	calls := 0
+
+	var wg sync.WaitGroup
+	started := make(chan struct{})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, _, _ = c.get(key, func() (cacheEntry, error) {
+			calls++
+			close(started)
+			<-release
+			return cacheEntry{etag: "e"}, nil
+		})
+	}()
+	<-started
+
+	wg.Add(1)
+	var hit bool
+	go func() {
+		defer wg.Done()
+		_, hit, _ = c.get(key, func() (cacheEntry, error) {
+			calls++
+			return cacheEntry{}, nil
+		})
+	}()
+	close(release)
+	wg.Wait()
+
+	require.Equal(t, 1, calls)
+	require.True(t, hit)
+}
+
+func TestResultCache_InvalidateDuringCalculation(t *testing.T) {
+	c := newResultCache(0, DefaultCacheSize)
+	key := cacheKey{repo: "myorg/api"}
+
+	_, _, err := c.get(key, func() (cacheEntry, error) {
+		// A push arrives while the stale result is being calculated.
+		c.invalidate("myorg/api")
+		return cacheEntry{etag: "stale"}, nil
+	})
+	require.NoError(t, err)
+	require.Zero(t, c.len())
+
+	_, _, _ = c.get(key, func() (cacheEntry, error) { return cacheEntry{etag: "fresh"}, nil })
+	_, _, _ = c.get(cacheKey{repo: "myorg/other"}, func() (cacheEntry, error) { return cacheEntry{}, nil })
+	require.Equal(t, 1, c.invalidate("myorg/api"))
+	require.Equal(t, 1, c.len())
+}
+
+func TestResultCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	now := time.Now()
+	c := newResultCache(0, 2)
+	c.now = func() time.Time { return now }
+	compute := func() (cacheEntry, error) { return cacheEntry{etag: "e"}, nil }
+	a, b, d := cacheKey{repo: "myorg/a"}, cacheKey{repo: "myorg/b"}, cacheKey{repo: "myorg/d"}
+
+	_, _, _ = c.get(a, compute)
+	now = now.Add(time.Second)
+	_, _, _ = c.get(b, compute)
+	now = now.Add(time.Second)
+	_, hit, _ := c.get(a, compute)
+	require.True(t, hit)
+
+	now = now.Add(time.Second)
+	_, _, _ = c.get(d, compute)
+	require.Equal(t, 2, c.len())
+	_, hit, _ = c.get(a, compute)
+	require.True(t, hit)
+	_, hit, _ = c.get(b, compute)
+	require.False(t, hit, "b was least recently used")
+}
+
+func TestResultCache_SweepsExpiredBeforeEvicting(t *testing.T) {
+	now := time.Now()
+	c := newResultCache(time.Minute, 2)
+	c.now = func() time.Time { return now }
+	compute := func() (cacheEntry, error) { return cacheEntry{etag: "e"}, nil }
+	a, b, d := cacheKey{repo: "myorg/a"}, cacheKey{repo: "myorg/b"}, cacheKey{repo: "myorg/d"}
+
+	_, _, _ = c.get(a, compute)
+	now = now.Add(10 * time.Second)
+	_, _, _ = c.get(b, compute)
+	now = now.Add(10 * time.Second)
+	_, hit, _ := c.get(a, compute)
+	require.True(t, hit)
+
+	// a expires before d is stored, so it goes even though b is less
+	// recently used.
+	now = now.Add(40 * time.Second)
+	_, _, _ = c.get(d, compute)
+	require.Equal(t, 2, c.len())
+	_, hit, _ = c.get(b, compute)
+	require.True(t, hit)
+}
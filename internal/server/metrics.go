@@ -0,0 +1,151 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// durationBuckets are the upper bounds, in seconds, of the calculation
+// duration histogram.
+var durationBuckets = []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}
+
+// metrics collects the server's Prometheus metrics and writes them in the
+// text exposition format.
+type metrics struct {
+	mu            sync.Mutex
+	requests      map[[2]string]uint64 // {handler, code} → count
+	cacheHits     uint64
+	cacheMisses   uint64
+	invalidations uint64
+	webhooks      map[string]uint64 // event → count
+	durations     map[string]*histogram
+}
+
+// histogram is a Prometheus histogram with durationBuckets.
+type histogram struct {
+	counts []uint64 // per bucket, not cumulative
+	sum    float64
+	count  uint64
+}
+
+func newMetrics() *metrics {
+	return &metrics{
+		requests:  make(map[[2]string]uint64),
+		webhooks:  make(map[string]uint64),
+		durations: make(map[string]*histogram),
+	}
+}
+
+func (m *metrics) request(handler string, code int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests[[2]string{handler, strconv.Itoa(code)}]++
+}
+
+func (m *metrics) cacheLookup(hit bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if hit {
+		m.cacheHits++
+	} else {
+		m.cacheMisses++
+	}
+}
+
+func (m *metrics) invalidated(entries int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.invalidations += uint64(entries)
+}
+
+func (m *metrics) webhook(event string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.webhooks[event]++
+}
+
+// calculation records how long a calculation from source ("remote" or
+// "local") took.
+func (m *metrics) calculation(source string, d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	h, ok := m.durations[source]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(durationBuckets))}
+		m.durations[source] = h
+	}
+	s := d.Seconds()
+	if i, _ := slices.BinarySearch(durationBuckets, s); i < len(durationBuckets) {
+		h.counts[i]++
+	}
+	h.sum += s
+	h.count++
+}
+
+// write writes all metrics; cacheEntries is the current cache size.
+func (m *metrics) write(w io.Writer, cacheEntries int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var b strings.Builder
+	b.WriteString("# HELP gitsemver_http_requests_total HTTP requests by handler and status code.\n")
+	b.WriteString("# TYPE gitsemver_http_requests_total counter\n")
+	keys := sortedKeys(m.requests, func(a, b [2]string) int {
+		return strings.Compare(a[0]+" "+a[1], b[0]+" "+b[1])
+	})
+	for _, k := range keys {
+		fmt.Fprintf(&b, "gitsemver_http_requests_total{handler=%q,code=%q} %d\n", k[0], k[1], m.requests[k])
+	}
+
+	b.WriteString("# HELP gitsemver_cache_hits_total Version requests answered from the cache.\n")
+	b.WriteString("# TYPE gitsemver_cache_hits_total counter\n")
+	fmt.Fprintf(&b, "gitsemver_cache_hits_total %d\n", m.cacheHits)
+	b.WriteString("# HELP gitsemver_cache_misses_total Version requests that ran a calculation.\n")
+	b.WriteString("# TYPE gitsemver_cache_misses_total counter\n")
+	fmt.Fprintf(&b, "gitsemver_cache_misses_total %d\n", m.cacheMisses)
+	b.WriteString("# HELP gitsemver_cache_invalidations_total Cache entries dropped by push webhooks.\n")
+	b.WriteString("# TYPE gitsemver_cache_invalidations_total counter\n")
+	fmt.Fprintf(&b, "gitsemver_cache_invalidations_total %d\n", m.invalidations)
+	b.WriteString("# HELP gitsemver_cache_entries Cached version responses.\n")
+	b.WriteString("# TYPE gitsemver_cache_entries gauge\n")
+	fmt.Fprintf(&b, "gitsemver_cache_entries %d\n", cacheEntries)
+
+	b.WriteString("# HELP gitsemver_webhook_events_total Verified GitHub webhook deliveries by event.\n")
+	b.WriteString("# TYPE gitsemver_webhook_events_total counter\n")
+	for _, event := range sortedKeys(m.webhooks, strings.Compare) {
+		fmt.Fprintf(&b, "gitsemver_webhook_events_total{event=%q} %d\n", event, m.webhooks[event])
+	}
+
+	b.WriteString("# HELP gitsemver_calculation_duration_seconds Time spent calculating versions.\n")
+	b.WriteString("# TYPE gitsemver_calculation_duration_seconds histogram\n")
+	for _, source := range sortedKeys(m.durations, strings.Compare) {
+		h := m.durations[source]
+		var cumulative uint64
+		for i, le := range durationBuckets {
+			cumulative += h.counts[i]
+			fmt.Fprintf(&b, "gitsemver_calculation_duration_seconds_bucket{source=%q,le=%q} %d\n",
+				source, strconv.FormatFloat(le, 'g', -1, 64), cumulative)
+		}
+		fmt.Fprintf(&b, "gitsemver_calculation_duration_seconds_bucket{source=%q,le=\"+Inf\"} %d\n", source, h.count)
+		fmt.Fprintf(&b, "gitsemver_calculation_duration_seconds_sum{source=%q} %s\n", source, strconv.FormatFloat(h.sum, 'g', -1, 64))
+		fmt.Fprintf(&b, "gitsemver_calculation_duration_seconds_count{source=%q} %d\n", source, h.count)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// sortedKeys returns the keys of m sorted with cmp.
+func sortedKeys[K comparable, V any](m map[K]V, cmp func(a, b K) int) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.SortFunc(keys, cmp)
+	return keys
+}
@@ -0,0 +1,63 @@
+package server
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetrics_Write(t *testing.T) {
+	m := newMetrics()
+	m.request("version", 200)
+	m.request("version", 200)
+	m.request("webhook", 401)
+	m.cacheLookup(true)
+	m.cacheLookup(false)
+	m.invalidated(3)
+	m.webhook("push")
+	m.calculation("remote", 300*time.Millisecond)
+	m.calculation("remote", 2*time.Minute)
+
+	var b strings.Builder
+	require.NoError(t, m.write(&b, 4))
+	out := b.String()
+
+	for _, line := range []string{
+		"# TYPE gitsemver_http_requests_total counter",
+		`gitsemver_http_requests_total{handler="version",code="200"} 2`,
+		`gitsemver_http_requests_total{handler="webhook",code="401"} 1`,
+		"gitsemver_cache_hits_total 1",
+		"gitsemver_cache_misses_total 1",
+		"gitsemver_cache_invalidations_total 3",
+		"gitsemver_cache_entries 4",
+		`gitsemver_webhook_events_total{event="push"} 1`,
+		"# TYPE gitsemver_calculation_duration_seconds histogram",
+		`gitsemver_calculation_duration_seconds_bucket{source="remote",le="0.25"} 0`,
+		`gitsemver_calculation_duration_seconds_bucket{source="remote",le="0.5"} 1`,
+		`gitsemver_calculation_duration_seconds_bucket{source="remote",le="60"} 1`,
+		`gitsemver_calculation_duration_seconds_bucket{source="remote",le="+Inf"} 2`,
+		`gitsemver_calculation_duration_seconds_sum{source="remote"} 120.3`,
+		`gitsemver_calculation_duration_seconds_count{source="remote"} 2`,
+	} {
+		require.Contains(t, out, line+"\n")
+	}
+}
+
+func TestMetricsEndpoint(t *testing.T) {
+	remote := newFakeRemote("1.2.0")
+	srv := newTestServer(t, Options{CalculateRemote: remote.calculate})
+	get(t, srv, "/v1/version?repo=myorg/api")
+	get(t, srv, "/v1/version?repo=myorg/api")
+
+	rec := get(t, srv, "/metrics")
+	require.Equal(t, 200, rec.Code)
+	require.Contains(t, rec.Header().Get("Content-Type"), "text/plain; version=0.0.4")
+	out := rec.Body.String()
+	require.Contains(t, out, `gitsemver_http_requests_total{handler="version",code="200"} 2`+"\n")
+	require.Contains(t, out, "gitsemver_cache_hits_total 1\n")
+	require.Contains(t, out, "gitsemver_cache_misses_total 1\n")
+	require.Contains(t, out, "gitsemver_cache_entries 1\n")
+	require.Contains(t, out, `gitsemver_calculation_duration_seconds_count{source="remote"} 1`+"\n")
+}
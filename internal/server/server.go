@@ -0,0 +1,357 @@
+// Package server exposes version calculation over HTTP for tools that want
+// the next version of many repositories without running CI, such as a
+// developer portal.
+//
+// Endpoints:
+//
+//	GET  /v1/version?repo=owner/name&ref=main&explain=true
+//	POST /v1/webhooks/github
+//	GET  /metrics
+//	GET  /healthz
+//
+// Remote results are cached in memory, shared by all requests, and dropped
+// when a signed GitHub push webhook arrives for the repository.
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MyCarrier-DevOps/go-gitsemver/pkg/sdk"
+)
+
+// ownerRepoRe matches a GitHub owner/name pair.
+var ownerRepoRe = regexp.MustCompile(`^[\w.-]+/[\w.-]+$`)
+
+// DefaultCacheSize is the number of remote results a Server caches when
+// Options.CacheSize is zero.
+const DefaultCacheSize = 1000
+
+// DefaultCalculateTimeout bounds a remote calculation when
+// Options.CalculateTimeout is zero.
+const DefaultCalculateTimeout = 2 * time.Minute
+
+// Options configures a Server.
+type Options struct {
+	// Remote holds the settings for remote calculations, such as
+	// authentication. Owner, Repo, Ref and Explain are set per request.
+	Remote sdk.RemoteOptions
+
+	// Local holds the settings for local calculations. Path, Branch and
+	// Explain are set per request.
+	Local sdk.LocalOptions
+
+	// AllowedPaths lists the local repositories requests may name. Requests
+	// for any other path are refused.
+	AllowedPaths []string
+
+	// AllowedRepos lists the GitHub repositories requests may name, as
+	// owner/name patterns in path.Match syntax, such as myorg/* or */*.
+	// Matching ignores case. Requests for any other repository are refused,
+	// so remote calculation is disabled when it is empty.
+	AllowedRepos []string
+
+	// WebhookSecret is the secret GitHub signs webhook deliveries with. The
+	// webhook endpoint is disabled when it is empty.
+	WebhookSecret string
+
+	// CacheTTL bounds how long a remote result is served from the cache.
+	// Zero keeps results until a push webhook invalidates them.
+	CacheTTL time.Duration
+
+	// CacheSize bounds how many remote results are cached; the least
+	// recently used is dropped to make room. Zero means DefaultCacheSize.
+	CacheSize int
+
+	// CalculateTimeout bounds a remote calculation, which outlives the
+	// request that started it. Zero means DefaultCalculateTimeout.
+	CalculateTimeout time.Duration
+
+	// CalculateRemote and CalculateLocal default to
+	// sdk.CalculateRemoteContext and sdk.CalculateContext.
+	CalculateRemote func(context.Context, sdk.RemoteOptions) (*sdk.Result, error)
+	CalculateLocal  func(context.Context, sdk.LocalOptions) (*sdk.Result, error)
+}
+
+// Server is the HTTP handler for the version API.
+type Server struct {
+	opts    Options
+	allowed map[string]bool
+	repos   []string
+	cache   *resultCache
+	metrics *metrics
+	mux     *http.ServeMux
+}
+
+// New returns a Server for opts. Allowed paths are made absolute.
+func New(opts Options) (*Server, error) {
+	if opts.CalculateRemote == nil {
+		opts.CalculateRemote = sdk.CalculateRemoteContext
+	}
+	if opts.CalculateLocal == nil {
+		opts.CalculateLocal = sdk.CalculateContext
+	}
+	if opts.CacheSize <= 0 {
+		opts.CacheSize = DefaultCacheSize
+	}
+	if opts.CalculateTimeout <= 0 {
+		opts.CalculateTimeout = DefaultCalculateTimeout
+	}
+
+	allowed := make(map[string]bool, len(opts.AllowedPaths))
+	for _, p := range opts.AllowedPaths {
+		abs, err := filepath.Abs(p)
+		if err != nil {
+			return nil, fmt.Errorf("resolving allowed path %q: %w", p, err)
+		}
+		allowed[abs] = true
+	}
+
+	repos := make([]string, 0, len(opts.AllowedRepos))
+	for _, pattern := range opts.AllowedRepos {
+		if _, err := path.Match(pattern, ""); err != nil || strings.Count(pattern, "/") != 1 {
+			return nil, fmt.Errorf("invalid allowed repository %q, expected an owner/name pattern", pattern)
+		}
+		repos = append(repos, strings.ToLower(pattern))
+	}
+
+	s := &Server{
+		opts:    opts,
+		allowed: allowed,
+		repos:   repos,
+		cache:   newResultCache(opts.CacheTTL, opts.CacheSize),
+		metrics: newMetrics(),
+		mux:     http.NewServeMux(),
+	}
+	s.mux.HandleFunc("GET /v1/version", s.handleVersion)
+	if opts.WebhookSecret != "" {
+		s.mux.HandleFunc("POST /v1/webhooks/github", s.handleWebhook)
+	}
+	s.mux.HandleFunc("GET /metrics", s.handleMetrics)
+	s.mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("ok\n"))
+	})
+	return s, nil
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// versionResponse is the body of GET /v1/version.
+type versionResponse struct {
+	Repo      string             `json:"repo"`
+	Ref       string             `json:"ref,omitempty"`
+	Variables map[string]string  `json:"variables"`
+	Explain   *sdk.ExplainResult `json:"explain,omitempty"`
+}
+
+// errorResponse is the body of every error response.
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// requestError is an error with the HTTP status it is reported with.
+type requestError struct {
+	status int
+	err    error
+}
+
+func (e *requestError) Error() string { return e.err.Error() }
+
+func (s *Server) handleVersion(w http.ResponseWriter, r *http.Request) {
+	status := s.serveVersion(w, r)
+	s.metrics.request("version", status)
+}
+
+// serveVersion writes the version response and returns its status code.
+func (s *Server) serveVersion(w http.ResponseWriter, r *http.Request) int {
+	q := r.URL.Query()
+	repo := q.Get("repo")
+	ref := q.Get("ref")
+	explain, err := parseBool(q.Get("explain"))
+	if err != nil {
+		return writeError(w, http.StatusBadRequest, fmt.Errorf("invalid explain value %q", q.Get("explain")))
+	}
+
+	var entry cacheEntry
+	if path, local, err := s.localPath(repo); err != nil {
+		return writeError(w, errorStatus(err), err)
+	} else if local {
+		// Local repositories change without webhooks, so they are not cached.
+		entry, err = s.calculateLocal(r.Context(), path, ref, explain)
+		if err != nil {
+			return writeError(w, errorStatus(err), err)
+		}
+	} else {
+		key := cacheKey{repo: strings.ToLower(repo), ref: ref, explain: explain}
+		var hit bool
+		entry, hit, err = s.cache.get(key, func() (cacheEntry, error) {
+			// Concurrent requests for key wait on this calculation, so it
+			// must outlive the request that started it, but not forever.
+			ctx, cancel := context.WithTimeout(context.WithoutCancel(r.Context()), s.opts.CalculateTimeout)
+			defer cancel()
+			return s.calculateRemote(ctx, repo, ref, explain)
+		})
+		if err != nil {
+			return writeError(w, errorStatus(err), err)
+		}
+		s.metrics.cacheLookup(hit)
+	}
+
+	w.Header().Set("ETag", entry.etag)
+	w.Header().Set("Cache-Control", "no-cache")
+	if etagMatches(r.Header.Get("If-None-Match"), entry.etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return http.StatusNotModified
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(entry.body)
+	return http.StatusOK
+}
+
+// localPath reports whether repo names a local repository, and returns its
+// absolute path if so. An owner/name must match an allowed repository, and
+// anything else must be an allowed path.
+func (s *Server) localPath(repo string) (string, bool, error) {
+	if repo == "" {
+		return "", false, &requestError{http.StatusBadRequest, errors.New("repo is required")}
+	}
+	if isOwnerRepo(repo) {
+		if !s.repoAllowed(repo) {
+			return "", false, &requestError{http.StatusForbidden, fmt.Errorf("repo %q is not an allowed repository", repo)}
+		}
+		return "", false, nil
+	}
+	abs, err := filepath.Abs(repo)
+	if err != nil || !s.allowed[abs] {
+		return "", false, &requestError{http.StatusForbidden, fmt.Errorf("repo %q is not owner/name or an allowed local path", repo)}
+	}
+	return abs, true, nil
+}
+
+// repoAllowed reports whether the owner/name repo matches AllowedRepos.
+func (s *Server) repoAllowed(repo string) bool {
+	repo = strings.ToLower(repo)
+	for _, pattern := range s.repos {
+		if ok, _ := path.Match(pattern, repo); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// isOwnerRepo reports whether repo is a GitHub owner/name pair.
+func isOwnerRepo(repo string) bool {
+	if !ownerRepoRe.MatchString(repo) {
+		return false
+	}
+	owner, name, _ := strings.Cut(repo, "/")
+	return !strings.HasPrefix(owner, ".") && name != "." && name != ".."
+}
+
+func (s *Server) calculateRemote(ctx context.Context, repo, ref string, explain bool) (cacheEntry, error) {
+	owner, name, _ := strings.Cut(repo, "/")
+	opts := s.opts.Remote
+	opts.Owner = owner
+	opts.Repo = name
+	opts.Ref = ref
+	opts.Explain = explain
+
+	start := time.Now()
+	result, err := s.opts.CalculateRemote(ctx, opts)
+	s.metrics.calculation("remote", time.Since(start))
+	if err != nil {
+		return cacheEntry{}, fmt.Errorf("calculating version of %s: %w", repo, err)
+	}
+	return renderVersion(repo, ref, result)
+}
+
+func (s *Server) calculateLocal(ctx context.Context, path, ref string, explain bool) (cacheEntry, error) {
+	opts := s.opts.Local
+	opts.Path = path
+	opts.Branch = ref
+	opts.Explain = explain
+
+	start := time.Now()
+	result, err := s.opts.CalculateLocal(ctx, opts)
+	s.metrics.calculation("local", time.Since(start))
+	if err != nil {
+		return cacheEntry{}, fmt.Errorf("calculating version of %s: %w", path, err)
+	}
+	return renderVersion(path, ref, result)
+}
+
+// renderVersion renders result as a response body with its ETag.
+func renderVersion(repo, ref string, result *sdk.Result) (cacheEntry, error) {
+	body, err := json.MarshalIndent(versionResponse{
+		Repo:      repo,
+		Ref:       ref,
+		Variables: result.Variables,
+		Explain:   result.ExplainResult,
+	}, "", "  ")
+	if err != nil {
+		return cacheEntry{}, fmt.Errorf("marshaling response: %w", err)
+	}
+	body = append(body, '\n')
+	sum := sha256.Sum256(body)
+	return cacheEntry{body: body, etag: `"` + hex.EncodeToString(sum[:16]) + `"`}, nil
+}
+
+func (s *Server) handleMetrics(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_ = s.metrics.write(w, s.cache.len())
+}
+
+// etagMatches reports whether an If-None-Match header matches etag. Weak
+// validators compare equal to strong ones, as RFC 9110 requires for
+// If-None-Match.
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// parseBool parses a query flag; empty means false.
+func parseBool(s string) (bool, error) {
+	if s == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(s)
+}
+
+// errorStatus returns the status for err: the status of a requestError,
+// otherwise 502 since most failures come from git or the GitHub API.
+func errorStatus(err error) int {
+	var re *requestError
+	if errors.As(err, &re) {
+		return re.status
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return http.StatusGatewayTimeout
+	}
+	return http.StatusBadGateway
+}
+
+// writeError writes err as a JSON error response and returns status.
+func writeError(w http.ResponseWriter, status int, err error) int {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(errorResponse{Error: err.Error()})
+	return status
+}
@@ -0,0 +1,254 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/MyCarrier-DevOps/go-gitsemver/internal/testutil"
+	"github.com/MyCarrier-DevOps/go-gitsemver/pkg/sdk"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRemote counts calculations and returns SemVer as the version.
+type fakeRemote struct {
+	calls  atomic.Int32
+	semVer atomic.Value
+}
+
+func newFakeRemote(semVer string) *fakeRemote {
+	f := &fakeRemote{}
+	f.semVer.Store(semVer)
+	return f
+}
+
+func (f *fakeRemote) calculate(_ context.Context, opts sdk.RemoteOptions) (*sdk.Result, error) {
+	f.calls.Add(1)
+	if opts.Repo == "broken" {
+		return nil, errors.New("repository not found")
+	}
+	result := &sdk.Result{Variables: map[string]string{
+		"SemVer":     f.semVer.Load().(string),
+		"BranchName": opts.Ref,
+	}}
+	if opts.Explain {
+		result.ExplainResult = &sdk.ExplainResult{SelectedSource: "Git tag"}
+	}
+	return result, nil
+}
+
+// newTestServer returns a Server for opts, allowing the myorg repositories
+// unless opts names others.
+func newTestServer(t *testing.T, opts Options) *Server {
+	t.Helper()
+	if opts.AllowedRepos == nil {
+		opts.AllowedRepos = []string{"myorg/*"}
+	}
+	srv, err := New(opts)
+	require.NoError(t, err)
+	return srv
+}
+
+func get(t *testing.T, h http.Handler, url string, header ...string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, url, nil)
+	for i := 0; i+1 < len(header); i += 2 {
+		req.Header.Set(header[i], header[i+1])
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestVersion_Remote(t *testing.T) {
+	remote := newFakeRemote("1.2.0")
+	srv := newTestServer(t, Options{
+		Remote:          sdk.RemoteOptions{Token: "t", MaxCommits: 50},
+		CalculateRemote: remote.calculate,
+	})
+
+	rec := get(t, srv, "/v1/version?repo=myorg/api&ref=main")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	require.NotEmpty(t, rec.Header().Get("ETag"))
+
+	var resp versionResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Equal(t, "myorg/api", resp.Repo)
+	require.Equal(t, "main", resp.Ref)
+	require.Equal(t, "1.2.0", resp.Variables["SemVer"])
+	require.Equal(t, "main", resp.Variables["BranchName"])
+	require.Nil(t, resp.Explain)
+
+	// Shared cache: the same request, in any case, does not recalculate.
+	again := get(t, srv, "/v1/version?repo=MyOrg/API&ref=main")
+	require.Equal(t, http.StatusOK, again.Code)
+	require.Equal(t, rec.Body.String(), again.Body.String())
+	require.Equal(t, int32(1), remote.calls.Load())
+
+	// explain is cached separately.
+	rec = get(t, srv, "/v1/version?repo=myorg/api&ref=main&explain=true")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Equal(t, "Git tag", resp.Explain.SelectedSource)
+	require.Equal(t, int32(2), remote.calls.Load())
+}
+
+func TestVersion_PassesRemoteOptions(t *testing.T) {
+	var got sdk.RemoteOptions
+	srv := newTestServer(t, Options{
+		Remote: sdk.RemoteOptions{Token: "t", MaxCommits: 50},
+		CalculateRemote: func(_ context.Context, opts sdk.RemoteOptions) (*sdk.Result, error) {
+			got = opts
+			return &sdk.Result{Variables: map[string]string{}}, nil
+		},
+	})
+
+	rec := get(t, srv, "/v1/version?repo=myorg/api&ref=v1.0.0&explain=1")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, sdk.RemoteOptions{
+		Owner: "myorg", Repo: "api", Ref: "v1.0.0", Explain: true, Token: "t", MaxCommits: 50,
+	}, got)
+}
+
+func TestVersion_RemoteOutlivesRequest(t *testing.T) {
+	var ctxErr error
+	srv := newTestServer(t, Options{
+		CalculateRemote: func(ctx context.Context, _ sdk.RemoteOptions) (*sdk.Result, error) {
+			ctxErr = ctx.Err()
+			return &sdk.Result{Variables: map[string]string{}}, nil
+		},
+	})
+
+	// Other requests may be waiting on a shared remote calculation, so the
+	// client going away must not cancel it.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req := httptest.NewRequestWithContext(ctx, http.MethodGet, "/v1/version?repo=myorg/api", nil)
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NoError(t, ctxErr)
+}
+
+func TestVersion_RemoteTimeout(t *testing.T) {
+	srv := newTestServer(t, Options{
+		CalculateTimeout: time.Millisecond,
+		CalculateRemote: func(ctx context.Context, _ sdk.RemoteOptions) (*sdk.Result, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	})
+
+	rec := get(t, srv, "/v1/version?repo=myorg/api")
+	require.Equal(t, http.StatusGatewayTimeout, rec.Code)
+	require.Contains(t, rec.Body.String(), "deadline exceeded")
+}
+
+func TestVersion_ETag(t *testing.T) {
+	remote := newFakeRemote("1.2.0")
+	srv := newTestServer(t, Options{CalculateRemote: remote.calculate})
+
+	rec := get(t, srv, "/v1/version?repo=myorg/api")
+	etag := rec.Header().Get("ETag")
+
+	rec = get(t, srv, "/v1/version?repo=myorg/api", "If-None-Match", etag)
+	require.Equal(t, http.StatusNotModified, rec.Code)
+	require.Empty(t, rec.Body.String())
+	require.Equal(t, etag, rec.Header().Get("ETag"))
+
+	rec = get(t, srv, "/v1/version?repo=myorg/api", "If-None-Match", `"other", W/`+etag)
+	require.Equal(t, http.StatusNotModified, rec.Code)
+
+	rec = get(t, srv, "/v1/version?repo=myorg/api", "If-None-Match", `"other"`)
+	require.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestVersion_Errors(t *testing.T) {
+	remote := newFakeRemote("1.2.0")
+	srv := newTestServer(t, Options{CalculateRemote: remote.calculate})
+
+	tests := []struct {
+		url    string
+		status int
+		err    string
+	}{
+		{"/v1/version", http.StatusBadRequest, "repo is required"},
+		{"/v1/version?repo=myorg/api&explain=maybe", http.StatusBadRequest, `invalid explain value "maybe"`},
+		{"/v1/version?repo=/srv/repos/api", http.StatusForbidden, `repo "/srv/repos/api" is not owner/name or an allowed local path`},
+		{"/v1/version?repo=../api", http.StatusForbidden, `repo "../api" is not owner/name or an allowed local path`},
+		{"/v1/version?repo=myorg/..", http.StatusForbidden, `repo "myorg/.." is not owner/name or an allowed local path`},
+		{"/v1/version?repo=other/api", http.StatusForbidden, `repo "other/api" is not an allowed repository`},
+		{"/v1/version?repo=myorg/broken", http.StatusBadGateway, "calculating version of myorg/broken: repository not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			rec := get(t, srv, tt.url)
+			require.Equal(t, tt.status, rec.Code)
+			var resp errorResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			require.Equal(t, tt.err, resp.Error)
+		})
+	}
+
+	// Failures are not cached.
+	get(t, srv, "/v1/version?repo=myorg/broken")
+	require.Equal(t, int32(2), remote.calls.Load())
+}
+
+func TestVersion_RepoAllowlist(t *testing.T) {
+	remote := newFakeRemote("1.2.0")
+	srv := newTestServer(t, Options{
+		AllowedRepos:    []string{"MyOrg/api-*", "platform/tools"},
+		CalculateRemote: remote.calculate,
+	})
+
+	require.Equal(t, http.StatusOK, get(t, srv, "/v1/version?repo=myorg/api-users").Code)
+	require.Equal(t, http.StatusOK, get(t, srv, "/v1/version?repo=Platform/Tools").Code)
+	require.Equal(t, http.StatusForbidden, get(t, srv, "/v1/version?repo=myorg/web").Code)
+	require.Equal(t, http.StatusForbidden, get(t, srv, "/v1/version?repo=platform/tools-old").Code)
+	require.Equal(t, int32(2), remote.calls.Load())
+
+	// Without an allowlist no remote repository is served.
+	srv = newTestServer(t, Options{AllowedRepos: []string{}, CalculateRemote: remote.calculate})
+	require.Equal(t, http.StatusForbidden, get(t, srv, "/v1/version?repo=myorg/api").Code)
+	require.Equal(t, int32(2), remote.calls.Load())
+}
+
+func TestNew_InvalidAllowedRepo(t *testing.T) {
+	for _, pattern := range []string{"myorg", "myorg/[", "a/b/c"} {
+		_, err := New(Options{AllowedRepos: []string{pattern}})
+		require.ErrorContains(t, err, "invalid allowed repository", pattern)
+	}
+}
+
+func TestVersion_LocalAllowlist(t *testing.T) {
+	repo := testutil.NewTestRepo(t)
+	initial := repo.AddCommit("initial")
+	repo.CreateTag("v1.0.0", initial)
+	repo.AddCommit("feat: add login")
+
+	srv := newTestServer(t, Options{AllowedPaths: []string{repo.Path()}})
+
+	rec := get(t, srv, "/v1/version?repo="+repo.Path()+"&ref=master")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var resp versionResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Equal(t, "1.1.0+1", resp.Variables["FullSemVer"])
+
+	rec = get(t, srv, "/v1/version?repo="+t.TempDir())
+	require.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestHealthz(t *testing.T) {
+	srv := newTestServer(t, Options{})
+	rec := get(t, srv, "/healthz")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "ok\n", rec.Body.String())
+}
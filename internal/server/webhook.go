@@ -0,0 +1,73 @@
+package server
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxWebhookBody is the largest payload GitHub delivers.
+const maxWebhookBody = 25 << 20
+
+// pushEvent is the part of a GitHub push webhook payload the server reads.
+type pushEvent struct {
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+}
+
+func (s *Server) handleWebhook(w http.ResponseWriter, r *http.Request) {
+	status := s.serveWebhook(w, r)
+	s.metrics.request("webhook", status)
+}
+
+// serveWebhook verifies a GitHub webhook delivery and drops the cached
+// results of the pushed repository. It returns the response status code.
+func (s *Server) serveWebhook(w http.ResponseWriter, r *http.Request) int {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBody))
+	if err != nil {
+		return writeError(w, http.StatusRequestEntityTooLarge, fmt.Errorf("reading payload: %w", err))
+	}
+	if !validSignature(s.opts.WebhookSecret, body, r.Header.Get("X-Hub-Signature-256")) {
+		return writeError(w, http.StatusUnauthorized, errors.New("invalid or missing X-Hub-Signature-256"))
+	}
+
+	event := r.Header.Get("X-GitHub-Event")
+	s.metrics.webhook(event)
+	if event == "push" {
+		var push pushEvent
+		if err := json.Unmarshal(body, &push); err != nil {
+			return writeError(w, http.StatusBadRequest, fmt.Errorf("parsing push payload: %w", err))
+		}
+		if push.Repository.FullName == "" {
+			return writeError(w, http.StatusBadRequest, errors.New("push payload has no repository.full_name"))
+		}
+		s.metrics.invalidated(s.cache.invalidate(strings.ToLower(push.Repository.FullName)))
+	}
+
+	// Other events, such as ping, need no action.
+	w.WriteHeader(http.StatusNoContent)
+	return http.StatusNoContent
+}
+
+// validSignature reports whether signature, an X-Hub-Signature-256 header,
+// is the HMAC-SHA256 of body under secret.
+func validSignature(secret string, body []byte, signature string) bool {
+	hexSum, ok := strings.CutPrefix(signature, "sha256=")
+	if !ok {
+		return false
+	}
+	got, err := hex.DecodeString(hexSum)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(got, mac.Sum(nil))
+}
@@ -0,0 +1,74 @@
+package server
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "s3cret"
+
+func sign(secret, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func deliver(t *testing.T, h http.Handler, event, body, signature string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/v1/webhooks/github", strings.NewReader(body))
+	req.Header.Set("X-GitHub-Event", event)
+	if signature != "" {
+		req.Header.Set("X-Hub-Signature-256", signature)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestWebhook_PushInvalidatesCache(t *testing.T) {
+	remote := newFakeRemote("1.2.0")
+	srv := newTestServer(t, Options{WebhookSecret: testSecret, CalculateRemote: remote.calculate})
+
+	get(t, srv, "/v1/version?repo=myorg/api&ref=main")
+	get(t, srv, "/v1/version?repo=myorg/other")
+	remote.semVer.Store("2.0.0")
+	require.Contains(t, get(t, srv, "/v1/version?repo=myorg/api&ref=main").Body.String(), `"1.2.0"`)
+
+	body := `{"ref":"refs/heads/main","repository":{"full_name":"MyOrg/API"}}`
+	rec := deliver(t, srv, "push", body, sign(testSecret, body))
+	require.Equal(t, http.StatusNoContent, rec.Code)
+	require.Equal(t, 1, srv.cache.len())
+
+	require.Contains(t, get(t, srv, "/v1/version?repo=myorg/api&ref=main").Body.String(), `"2.0.0"`)
+	require.Equal(t, int32(3), remote.calls.Load())
+}
+
+func TestWebhook_Signature(t *testing.T) {
+	srv := newTestServer(t, Options{WebhookSecret: testSecret})
+	body := `{"repository":{"full_name":"myorg/api"}}`
+
+	for _, signature := range []string{"", sign("wrong", body), "sha1=abc", "sha256=zz"} {
+		rec := deliver(t, srv, "push", body, signature)
+		require.Equal(t, http.StatusUnauthorized, rec.Code, signature)
+	}
+
+	rec := deliver(t, srv, "ping", `{"zen":"hi"}`, sign(testSecret, `{"zen":"hi"}`))
+	require.Equal(t, http.StatusNoContent, rec.Code)
+
+	rec = deliver(t, srv, "push", `{}`, sign(testSecret, `{}`))
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestWebhook_DisabledWithoutSecret(t *testing.T) {
+	srv := newTestServer(t, Options{})
+	body := `{"repository":{"full_name":"myorg/api"}}`
+	rec := deliver(t, srv, "push", body, sign("", body))
+	require.Equal(t, http.StatusNotFound, rec.Code)
+}
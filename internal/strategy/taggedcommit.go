@@ -26,7 +26,80 @@ func (s *TaggedCommitStrategy) GetBaseVersions(
 	ec config.EffectiveConfiguration,
 	explain bool,
 ) ([]BaseVersion, error) {
-	return s.getTaggedVersions(ctx, ec, ctx.CurrentBranch, &ctx.CurrentCommit.When, explain)
+	versions, err := s.getTaggedVersions(ctx, ec, ctx.CurrentBranch, &ctx.CurrentCommit.When, explain)
+	if err != nil || !ec.TrackMergeTarget {
+		return versions, err
+	}
+
+	mergeTarget, err := s.getMergeTargetVersions(ctx, ec, ctx.CurrentBranch, explain)
+	if err != nil {
+		return nil, err
+	}
+	return append(versions, mergeTarget...), nil
+}
+
+// getMergeTargetVersions returns versions tagged on merge commits that merged
+// a commit of branch into another branch (track-merge-target). The merge
+// commit is not on branch, so the merged commit becomes the version source.
+func (s *TaggedCommitStrategy) getMergeTargetVersions(
+	ctx *context.GitVersionContext,
+	ec config.EffectiveConfiguration,
+	branch git.Branch,
+	explain bool,
+) ([]BaseVersion, error) {
+	if branch.Tip == nil {
+		return nil, nil
+	}
+
+	// Merge targets may be tagged after the current commit was made, so
+	// tags are not limited by age.
+	versionTags, err := s.store.GetValidVersionTags(ec.TagPrefix, nil)
+	if err != nil {
+		return nil, fmt.Errorf("getting version tags: %w", err)
+	}
+
+	commits, err := s.store.GetCommitLog(git.Commit{}, *branch.Tip)
+	if err != nil {
+		return nil, fmt.Errorf("getting branch commits: %w", err)
+	}
+	onBranch := make(map[string]git.Commit, len(commits))
+	for _, c := range commits {
+		onBranch[c.Sha] = c
+	}
+
+	var result []BaseVersion
+	for _, vt := range versionTags {
+		if _, ok := onBranch[vt.Commit.Sha]; ok || !vt.Commit.IsMerge() {
+			continue
+		}
+		// The first parent is the target branch; the others were merged in.
+		for _, parent := range vt.Commit.Parents[1:] {
+			merged, ok := onBranch[parent]
+			if !ok {
+				continue
+			}
+			shouldIncrement := merged.Sha != ctx.CurrentCommit.Sha
+
+			var bvExp *Explanation
+			if explain {
+				bvExp = NewExplanation("TaggedCommit")
+				bvExp.Addf("tag %s on merge commit %s, which merged %s of this branch -> %s, ShouldIncrement=%t",
+					vt.Tag.Name.Friendly, vt.Commit.ShortSha(), merged.ShortSha(),
+					vt.Version.SemVer(), shouldIncrement)
+			}
+
+			result = append(result, BaseVersion{
+				Source:            fmt.Sprintf("Git tag '%s' on merge target", vt.Tag.Name.Friendly),
+				ShouldIncrement:   shouldIncrement,
+				SemanticVersion:   vt.Version,
+				BaseVersionSource: &merged,
+				Explanation:       bvExp,
+			})
+			break
+		}
+	}
+
+	return result, nil
 }
 
 // getTaggedVersions is the internal implementation, also called by
@@ -226,3 +226,84 @@ func TestTaggedCommit_Explanation(t *testing.T) {
 	require.NotNil(t, versions[0].Explanation)
 	require.NotEmpty(t, versions[0].Explanation.Steps)
 }
+
+func TestTaggedCommit_TrackMergeTarget(t *testing.T) {
+	base := newTestCommit("aaa0000000000000000000000000000000000000", "base")
+	head := newTestCommit("bbb0000000000000000000000000000000000000", "feat: login")
+	merge := git.Commit{
+		Sha:     "ccc0000000000000000000000000000000000000",
+		Parents: []string{base.Sha, head.Sha},
+		When:    time.Now().Add(time.Hour),
+		Message: "Merge branch 'feature/login'",
+	}
+	commits := map[string]git.Commit{base.Sha: base, head.Sha: head, merge.Sha: merge}
+
+	mock := &git.MockRepository{
+		TagsFunc: func(filters ...git.PathFilter) ([]git.Tag, error) {
+			return []git.Tag{
+				{Name: git.NewReferenceName("refs/tags/v2.0.0"), TargetSha: merge.Sha},
+			}, nil
+		},
+		PeelTagToCommitFunc: func(tag git.Tag) (string, error) { return tag.TargetSha, nil },
+		CommitFromShaFunc:   func(sha string) (git.Commit, error) { return commits[sha], nil },
+		CommitLogFunc: func(from, to string, filters ...git.PathFilter) ([]git.Commit, error) {
+			return []git.Commit{head, base}, nil
+		},
+	}
+	store := git.NewRepositoryStore(mock)
+
+	ctx := &context.GitVersionContext{
+		CurrentBranch: git.Branch{Tip: &head},
+		CurrentCommit: head,
+	}
+	s := NewTaggedCommitStrategy(store)
+
+	// The merge commit is newer and not on the branch: ignored by default.
+	ec := config.EffectiveConfiguration{TagPrefix: "[vV]"}
+	versions, err := s.GetBaseVersions(ctx, ec, false)
+	require.NoError(t, err)
+	require.Empty(t, versions)
+
+	ec.TrackMergeTarget = true
+	versions, err = s.GetBaseVersions(ctx, ec, true)
+	require.NoError(t, err)
+	require.Len(t, versions, 1)
+	require.Equal(t, int64(2), versions[0].SemanticVersion.Major)
+	require.False(t, versions[0].ShouldIncrement)
+	require.Equal(t, head.Sha, versions[0].BaseVersionSource.Sha)
+	require.Contains(t, versions[0].Source, "merge target")
+	require.Contains(t, versions[0].Explanation.Steps[0], "merge commit ccc0000")
+}
+
+func TestTaggedCommit_TrackMergeTargetIgnoresFirstParent(t *testing.T) {
+	head := newTestCommit("aaa0000000000000000000000000000000000000", "head")
+	merge := git.Commit{
+		Sha:     "ccc0000000000000000000000000000000000000",
+		Parents: []string{head.Sha, "ddd0000000000000000000000000000000000000"},
+		When:    time.Now(),
+	}
+
+	mock := &git.MockRepository{
+		TagsFunc: func(filters ...git.PathFilter) ([]git.Tag, error) {
+			return []git.Tag{
+				{Name: git.NewReferenceName("refs/tags/v2.0.0"), TargetSha: merge.Sha},
+			}, nil
+		},
+		PeelTagToCommitFunc: func(tag git.Tag) (string, error) { return tag.TargetSha, nil },
+		CommitFromShaFunc:   func(sha string) (git.Commit, error) { return merge, nil },
+		CommitLogFunc: func(from, to string, filters ...git.PathFilter) ([]git.Commit, error) {
+			return []git.Commit{head}, nil
+		},
+	}
+
+	ctx := &context.GitVersionContext{
+		CurrentBranch: git.Branch{Tip: &head},
+		CurrentCommit: head,
+	}
+	ec := config.EffectiveConfiguration{TagPrefix: "[vV]", TrackMergeTarget: true}
+
+	// Another branch merged into this one's descendant: not a merge target.
+	versions, err := NewTaggedCommitStrategy(git.NewRepositoryStore(mock)).GetBaseVersions(ctx, ec, false)
+	require.NoError(t, err)
+	require.Empty(t, versions)
+}
@@ -8,6 +8,7 @@
 //	    Path: "/path/to/repo",
 //	})
 //	fmt.Println(result.Variables["SemVer"]) // "1.2.3"
+//	fmt.Println(result.Version.Minor)        // 2
 //
 //	result, err := sdk.CalculateRemote(sdk.RemoteOptions{
 //	    Owner: "myorg",
@@ -15,19 +16,31 @@
 //	    Token: os.Getenv("GITHUB_TOKEN"),
 //	})
 //	fmt.Println(result.Variables["FullSemVer"]) // "1.2.3+5"
+//
+// CalculateContext and CalculateRemoteContext take a context.Context that
+// bounds the whole calculation, including every GitHub API request:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+//	defer cancel()
+//	result, err := sdk.CalculateRemoteContext(ctx, opts)
 package sdk
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/MyCarrier-DevOps/go-gitsemver/internal/calculator"
 	"github.com/MyCarrier-DevOps/go-gitsemver/internal/config"
 	"github.com/MyCarrier-DevOps/go-gitsemver/internal/git"
 	"github.com/MyCarrier-DevOps/go-gitsemver/internal/output"
 	"github.com/MyCarrier-DevOps/go-gitsemver/internal/strategy"
+	"github.com/MyCarrier-DevOps/go-gitsemver/pkg/semver"
+
+	isemver "github.com/MyCarrier-DevOps/go-gitsemver/internal/semver"
 
 	configctx "github.com/MyCarrier-DevOps/go-gitsemver/internal/context"
 
@@ -49,6 +62,11 @@ type LocalOptions struct {
 	// If empty, auto-detects GitVersion.yml or go-gitsemver.yml in the repo root.
 	ConfigPath string
 
+	// ConfigOverrides are "key=value" assignments applied after the config
+	// file, e.g. "branches.main.increment=Minor". Unlike the CLI, GITSEMVER_*
+	// environment variables are not read.
+	ConfigOverrides []string
+
 	// Explain enables explain mode, populating ExplainResult on the returned Result.
 	Explain bool
 }
@@ -80,9 +98,22 @@ type RemoteOptions struct {
 	// repository's default branch.
 	Ref string
 
+	// PullRequest versions the given pull request number instead of Ref. The
+	// PR head is versioned on branch "pull/<n>/merge" as if merged into its
+	// base. Mutually exclusive with Ref.
+	PullRequest int
+
 	// MaxCommits is the hard cap on commit walk depth. Defaults to 1000.
 	MaxCommits int
 
+	// Concurrency is the number of parallel API requests used to prefetch
+	// tag peels and merge bases. Defaults to 8; 1 disables prefetching.
+	Concurrency int
+
+	// MaxRetryWait is the total time to spend waiting on GitHub rate limits
+	// and transient server errors before failing. Defaults to 2 minutes.
+	MaxRetryWait time.Duration
+
 	// Branch overrides the target branch for context resolution.
 	Branch string
 
@@ -97,12 +128,19 @@ type RemoteOptions struct {
 	// of auto-detecting from known config file names. Ignored when ConfigPath is set.
 	RemoteConfigPath string
 
+	// ConfigOverrides are "key=value" assignments applied after the config
+	// file, e.g. "branches.main.increment=Minor".
+	ConfigOverrides []string
+
 	// Explain enables explain mode, populating ExplainResult on the returned Result.
 	Explain bool
 }
 
 // Result holds the calculated version and all output variables.
 type Result struct {
+	// Version is the calculated version in typed form.
+	Version Version
+
 	// Variables contains all 30+ output variables keyed by name.
 	// Common keys: SemVer, FullSemVer, MajorMinorPatch, Major, Minor, Patch,
 	// PreReleaseTag, PreReleaseNumber, CommitsSinceVersionSource, Sha, ShortSha,
@@ -113,11 +151,47 @@ type Result struct {
 	ExplainResult *ExplainResult
 }
 
+// Version is the calculated version with the commit details behind it. It
+// describes the same version as Result.Variables.
+type Version struct {
+	// Version holds Major, Minor, Patch and PreRelease, and provides
+	// Compare and Bump.
+	semver.Version
+
+	// CommitsSinceVersionSource counts the commits since the version source.
+	CommitsSinceVersionSource int
+
+	// UncommittedChanges counts uncommitted changes in the working directory.
+	UncommittedChanges int
+
+	// Branch is the name of the branch being versioned.
+	Branch string
+
+	// Sha and ShortSha identify the versioned commit.
+	Sha      string
+	ShortSha string
+
+	// VersionSourceSha is the commit the version was calculated from, such
+	// as the tagged commit. Empty when there is none.
+	VersionSourceSha string
+
+	// CommitDate is the date of the versioned commit. Nil when unknown.
+	CommitDate *time.Time
+}
+
 // ExplainResult holds structured explain data for programmatic consumption.
 type ExplainResult struct {
+	// BranchMatches lists the branch configurations whose regex matched the
+	// branch, in selection order. The first entry is the one applied.
+	BranchMatches []ExplainBranchMatch
+
 	// Candidates lists all candidate base versions evaluated by strategies.
 	Candidates []ExplainCandidate
 
+	// IgnoredCandidates lists the candidates removed by the ignore config,
+	// with the reason in IgnoredReason.
+	IgnoredCandidates []ExplainCandidate
+
 	// SelectedSource is the human-readable name of the winning strategy.
 	SelectedSource string
 
@@ -137,6 +211,18 @@ type ExplainResult struct {
 	FormattedOutput string
 }
 
+// ExplainBranchMatch describes a branch configuration that matched the branch.
+type ExplainBranchMatch struct {
+	// Name is the branch configuration key (e.g. "feature").
+	Name string
+
+	// Regex is the branch configuration's regex.
+	Regex string
+
+	// Priority is the branch configuration's priority.
+	Priority int
+}
+
 // ExplainCandidate describes a single candidate base version from a strategy.
 type ExplainCandidate struct {
 	// Strategy is the name of the strategy that produced this candidate.
@@ -153,6 +239,17 @@ type ExplainCandidate struct {
 
 	// Steps records the reasoning chain for how the strategy derived this version.
 	Steps []string
+
+	// EffectiveVersion is the version the candidate was ranked by: Version,
+	// incremented when ShouldIncrement. Empty for ignored candidates.
+	EffectiveVersion string
+
+	// Rank is the candidate's position in the ranking, 1 for the selected
+	// candidate. Zero for ignored candidates.
+	Rank int
+
+	// IgnoredReason says why the ignore config removed the candidate.
+	IgnoredReason string
 }
 
 // configFileNames lists the files searched for configuration in order.
@@ -165,7 +262,14 @@ var configFileNames = []string{
 }
 
 // Calculate computes the next semantic version from a local git repository.
+// It is CalculateContext with context.Background().
 func Calculate(opts LocalOptions) (*Result, error) {
+	return CalculateContext(context.Background(), opts)
+}
+
+// CalculateContext is like Calculate but stops walking history and returns
+// ctx's error once ctx is cancelled or its deadline passes.
+func CalculateContext(ctx context.Context, opts LocalOptions) (*Result, error) {
 	path := opts.Path
 	if path == "" {
 		path = "."
@@ -178,29 +282,41 @@ func Calculate(opts LocalOptions) (*Result, error) {
 	}
 
 	// 2. Load configuration.
-	cfg, err := loadLocalConfig(opts.ConfigPath, repo.WorkingDirectory())
+	cfg, err := loadLocalConfig(opts.ConfigPath, repo.WorkingDirectory(), opts.ConfigOverrides)
 	if err != nil {
 		return nil, fmt.Errorf("loading configuration: %w", err)
 	}
 
 	// 3. Run the shared calculation pipeline.
-	return calculate(repo, cfg, opts.Branch, opts.Commit, opts.Explain)
+	return calculate(ctx, repo, cfg, opts.Branch, opts.Commit, opts.Explain)
 }
 
 // CalculateRemote computes the next semantic version via the GitHub API.
+// It is CalculateRemoteContext with context.Background().
 func CalculateRemote(opts RemoteOptions) (*Result, error) {
+	return CalculateRemoteContext(context.Background(), opts)
+}
+
+// CalculateRemoteContext is like CalculateRemote but makes every GitHub API
+// request with ctx, so cancelling ctx or exceeding its deadline stops the
+// calculation with ctx's error.
+func CalculateRemoteContext(ctx context.Context, opts RemoteOptions) (*Result, error) {
 	if opts.Owner == "" || opts.Repo == "" {
 		return nil, errors.New("owner and repo are required")
 	}
+	if opts.PullRequest > 0 && opts.Ref != "" {
+		return nil, errors.New("PullRequest and Ref are mutually exclusive")
+	}
 
 	// 1. Create GitHub client.
-	client, err := ghprovider.NewClient(ghprovider.ClientConfig{
+	client, err := ghprovider.NewClient(ctx, ghprovider.ClientConfig{
 		Token:      opts.Token,
 		AppID:      opts.AppID,
 		AppKey:     opts.AppKey,
 		AppKeyPath: opts.AppKeyPath,
 		BaseURL:    opts.BaseURL,
 		Owner:      opts.Owner,
+		Retry:      ghprovider.RetryConfig{MaxWait: opts.MaxRetryWait},
 	})
 	if err != nil {
 		return nil, fmt.Errorf("creating GitHub client: %w", err)
@@ -216,26 +332,41 @@ func CalculateRemote(opts RemoteOptions) (*Result, error) {
 		maxCommits = 1000
 	}
 	ghOpts = append(ghOpts, ghprovider.WithMaxCommits(maxCommits))
+	if opts.PullRequest > 0 {
+		ghOpts = append(ghOpts, ghprovider.WithPullRequest(opts.PullRequest))
+	}
+	if opts.Concurrency > 0 {
+		ghOpts = append(ghOpts, ghprovider.WithConcurrency(opts.Concurrency))
+	}
 	if opts.BaseURL != "" {
 		ghOpts = append(ghOpts, ghprovider.WithBaseURL(opts.BaseURL))
 	}
 	ghRepo := ghprovider.NewGitHubRepository(client, opts.Owner, opts.Repo, ghOpts...)
 
 	// 3. Load configuration.
-	cfg, err := loadRemoteConfig(opts.ConfigPath, opts.RemoteConfigPath, ghRepo)
+	cfg, err := loadRemoteConfig(ctx, opts.ConfigPath, opts.RemoteConfigPath, opts.ConfigOverrides, ghRepo)
 	if err != nil {
 		return nil, fmt.Errorf("loading configuration: %w", err)
 	}
 
-	// 4. Run the shared calculation pipeline.
-	return calculate(ghRepo, cfg, opts.Branch, opts.Commit, opts.Explain)
+	// 4. For pull requests, the PR head is the commit to version.
+	commit := opts.Commit
+	if commit == "" {
+		commit, err = ghRepo.PullRequestHead(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("resolving pull request: %w", err)
+		}
+	}
+
+	// 5. Run the shared calculation pipeline.
+	return calculate(ctx, ghRepo, cfg, opts.Branch, commit, opts.Explain)
 }
 
 // calculate runs the shared version calculation pipeline.
-func calculate(repo git.Repository, cfg *config.Config, branch, commit string, explain bool) (*Result, error) {
-	store := git.NewRepositoryStore(repo)
+func calculate(ctx context.Context, repo git.Repository, cfg *config.Config, branch, commit string, explain bool) (*Result, error) {
+	store := git.NewRepositoryStore(repo).WithContext(ctx)
 
-	ctx, err := configctx.NewContext(store, repo, cfg, configctx.Options{
+	gvCtx, err := configctx.NewContext(store, repo, cfg, configctx.Options{
 		TargetBranch: branch,
 		CommitID:     commit,
 	})
@@ -243,21 +374,30 @@ func calculate(repo git.Repository, cfg *config.Config, branch, commit string, e
 		return nil, fmt.Errorf("building context: %w", err)
 	}
 
-	ec, err := ctx.GetEffectiveConfiguration(ctx.CurrentBranch.FriendlyName())
+	ec, err := gvCtx.GetEffectiveConfiguration(gvCtx.CurrentBranch.FriendlyName())
 	if err != nil {
 		return nil, fmt.Errorf("resolving branch configuration: %w", err)
 	}
 
+	if p, ok := repo.(git.Prefetcher); ok {
+		if err := prefetch(ctx, p, store, gvCtx); err != nil {
+			return nil, err
+		}
+	}
+
 	strategies := strategy.AllStrategies(store)
 	calc := calculator.NewNextVersionCalculator(store, strategies)
-	result, err := calc.Calculate(ctx, ec, explain)
+	result, err := calc.Calculate(gvCtx, ec, explain)
 	if err != nil {
 		return nil, fmt.Errorf("calculating version: %w", err)
 	}
 
 	vars := output.GetVariables(result.Version, ec)
 
-	r := &Result{Variables: vars}
+	r := &Result{
+		Version:   typedVersion(output.PromotedVersion(result.Version, ec)),
+		Variables: vars,
+	}
 
 	if explain {
 		r.ExplainResult = buildExplainResult(result)
@@ -266,6 +406,43 @@ func calculate(repo git.Repository, cfg *config.Config, branch, commit string, e
 	return r, nil
 }
 
+// typedVersion converts a calculated version to the public Version type.
+func typedVersion(v isemver.SemanticVersion) Version {
+	meta := v.BuildMetaData
+	typed := Version{
+		Version: semver.Version{
+			Major:      int(v.Major),
+			Minor:      int(v.Minor),
+			Patch:      int(v.Patch),
+			PreRelease: semver.PreRelease{Label: v.PreReleaseTag.Name, Number: v.PreReleaseTag.IntNumber()},
+		},
+		CommitsSinceVersionSource: int(meta.CommitsSinceVersionSource),
+		UncommittedChanges:        int(meta.UncommittedChanges),
+		Branch:                    meta.Branch,
+		Sha:                       meta.Sha,
+		ShortSha:                  meta.ShortSha,
+		VersionSourceSha:          meta.VersionSourceSha,
+	}
+	if !meta.CommitDate.IsZero() {
+		date := meta.CommitDate
+		typed.CommitDate = &date
+	}
+	return typed
+}
+
+// prefetch warms the backend cache with the tag peels and merge bases the
+// strategies will request for the current branch.
+func prefetch(ctx context.Context, p git.Prefetcher, store *git.RepositoryStore, gvCtx *configctx.GitVersionContext) error {
+	candidates, err := store.MergeBaseCandidates(gvCtx.CurrentBranch, gvCtx.FullConfiguration)
+	if err != nil {
+		return fmt.Errorf("resolving merge base candidates: %w", err)
+	}
+	if err := p.Prefetch(ctx, gvCtx.CurrentCommit, candidates); err != nil {
+		return fmt.Errorf("prefetching repository data: %w", err)
+	}
+	return nil
+}
+
 // buildExplainResult maps internal calculator.VersionResult to the public ExplainResult.
 func buildExplainResult(result calculator.VersionResult) *ExplainResult {
 	er := &ExplainResult{
@@ -286,8 +463,19 @@ func buildExplainResult(result calculator.VersionResult) *ExplainResult {
 		}
 	}
 
+	// Map branch config matches.
+	if result.BranchConfig != nil {
+		for _, m := range result.BranchConfig.Matches {
+			er.BranchMatches = append(er.BranchMatches, ExplainBranchMatch{
+				Name:     m.Name,
+				Regex:    m.Regex,
+				Priority: m.Priority,
+			})
+		}
+	}
+
 	// Map candidates.
-	for _, c := range result.AllCandidates {
+	for i, c := range result.AllCandidates {
 		ec := ExplainCandidate{
 			Version:         c.SemanticVersion.SemVer(),
 			ShouldIncrement: c.ShouldIncrement,
@@ -301,14 +489,25 @@ func buildExplainResult(result calculator.VersionResult) *ExplainResult {
 			ec.Strategy = c.Explanation.Strategy
 			ec.Steps = c.Explanation.Steps
 		}
+		if i < len(result.Evaluations) {
+			ev := result.Evaluations[i]
+			ec.IgnoredReason = ev.IgnoredReason
+			if ev.Rank > 0 {
+				ec.EffectiveVersion = ev.EffectiveVersion.SemVer()
+				ec.Rank = ev.Rank
+			}
+		}
 		er.Candidates = append(er.Candidates, ec)
+		if ec.IgnoredReason != "" {
+			er.IgnoredCandidates = append(er.IgnoredCandidates, ec)
+		}
 	}
 
 	return er
 }
 
 // loadLocalConfig loads configuration from a file path or auto-detects it.
-func loadLocalConfig(configPath, workDir string) (*config.Config, error) {
+func loadLocalConfig(configPath, workDir string, overrides []string) (*config.Config, error) {
 	builder := config.NewBuilder()
 
 	if configPath == "" {
@@ -316,14 +515,23 @@ func loadLocalConfig(configPath, workDir string) (*config.Config, error) {
 	}
 
 	if configPath != "" {
-		userCfg, err := config.LoadFromFile(configPath)
+		layers, err := config.LoadLayers(configPath, nil)
 		if err != nil {
 			return nil, err
 		}
-		builder.Add(userCfg)
+		builder.AddLayers(layers)
 	}
 
-	return builder.Build()
+	return buildWithOverrides(builder, overrides)
+}
+
+// buildWithOverrides adds overrides as the final layer and builds.
+func buildWithOverrides(builder *config.Builder, overrides []string) (*config.Config, error) {
+	set, err := config.ParseOverrides(overrides)
+	if err != nil {
+		return nil, err
+	}
+	return builder.AddLayer("overrides", set).Build()
 }
 
 // findConfigFile searches for a config file in the given directory.
@@ -340,45 +548,48 @@ func findConfigFile(dir string) string {
 // loadRemoteConfig loads configuration from a local override or the remote repo.
 // When remoteConfigPath is set, that specific file is fetched from the remote repo
 // instead of auto-detecting from known config file names.
-func loadRemoteConfig(configPath, remoteConfigPath string, ghRepo *ghprovider.GitHubRepository) (*config.Config, error) {
+func loadRemoteConfig(ctx context.Context, configPath, remoteConfigPath string, overrides []string, ghRepo *ghprovider.GitHubRepository) (*config.Config, error) {
 	builder := config.NewBuilder()
+	read := func(path string) ([]byte, error) {
+		content, err := ghRepo.FetchFileContent(ctx, path)
+		if err != nil {
+			return nil, fmt.Errorf("fetching remote config %s: %w", path, err)
+		}
+		return []byte(content), nil
+	}
 
 	if configPath != "" {
 		// Use explicit local config file.
-		userCfg, err := config.LoadFromFile(configPath)
+		layers, err := config.LoadLayers(configPath, nil)
 		if err != nil {
 			return nil, err
 		}
-		builder.Add(userCfg)
+		builder.AddLayers(layers)
 	} else if remoteConfigPath != "" {
 		// Fetch a specific config file from the remote repo.
-		content, err := ghRepo.FetchFileContent(remoteConfigPath)
-		if err != nil {
-			return nil, fmt.Errorf("fetching remote config %s: %w", remoteConfigPath, err)
-		}
-		userCfg, err := config.LoadFromBytes([]byte(content))
+		layers, err := config.LoadLayers(remoteConfigPath, read)
 		if err != nil {
-			return nil, fmt.Errorf("parsing remote config %s: %w", remoteConfigPath, err)
+			return nil, err
 		}
-		builder.Add(userCfg)
+		builder.AddLayers(layers)
 	} else {
 		// Auto-detect: try known config file names in the remote repo.
 		for _, name := range configFileNames {
-			content, err := ghRepo.FetchFileContent(name)
+			content, err := ghRepo.FetchFileContent(ctx, name)
 			if err != nil {
 				if ghprovider.IsNotFoundError(err) {
 					continue
 				}
 				return nil, fmt.Errorf("fetching remote config %s: %w", name, err)
 			}
-			userCfg, err := config.LoadFromBytes([]byte(content))
+			layers, err := config.LoadLayersFromBytes(name, []byte(content), read)
 			if err != nil {
 				return nil, fmt.Errorf("parsing remote config %s: %w", name, err)
 			}
-			builder.Add(userCfg)
+			builder.AddLayers(layers)
 			break
 		}
 	}
 
-	return builder.Build()
+	return buildWithOverrides(builder, overrides)
 }
@@ -1,16 +1,20 @@
 package sdk_test
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/MyCarrier-DevOps/go-gitsemver/internal/testutil"
 	"github.com/MyCarrier-DevOps/go-gitsemver/pkg/sdk"
+	"github.com/MyCarrier-DevOps/go-gitsemver/pkg/semver"
 
 	gh "github.com/google/go-github/v68/github"
 	"github.com/stretchr/testify/require"
@@ -31,6 +35,16 @@ func TestCalculate_BasicRepo(t *testing.T) {
 	require.NotEmpty(t, result.Variables["MajorMinorPatch"])
 }
 
+func TestCalculateContext_Cancelled(t *testing.T) {
+	repo := testutil.NewTestRepo(t)
+	repo.AddCommit("initial commit")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := sdk.CalculateContext(ctx, sdk.LocalOptions{Path: repo.Path()})
+	require.ErrorIs(t, err, context.Canceled)
+}
+
 func TestCalculate_WithTag(t *testing.T) {
 	repo := testutil.NewTestRepo(t)
 	sha := repo.AddCommit("initial commit")
@@ -104,6 +118,75 @@ func TestCalculate_AutoDetectsConfig(t *testing.T) {
 	require.Equal(t, "7.0.0", result.Variables["MajorMinorPatch"])
 }
 
+func TestCalculate_ConfigOverrides(t *testing.T) {
+	repo := testutil.NewTestRepo(t)
+	repo.AddCommit("initial commit")
+	repo.WriteConfig("next-version: 7.0.0\n")
+
+	result, err := sdk.Calculate(sdk.LocalOptions{
+		Path:            repo.Path(),
+		ConfigOverrides: []string{"next-version=8.0.0"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "8.0.0", result.Variables["MajorMinorPatch"])
+
+	_, err = sdk.Calculate(sdk.LocalOptions{
+		Path:            repo.Path(),
+		ConfigOverrides: []string{"next-versoin=8.0.0"},
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unknown config key")
+}
+
+func TestCalculate_TypedVersion(t *testing.T) {
+	repo := testutil.NewTestRepo(t)
+	sha := repo.AddCommit("initial commit")
+	repo.CreateTag("v1.0.0", sha)
+	repo.CreateBranch("develop", sha)
+	repo.Checkout("develop")
+	repo.AddCommit("feature work")
+	head := repo.AddCommit("more work")
+
+	result, err := sdk.Calculate(sdk.LocalOptions{Path: repo.Path()})
+	require.NoError(t, err)
+
+	v := result.Version
+	require.Equal(t, result.Variables["SemVer"], v.String())
+	require.Equal(t, result.Variables["Major"], strconv.Itoa(v.Major))
+	require.Equal(t, result.Variables["Minor"], strconv.Itoa(v.Minor))
+	require.Equal(t, result.Variables["Patch"], strconv.Itoa(v.Patch))
+	require.Equal(t, result.Variables["PreReleaseLabel"], v.PreRelease.Label)
+	require.Equal(t, "alpha", v.PreRelease.Label)
+	require.NotNil(t, v.PreRelease.Number)
+	require.Equal(t, result.Variables["PreReleaseNumber"], strconv.Itoa(*v.PreRelease.Number))
+	require.Equal(t, 2, v.CommitsSinceVersionSource)
+	require.Equal(t, "develop", v.Branch)
+	require.Equal(t, head, v.Sha)
+	require.Equal(t, result.Variables["ShortSha"], v.ShortSha)
+	require.Equal(t, sha, v.VersionSourceSha)
+	require.NotNil(t, v.CommitDate)
+	require.Equal(t, result.Variables["CommitDate"], v.CommitDate.Format("2006-01-02"))
+	require.Positive(t, v.Compare(semver.MustParse("1.0.0")))
+	require.Equal(t, "2.0.0", v.Bump(semver.Major).String())
+}
+
+func TestCalculate_TypedVersionContinuousDeployment(t *testing.T) {
+	repo := testutil.NewTestRepo(t)
+	sha := repo.AddCommit("initial commit")
+	repo.CreateTag("v1.0.0", sha)
+	repo.AddCommit("fix: typo")
+
+	result, err := sdk.Calculate(sdk.LocalOptions{
+		Path:            repo.Path(),
+		ConfigOverrides: []string{"branches.main.mode=ContinuousDeployment"},
+	})
+	require.NoError(t, err)
+
+	// The typed version carries the promoted pre-release, as Variables do.
+	require.Equal(t, result.Variables["SemVer"], result.Version.String())
+	require.Equal(t, result.Variables["PreReleaseNumber"], strconv.Itoa(*result.Version.PreRelease.Number))
+}
+
 func TestCalculate_InvalidConfigPath(t *testing.T) {
 	repo := testutil.NewTestRepo(t)
 	repo.AddCommit("initial commit")
@@ -343,6 +426,120 @@ func TestCalculateRemote_WithMockServer(t *testing.T) {
 	require.NotEmpty(t, result.Variables["SemVer"])
 }
 
+func TestCalculateRemoteContext_Deadline(t *testing.T) {
+	// Every request hangs until the client gives up.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := sdk.CalculateRemoteContext(ctx, sdk.RemoteOptions{
+		Owner:   "testowner",
+		Repo:    "testrepo",
+		Token:   "ghp_test",
+		BaseURL: server.URL + "/api/v3",
+	})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestCalculateRemote_PullRequestAndRefExclusive(t *testing.T) {
+	_, err := sdk.CalculateRemote(sdk.RemoteOptions{
+		Owner:       "myorg",
+		Repo:        "myrepo",
+		Token:       "ghp_test",
+		Ref:         "main",
+		PullRequest: 5,
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "mutually exclusive")
+}
+
+func TestCalculateRemote_PullRequest(t *testing.T) {
+	const (
+		mainSha = "aaa1111111111111111111111111111111111111"
+		headSha = "bbb2222222222222222222222222222222222222"
+	)
+	commitNode := func(sha, msg, date string, parents ...string) map[string]interface{} {
+		nodes := []map[string]interface{}{}
+		for _, p := range parents {
+			nodes = append(nodes, map[string]interface{}{"oid": p})
+		}
+		return map[string]interface{}{
+			"__typename":    "Commit",
+			"oid":           sha,
+			"message":       msg,
+			"committedDate": date,
+			"parents":       map[string]interface{}{"nodes": nodes},
+		}
+	}
+	mainNode := commitNode(mainSha, "initial commit", "2025-01-15T12:00:00Z")
+	headNode := commitNode(headSha, "feat: add login", "2025-01-16T12:00:00Z", mainSha)
+	refs := func(nodes []map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{"data": map[string]interface{}{"repository": map[string]interface{}{
+			"refs": map[string]interface{}{"nodes": nodes, "pageInfo": map[string]interface{}{"hasNextPage": false, "endCursor": ""}},
+		}}}
+	}
+	history := func(nodes ...map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{"data": map[string]interface{}{"repository": map[string]interface{}{
+			"object": map[string]interface{}{"history": map[string]interface{}{
+				"nodes": nodes, "pageInfo": map[string]interface{}{"hasNextPage": false, "endCursor": ""},
+			}},
+		}}}
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v3/repos/testowner/testrepo/pulls/42", func(w http.ResponseWriter, r *http.Request) {
+		writeTestJSON(w, map[string]interface{}{
+			"number": 42,
+			"head":   map[string]interface{}{"ref": "feature/login", "sha": headSha},
+			"base":   map[string]interface{}{"ref": "main", "sha": mainSha},
+		})
+	})
+	mux.HandleFunc("/api/v3/repos/testowner/testrepo/contents/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		writeTestJSON(w, map[string]interface{}{"message": "Not Found"})
+	})
+	mux.HandleFunc("/api/graphql", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		switch {
+		case strings.Contains(body.Query, "refs/heads/"):
+			writeTestJSON(w, refs([]map[string]interface{}{
+				{"name": "main", "target": mainNode},
+				{"name": "feature/login", "target": headNode},
+			}))
+		case strings.Contains(body.Query, "refs/tags/"):
+			writeTestJSON(w, refs([]map[string]interface{}{
+				{"name": "v1.0.0", "target": mainNode},
+			}))
+		case body.Variables["expr"] == headSha:
+			writeTestJSON(w, history(headNode, mainNode))
+		default:
+			writeTestJSON(w, history(mainNode))
+		}
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	result, err := sdk.CalculateRemote(sdk.RemoteOptions{
+		Owner:       "testowner",
+		Repo:        "testrepo",
+		Token:       "ghp_test",
+		BaseURL:     server.URL + "/api/v3",
+		PullRequest: 42,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "pull/42/merge", result.Variables["BranchName"])
+	require.Equal(t, headSha, result.Variables["Sha"])
+	require.Contains(t, result.Variables["SemVer"], "PullRequest42")
+}
+
 func TestCalculateRemote_WithRemoteConfigPath(t *testing.T) {
 	mux := http.NewServeMux()
 	tipSha := "abc123def456abc123def456abc123def456abc1"
@@ -502,6 +699,38 @@ func TestCalculate_ExplainCandidateDetails(t *testing.T) {
 		"should have at least TaggedCommit or Fallback candidate")
 }
 
+func TestCalculate_ExplainIgnoredCandidates(t *testing.T) {
+	repo := testutil.NewTestRepo(t)
+	first := repo.AddCommit("initial")
+	repo.CreateTag("v1.0.0", first)
+	bad := repo.AddCommit("mistake")
+	repo.CreateTag("v9.0.0", bad)
+	repo.AddCommit("fix: typo")
+	repo.WriteConfig("ignore:\n  sha: [" + bad + "]\n")
+
+	result, err := sdk.Calculate(sdk.LocalOptions{
+		Path:    repo.Path(),
+		Explain: true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "1.0.1", result.Variables["MajorMinorPatch"])
+
+	er := result.ExplainResult
+	require.Len(t, er.IgnoredCandidates, 1)
+	require.Equal(t, "9.0.0", er.IgnoredCandidates[0].Version)
+	require.Equal(t, "source commit "+bad[:7]+" is listed in ignore.sha", er.IgnoredCandidates[0].IgnoredReason)
+	require.Contains(t, er.FormattedOutput, "ignored: source commit "+bad[:7])
+
+	ranks := map[int]bool{}
+	for _, c := range er.Candidates {
+		if c.IgnoredReason == "" {
+			require.NotEmpty(t, c.EffectiveVersion)
+			ranks[c.Rank] = true
+		}
+	}
+	require.True(t, ranks[1])
+}
+
 func TestCalculate_ExplainIncrementSteps(t *testing.T) {
 	repo := testutil.NewTestRepo(t)
 	sha := repo.AddCommit("initial")
@@ -532,6 +761,14 @@ func TestCalculate_ExplainFeatureBranch(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, result.ExplainResult)
 	require.NotEmpty(t, result.ExplainResult.PreReleaseSteps)
+
+	// feature and the catch-all unknown config both match; feature wins.
+	require.Equal(t, []sdk.ExplainBranchMatch{
+		{Name: "feature", Regex: `^features?[/-]`, Priority: 50},
+		{Name: "unknown", Regex: `.*`, Priority: 0},
+	}, result.ExplainResult.BranchMatches)
+	require.Equal(t, "feature", result.Variables["BranchConfigName"])
+	require.Contains(t, result.ExplainResult.FormattedOutput, `"feature/search" matched 2 branch configs`)
 }
 
 func TestCalculateRemote_ExplainEnabled(t *testing.T) {
@@ -0,0 +1,150 @@
+// Package semver provides the semantic version type returned by the sdk
+// package, with the parsing, ordering and increment rules go-gitsemver
+// uses, so consumers need no semver library of their own.
+//
+//	v, err := semver.Parse("v1.2.3-beta.4")
+//	next := v.Bump(semver.Minor)         // 1.3.0
+//	newer := next.Compare(v) > 0         // true
+package semver
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	isemver "github.com/MyCarrier-DevOps/go-gitsemver/internal/semver"
+)
+
+// Field is a version field that Bump increments.
+type Field int
+
+const (
+	Patch Field = iota + 1
+	Minor
+	Major
+)
+
+// String returns the field name.
+func (f Field) String() string {
+	switch f {
+	case Patch:
+		return "Patch"
+	case Minor:
+		return "Minor"
+	case Major:
+		return "Major"
+	default:
+		return "Field(" + strconv.Itoa(int(f)) + ")"
+	}
+}
+
+// PreRelease is the pre-release part of a version, such as "beta.4".
+type PreRelease struct {
+	// Label is the pre-release name, e.g. "beta". Empty for a tag that is
+	// only a number.
+	Label string
+
+	// Number is the pre-release number, e.g. 4. Nil when the tag has none.
+	Number *int
+}
+
+// IsZero reports whether there is no pre-release, i.e. the version is stable.
+func (p PreRelease) IsZero() bool {
+	return p.Label == "" && p.Number == nil
+}
+
+// String returns the pre-release as it appears after the dash, e.g. "beta.4".
+func (p PreRelease) String() string {
+	return p.internal().String()
+}
+
+func (p PreRelease) internal() isemver.PreReleaseTag {
+	tag := isemver.PreReleaseTag{Name: p.Label}
+	if p.Number != nil {
+		n := int64(*p.Number)
+		tag.Number = &n
+	}
+	return tag
+}
+
+// Version is a semantic version without build metadata.
+type Version struct {
+	Major      int
+	Minor      int
+	Patch      int
+	PreRelease PreRelease
+}
+
+// Parse parses a version such as "1.2.3", "v1.2.3-beta.4" or "1.2". Missing
+// minor and patch numbers are zero, and build metadata is ignored. More than
+// three numbers, as in "1.2.3.4", is an error.
+func Parse(s string) (Version, error) {
+	// Tags may carry a fourth number, which the tag parser drops; a
+	// version given to Parse may not.
+	core, _, _ := strings.Cut(s, "+")
+	core, _, _ = strings.Cut(core, "-")
+	if strings.Count(core, ".") > 2 {
+		return Version{}, errors.New("invalid version format: " + s)
+	}
+
+	v, err := isemver.Parse(s, "[vV]?")
+	if err != nil {
+		return Version{}, err
+	}
+	return fromInternal(v), nil
+}
+
+// MustParse is like Parse but panics if s is not a valid version.
+func MustParse(s string) Version {
+	v, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Compare returns a negative number when v sorts before other, zero when
+// they are equal, and a positive number otherwise. A stable version sorts
+// after its pre-releases; pre-releases compare by label, ignoring case,
+// then by number.
+func (v Version) Compare(other Version) int {
+	return v.internal().CompareTo(other.internal())
+}
+
+// Bump returns v with field incremented, the lower fields zeroed and the
+// pre-release removed. Any other field returns v unchanged.
+func (v Version) Bump(field Field) Version {
+	switch field {
+	case Major:
+		return Version{Major: v.Major + 1}
+	case Minor:
+		return Version{Major: v.Major, Minor: v.Minor + 1}
+	case Patch:
+		return Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch + 1}
+	default:
+		return v
+	}
+}
+
+// String returns the version in SemVer 2.0 form, e.g. "1.2.3-beta.4".
+func (v Version) String() string {
+	return v.internal().SemVer()
+}
+
+func (v Version) internal() isemver.SemanticVersion {
+	return isemver.SemanticVersion{
+		Major:         int64(v.Major),
+		Minor:         int64(v.Minor),
+		Patch:         int64(v.Patch),
+		PreReleaseTag: v.PreRelease.internal(),
+	}
+}
+
+func fromInternal(v isemver.SemanticVersion) Version {
+	return Version{
+		Major:      int(v.Major),
+		Minor:      int(v.Minor),
+		Patch:      int(v.Patch),
+		PreRelease: PreRelease{Label: v.PreReleaseTag.Name, Number: v.PreReleaseTag.IntNumber()},
+	}
+}
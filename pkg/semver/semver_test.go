@@ -0,0 +1,77 @@
+package semver_test
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/MyCarrier-DevOps/go-gitsemver/pkg/semver"
+
+	"github.com/stretchr/testify/require"
+)
+
+func intPtr(n int) *int { return &n }
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want semver.Version
+	}{
+		{"1.2.3", semver.Version{Major: 1, Minor: 2, Patch: 3}},
+		{"v1.2.3", semver.Version{Major: 1, Minor: 2, Patch: 3}},
+		{"1.2", semver.Version{Major: 1, Minor: 2}},
+		{"1.2.3-beta.4", semver.Version{Major: 1, Minor: 2, Patch: 3, PreRelease: semver.PreRelease{Label: "beta", Number: intPtr(4)}}},
+		{"1.2.3-rc", semver.Version{Major: 1, Minor: 2, Patch: 3, PreRelease: semver.PreRelease{Label: "rc"}}},
+		{"1.2.3+5", semver.Version{Major: 1, Minor: 2, Patch: 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			v, err := semver.Parse(tt.in)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, v)
+		})
+	}
+
+	_, err := semver.Parse("not-a-version")
+	require.Error(t, err)
+	for _, s := range []string{"1.2.3.4", "v1.2.3.4-beta.1", "1.2.3.4+5"} {
+		_, err = semver.Parse(s)
+		require.ErrorContains(t, err, "invalid version format", s)
+	}
+	require.Panics(t, func() { semver.MustParse("x") })
+}
+
+func TestVersion_String(t *testing.T) {
+	require.Equal(t, "1.2.3", semver.MustParse("v1.2.3").String())
+	require.Equal(t, "1.2.3-beta.4", semver.MustParse("1.2.3-beta.4").String())
+	require.Equal(t, "beta.4", semver.MustParse("1.2.3-beta.4").PreRelease.String())
+	require.True(t, semver.MustParse("1.2.3").PreRelease.IsZero())
+}
+
+func TestVersion_Compare(t *testing.T) {
+	ordered := []string{"1.0.0-alpha.1", "1.0.0-alpha.2", "1.0.0-beta.1", "1.0.0", "1.0.1", "1.1.0", "2.0.0"}
+	versions := make([]semver.Version, len(ordered))
+	for i, s := range ordered {
+		versions[len(ordered)-1-i] = semver.MustParse(s)
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i].Compare(versions[j]) < 0 })
+	for i, v := range versions {
+		require.Equal(t, ordered[i], v.String())
+	}
+
+	require.Zero(t, semver.MustParse("1.0.0-Beta.1").Compare(semver.MustParse("1.0.0-beta.1")))
+}
+
+func TestVersion_Bump(t *testing.T) {
+	v := semver.MustParse("1.2.3-beta.4")
+	require.Equal(t, "2.0.0", v.Bump(semver.Major).String())
+	require.Equal(t, "1.3.0", v.Bump(semver.Minor).String())
+	require.Equal(t, "1.2.4", v.Bump(semver.Patch).String())
+	require.Equal(t, v, v.Bump(semver.Field(0)))
+}
+
+func TestField_String(t *testing.T) {
+	require.Equal(t, "Major", semver.Major.String())
+	require.Equal(t, "Minor", semver.Minor.String())
+	require.Equal(t, "Patch", semver.Patch.String())
+	require.Equal(t, "Field(9)", semver.Field(9).String())
+}